@@ -4,6 +4,7 @@
 package main
 
 import (
+	"errors"
 	"os"
 	"os/exec"
 	"path"
@@ -11,9 +12,10 @@ import (
 
 	"github.com/urfave/cli/v2"
 
-	"github.com/tundrik/tg/pkg/generator"
-	"github.com/tundrik/tg/pkg/logger"
-	"github.com/tundrik/tg/pkg/skeleton"
+	"github.com/tundrik/tg/v2/pkg/generator"
+	"github.com/tundrik/tg/v2/pkg/importer"
+	"github.com/tundrik/tg/v2/pkg/logger"
+	"github.com/tundrik/tg/v2/pkg/skeleton"
 )
 
 var (
@@ -116,6 +118,18 @@ func main() {
 					Name:  "outSwagger",
 					Usage: "path to output swagger file",
 				},
+				&cli.StringFlag{
+					Name:  "openapi",
+					Usage: "version of OpenAPI specification (3.0 or 3.1)",
+				},
+				&cli.StringFlag{
+					Name:  "outOpenRPC",
+					Usage: "path to output OpenRPC file",
+				},
+				&cli.BoolFlag{
+					Name:  "embedSpec",
+					Usage: "embed swagger and documentation page to transport package",
+				},
 				&cli.StringFlag{
 					Name:  "redoc",
 					Usage: "path to output redoc bundle",
@@ -170,11 +184,57 @@ func main() {
 					Value: false,
 					Usage: "enable js client with package manifest",
 				},
+				&cli.BoolFlag{
+					Name:  "ts",
+					Value: false,
+					Usage: "enable typescript client with package manifest",
+				},
+				&cli.BoolFlag{
+					Name:  "python",
+					Value: false,
+					Usage: "enable python client with package manifest",
+				},
+				&cli.BoolFlag{
+					Name:  "cli",
+					Value: false,
+					Usage: "enable command-line client built on go client",
+				},
+				&cli.StringFlag{
+					Name:  "module",
+					Usage: "go client module path, enables standalone go.mod with copied exchange types",
+				},
+				&cli.StringFlag{
+					Name:  "moduleVersion",
+					Usage: "go client module version",
+				},
 			},
 
 			UsageText:   "tg client --services ./pkg/someService/service",
 			Description: "generate services transport layer by interfaces",
 		},
+		{
+			Name:   "mocks",
+			Usage:  "generate mocks and in-process server by interfaces in 'service' package",
+			Action: cmdMocks,
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  "services",
+					Value: "./pkg/someService/service",
+					Usage: "path to services package",
+				},
+				&cli.StringFlag{
+					Name:  "transport",
+					Usage: "path to generated transport package",
+				},
+				&cli.StringFlag{
+					Name:  "out",
+					Usage: "path to output folder",
+				},
+			},
+
+			UsageText:   "tg mocks --services ./pkg/someService/service",
+			Description: "generate mocks with expectations and server harness mounting real transport",
+		},
 		{
 			Name:   "swagger",
 			Usage:  "generate swagger documentation by interfaces in 'service' package",
@@ -193,6 +253,10 @@ func main() {
 					Name:  "iface",
 					Usage: "interfaces included to swagger",
 				},
+				&cli.StringFlag{
+					Name:  "openapi",
+					Usage: "version of OpenAPI specification (3.0 or 3.1)",
+				},
 				&cli.StringFlag{
 					Name:  "redoc",
 					Usage: "path to output redoc bundle",
@@ -202,6 +266,69 @@ func main() {
 			UsageText:   "tg swagger --iface firstIface --iface secondIface",
 			Description: "generate swagger documentation by interfaces",
 		},
+		{
+			Name:   "openrpc",
+			Usage:  "generate OpenRPC documentation by interfaces in 'service' package",
+			Action: cmdOpenRPC,
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  "services",
+					Value: "./pkg/someService/service",
+					Usage: "path to services package",
+				},
+				&cli.StringFlag{
+					Name:  "outFile",
+					Usage: "path to output file",
+				},
+			},
+
+			UsageText:   "tg openrpc --services ./pkg/someService/service",
+			Description: "generate OpenRPC documentation by jsonRPC interfaces",
+		},
+		{
+			Name:   "collection",
+			Usage:  "generate Postman, Insomnia and .http request collections by interfaces in 'service' package",
+			Action: cmdCollection,
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  "services",
+					Value: "./pkg/someService/service",
+					Usage: "path to services package",
+				},
+				&cli.StringFlag{
+					Name:  "out",
+					Usage: "path to output folder",
+				},
+				&cli.StringSliceFlag{
+					Name:  "format",
+					Usage: "collection formats: postman, insomnia, http (all by default)",
+				},
+			},
+
+			UsageText:   "tg collection --services ./pkg/someService/service --format postman",
+			Description: "generate request collections grouped by services",
+		},
+		{
+			Name:  "import",
+			Usage: "generate services by API specifications",
+			Subcommands: []*cli.Command{
+				{
+					Name:   "openapi",
+					Usage:  "generate types and annotated service interfaces by OpenAPI document",
+					Action: cmdImportOpenAPI,
+					Flags: []cli.Flag{
+						&cli.StringFlag{
+							Name:  "out",
+							Value: "./pkg/api",
+							Usage: "path to output folder with 'service' and 'types' packages",
+						},
+					},
+
+					UsageText:   "tg import openapi --out ./pkg/partner spec.yaml",
+					Description: "generate types of components and service interface for each tag of OpenAPI document",
+				},
+			},
+		},
 	}
 	err := app.Run(os.Args)
 	if err != nil {
@@ -236,13 +363,24 @@ func cmdClient(c *cli.Context) (err error) {
 	if tr, err = generator.NewTransport(log, c.String("services")); err != nil {
 		return
 	}
-	if c.Bool("go") {
+	if c.Bool("go") || c.Bool("cli") {
 		if err = tr.RenderClient(c.String("outPath")); err != nil {
 			return
 		}
+		if c.Bool("cli") {
+			if err = tr.RenderClientCLI(c.String("outPath"), c.String("module")); err != nil {
+				return
+			}
+		}
+		if err = tr.RenderClientModule(c.String("outPath"), c.String("module"), c.String("moduleVersion")); err != nil {
+			return
+		}
 	}
-	if c.Bool("js") {
-		if err = tr.RenderClientJS(c.String("outPath")); err != nil {
+	if err = tr.RenderClientsJS(c.String("outPath"), c.Bool("js"), c.Bool("ts")); err != nil {
+		return
+	}
+	if c.Bool("python") {
+		if err = tr.RenderClientPython(c.String("outPath")); err != nil {
 			return
 		}
 	}
@@ -273,7 +411,19 @@ func cmdTransport(c *cli.Context) (err error) {
 		return
 	}
 	if c.String("outSwagger") != "" {
-		err = tr.RenderSwagger(c.String("outSwagger"))
+		if err = tr.RenderSwagger(c.String("outSwagger"), c.String("openapi")); err != nil {
+			return
+		}
+	}
+	if c.String("outOpenRPC") != "" {
+		if err = tr.RenderOpenRPC(c.String("outOpenRPC")); err != nil {
+			return
+		}
+	}
+	if c.Bool("embedSpec") {
+		if err = tr.RenderSpec(outPath, c.String("openapi"), c.String("outOpenRPC") != ""); err != nil {
+			return
+		}
 	}
 	if c.String("redoc") != "" {
 		var output []byte
@@ -285,6 +435,29 @@ func cmdTransport(c *cli.Context) (err error) {
 	return
 }
 
+func cmdMocks(c *cli.Context) (err error) {
+
+	defer func() {
+		if err == nil {
+			log.Info("done")
+		}
+	}()
+	var tr generator.Transport
+	if tr, err = generator.NewTransport(log, c.String("services")); err != nil {
+		return
+	}
+	basePath, _ := path.Split(c.String("services"))
+	outPath := path.Join(basePath, "mocks")
+	if c.String("out") != "" {
+		outPath = c.String("out")
+	}
+	transportPath := path.Join(basePath, "transport")
+	if c.String("transport") != "" {
+		transportPath = c.String("transport")
+	}
+	return tr.RenderMocks(outPath, transportPath)
+}
+
 func cmdSwagger(c *cli.Context) (err error) {
 
 	defer func() {
@@ -303,7 +476,7 @@ func cmdSwagger(c *cli.Context) (err error) {
 	if c.String("outFile") != "" {
 		outPath = c.String("outFile")
 	}
-	if err = tr.RenderSwagger(outPath); err == nil {
+	if err = tr.RenderSwagger(outPath, c.String("openapi")); err == nil {
 		if c.String("redoc") != "" {
 			var output []byte
 			log.Infof("write to %s", c.String("redoc"))
@@ -315,6 +488,27 @@ func cmdSwagger(c *cli.Context) (err error) {
 	return
 }
 
+func cmdOpenRPC(c *cli.Context) (err error) {
+
+	defer func() {
+		if err == nil {
+			log.Info("done")
+		}
+	}()
+
+	var tr generator.Transport
+	if tr, err = generator.NewTransport(log, c.String("services")); err != nil {
+		return
+	}
+
+	outPath := path.Join(c.String("services"), "openrpc.json")
+
+	if c.String("outFile") != "" {
+		outPath = c.String("outFile")
+	}
+	return tr.RenderOpenRPC(outPath)
+}
+
 func cmdAzure(c *cli.Context) (err error) {
 
 	defer func() {
@@ -332,3 +526,37 @@ func cmdAzure(c *cli.Context) (err error) {
 	}
 	return tr.RenderAzure(c.String("appName"), c.String("routePrefix"), outPath, c.String("logLevel"), c.Bool("enableHealth"))
 }
+
+func cmdCollection(c *cli.Context) (err error) {
+
+	defer func() {
+		if err == nil {
+			log.Info("done")
+		}
+	}()
+
+	var tr generator.Transport
+	if tr, err = generator.NewTransport(log, c.String("services")); err != nil {
+		return
+	}
+
+	outPath := path.Join(c.String("services"), "collection")
+
+	if c.String("out") != "" {
+		outPath = c.String("out")
+	}
+	return tr.RenderCollection(outPath, c.StringSlice("format")...)
+}
+
+func cmdImportOpenAPI(c *cli.Context) (err error) {
+
+	defer func() {
+		if err == nil {
+			log.Info("done")
+		}
+	}()
+	if c.Args().First() == "" {
+		return errors.New("path to OpenAPI document is expected")
+	}
+	return importer.ImportOpenAPI(log, c.Args().First(), c.String("out"))
+}
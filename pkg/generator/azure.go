@@ -6,8 +6,9 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"strconv"
 
-	"github.com/tundrik/tg/pkg/utils"
+	"github.com/tundrik/tg/v2/pkg/utils"
 )
 
 type azure struct {
@@ -26,33 +27,39 @@ func (app *azure) render(appName, routePrefix, outFilePath, logLevel string, ena
 
 	for svcName, svc := range app.services {
 		for _, svcMethod := range svc.methods {
-			route := svcMethod.httpPath(false)
+			routes := []string{svcMethod.httpPath(false)}
 			if svcMethod.isJsonRPC() {
-				route = svcMethod.jsonrpcPath(false)
+				routes = svcMethod.jsonrpcPaths(false)
 			}
-			fn := azureFunc{
-				Bindings: []azureBindings{
-					{
-						Name:      "req",
-						Direction: "in",
-						AuthLevel: "anonymous",
-						Type:      "httpTrigger",
-						Route:     route,
-						Methods:   []string{"head", "options", svcMethod.httpMethod()},
+			for i, route := range routes {
+				fn := azureFunc{
+					Bindings: []azureBindings{
+						{
+							Name:      "req",
+							Direction: "in",
+							AuthLevel: "anonymous",
+							Type:      "httpTrigger",
+							Route:     route,
+							Methods:   []string{"head", "options", svcMethod.httpMethod()},
+						},
+						{
+							Name:      "res",
+							Type:      "http",
+							Direction: "out",
+						},
 					},
-					{
-						Name:      "res",
-						Type:      "http",
-						Direction: "out",
-					},
-				},
-			}
-			outFileName := path.Join(outFilePath, appName, utils.ToLowerCamel(svcName)+svcMethod.Name, "function.json")
-			if err = os.MkdirAll(filepath.Dir(outFileName), 0777); err != nil {
-				return
-			}
-			if err = ioutil.WriteFile(outFileName, toJSON(fn), 0600); err != nil {
-				return
+				}
+				fnName := utils.ToLowerCamel(svcName) + svcMethod.Name
+				if i > 0 {
+					fnName += strconv.Itoa(i + 1)
+				}
+				outFileName := path.Join(outFilePath, appName, fnName, "function.json")
+				if err = os.MkdirAll(filepath.Dir(outFileName), 0777); err != nil {
+					return
+				}
+				if err = ioutil.WriteFile(outFileName, toJSON(fn), 0600); err != nil {
+					return
+				}
 			}
 		}
 	}
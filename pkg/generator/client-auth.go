@@ -0,0 +1,253 @@
+package generator
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+
+	. "github.com/dave/jennifer/jen"
+
+	"github.com/tundrik/tg/v2/pkg/utils"
+)
+
+const (
+	packageHMAC   = "crypto/hmac"
+	packageSHA256 = "crypto/sha256"
+	packageHex    = "encoding/hex"
+	packageBase64 = "encoding/base64"
+
+	tokenExpiryDelta = 10
+)
+
+func (tr Transport) renderClientAuth(outDir string) (err error) {
+
+	srcFile := newSrc(filepath.Base(outDir))
+	srcFile.PackageComment(doNotEdit)
+
+	srcFile.ImportName(packageJson, "json")
+
+	srcFile.Line().Type().Id("Token").Struct(
+		Id("Type").String(),
+		Id("Value").String(),
+		Id("Expiry").Qual(packageTime, "Time"),
+	)
+
+	srcFile.Line().Comment("TokenSource returns token, which is sent in Authorization header of every call")
+	srcFile.Type().Id("TokenSource").Interface(
+		Id("Token").Params(Id(_ctx_).Qual(packageContext, "Context")).Params(Id("token").Id("Token"), Err().Error()),
+	)
+
+	srcFile.Line().Type().Id("TokenSourceFunc").Func().Params(Id(_ctx_).Qual(packageContext, "Context")).Params(Id("token").Id("Token"), Err().Error())
+
+	srcFile.Line().Func().Params(Id("fn").Id("TokenSourceFunc")).Id("Token").Params(Id(_ctx_).Qual(packageContext, "Context")).Params(Id("Token"), Error()).Block(
+		Return(Id("fn").Call(Id(_ctx_))),
+	)
+
+	srcFile.Line().Comment("RequestSigner is called right before request is sent and may add headers to it")
+	srcFile.Type().Id("RequestSigner").Func().Params(Id("request").Op("*").Id("HTTPRequest")).Params(Error())
+
+	srcFile.Line().Comment("StaticToken returns bearer token source with fixed token")
+	srcFile.Func().Id("StaticToken").Params(Id("token").String()).Params(Id("TokenSource")).Block(
+		Return(Id("TokenSourceFunc").Call(Func().Params(Id("_").Qual(packageContext, "Context")).Params(Id("Token"), Error()).Block(
+			Return(Id("Token").Values(Dict{Id("Type"): Lit("Bearer"), Id("Value"): Id("token")}), Nil()),
+		))),
+	)
+
+	srcFile.Line().Type().Id("clientCredentials").Struct(
+		Id("tokenURL").String(),
+		Id("clientID").String(),
+		Id("clientSecret").String(),
+		Id("scopes").Op("[]").String(),
+		Id("transport").Id("HTTPTransport"),
+		Line().Id("lock").Qual(packageSync, "Mutex"),
+		Id("token").Id("Token"),
+		Id("fetching").Chan().Struct(),
+	)
+
+	srcFile.Line().Comment("ClientCredentials returns OAuth2 client credentials token source, token is cached until it expires.")
+	srcFile.Comment("Token is requested by transport of client, which source is passed to by Auth option")
+	srcFile.Func().Id("ClientCredentials").Params(List(Id("tokenURL"), Id("clientID"), Id("clientSecret")).String(), Id("scopes").Op("...").String()).Params(Id("TokenSource")).Block(
+		Return(Op("&").Id("clientCredentials").Values(Dict{
+			Id("tokenURL"):     Id("tokenURL"),
+			Id("clientID"):     Id("clientID"),
+			Id("clientSecret"): Id("clientSecret"),
+			Id("scopes"):       Id("scopes"),
+		})),
+	)
+
+	srcFile.Line().Comment("useTransport sets transport of the first client, which uses token source")
+	srcFile.Func().Params(Id("source").Op("*").Id("clientCredentials")).Id("useTransport").Params(Id("transport").Id("HTTPTransport")).Block(
+		Id("source").Dot("lock").Dot("Lock").Call(),
+		Defer().Id("source").Dot("lock").Dot("Unlock").Call(),
+		If(Id("source").Dot("transport").Op("==").Nil()).Block(
+			Id("source").Dot("transport").Op("=").Id("transport"),
+		),
+	)
+
+	srcFile.Line().Comment("Token returns cached token, only one call requests new token, others wait for it")
+	srcFile.Func().Params(Id("source").Op("*").Id("clientCredentials")).Id("Token").Params(Id(_ctx_).Qual(packageContext, "Context")).Params(Id("token").Id("Token"), Err().Error()).Block(
+		For().Block(
+			Id("source").Dot("lock").Dot("Lock").Call(),
+			If(Id("source").Dot("token").Dot("Value").Op("!=").Lit("").Op("&&").Parens(Id("source").Dot("token").Dot("Expiry").Dot("IsZero").Call().Op("||").Qual(packageTime, "Until").Call(Id("source").Dot("token").Dot("Expiry")).Op(">").Lit(tokenExpiryDelta).Op("*").Qual(packageTime, "Second"))).Block(
+				Id("token").Op("=").Id("source").Dot("token"),
+				Id("source").Dot("lock").Dot("Unlock").Call(),
+				Return(),
+			),
+			Id("fetching").Op(":=").Id("source").Dot("fetching"),
+			If(Id("fetching").Op("==").Nil()).Block(
+				Id("source").Dot("fetching").Op("=").Make(Chan().Struct()),
+				Id("source").Dot("lock").Dot("Unlock").Call(),
+				Break(),
+			),
+			Id("source").Dot("lock").Dot("Unlock").Call(),
+			Select().Block(
+				Case(Op("<-").Id("fetching")),
+				Case(Op("<-").Id(_ctx_).Dot("Done").Call()).Block(
+					Return(Id("token"), Id(_ctx_).Dot("Err").Call()),
+				),
+			),
+		),
+		List(Id("token"), Err()).Op("=").Id("source").Dot("fetch").Call(Id(_ctx_)),
+		Id("source").Dot("lock").Dot("Lock").Call(),
+		Defer().Id("source").Dot("lock").Dot("Unlock").Call(),
+		If(Err().Op("==").Nil()).Block(
+			Id("source").Dot("token").Op("=").Id("token"),
+		),
+		Close(Id("source").Dot("fetching")),
+		Id("source").Dot("fetching").Op("=").Nil(),
+		Return(),
+	)
+
+	srcFile.Line().Func().Params(Id("source").Op("*").Id("clientCredentials")).Id("fetch").Params(Id(_ctx_).Qual(packageContext, "Context")).Params(Id("token").Id("Token"), Err().Error()).Block(
+		Id("source").Dot("lock").Dot("Lock").Call(),
+		Id("transport").Op(":=").Id("source").Dot("transport"),
+		Id("source").Dot("lock").Dot("Unlock").Call(),
+		If(Id("transport").Op("==").Nil()).Block(
+			Id("transport").Op("=").Id("NetHTTPTransport").Call(Qual(packageHttp, "DefaultClient")),
+		),
+		Id("form").Op(":=").Qual(packageURL, "Values").Values(Dict{Lit("grant_type"): Index().String().Values(Lit("client_credentials"))}),
+		If(Len(Id("source").Dot("scopes")).Op("!=").Lit(0)).Block(
+			Id("form").Dot("Set").Call(Lit("scope"), Qual(packageStrings, "Join").Call(Id("source").Dot("scopes"), Lit(" "))),
+		),
+		Id("credentials").Op(":=").Qual(packageURL, "QueryEscape").Call(Id("source").Dot("clientID")).Op("+").Lit(":").Op("+").Qual(packageURL, "QueryEscape").Call(Id("source").Dot("clientSecret")),
+		Id("request").Op(":=").Op("&").Id("HTTPRequest").Values(Dict{
+			Id("Method"): Qual(packageHttp, "MethodPost"),
+			Id("URL"):    Id("source").Dot("tokenURL"),
+			Id("Header"): Qual(packageHttp, "Header").Values(Dict{
+				Lit("Content-Type"):  Index().String().Values(Lit("application/x-www-form-urlencoded")),
+				Lit("Authorization"): Index().String().Values(Lit("Basic ").Op("+").Qual(packageBase64, "StdEncoding").Dot("EncodeToString").Call(Index().Byte().Call(Id("credentials")))),
+			}),
+			Id("Body"): Index().Byte().Call(Id("form").Dot("Encode").Call()),
+		}),
+		Var().Id("response").Op("*").Id("HTTPResponse"),
+		If(List(Id("response"), Err()).Op("=").Id("transport").Dot("Do").Call(Id(_ctx_), Id("request")).Op(";").Err().Op("!=").Nil()).Block(
+			Return(),
+		),
+		If(Id("response").Dot("StatusCode").Op("!=").Qual(packageHttp, "StatusOK")).Block(
+			Return(Id("token"), Qual(packageFmt, "Errorf").Call(Lit("token endpoint status %d: %s"), Id("response").Dot("StatusCode"), Id("response").Dot("Body"))),
+		),
+		Var().Id("tokenResponse").Struct(
+			Id("AccessToken").String().Tag(map[string]string{"json": "access_token"}),
+			Id("TokenType").String().Tag(map[string]string{"json": "token_type"}),
+			Id("ExpiresIn").Int64().Tag(map[string]string{"json": "expires_in"}),
+		),
+		If(Err().Op("=").Qual(packageJson, "Unmarshal").Call(Id("response").Dot("Body"), Op("&").Id("tokenResponse")).Op(";").Err().Op("!=").Nil()).Block(
+			Return(),
+		),
+		If(Id("tokenResponse").Dot("AccessToken").Op("==").Lit("")).Block(
+			Return(Id("token"), Qual(packageErrors, "New").Call(Lit("token endpoint returned empty access token"))),
+		),
+		Id("token").Op("=").Id("Token").Values(Dict{Id("Type"): Id("tokenResponse").Dot("TokenType"), Id("Value"): Id("tokenResponse").Dot("AccessToken")}),
+		If(Id("token").Dot("Type").Op("==").Lit("").Op("||").Qual(packageStrings, "EqualFold").Call(Id("token").Dot("Type"), Lit("bearer"))).Block(
+			Id("token").Dot("Type").Op("=").Lit("Bearer"),
+		),
+		If(Id("tokenResponse").Dot("ExpiresIn").Op(">").Lit(0)).Block(
+			Id("token").Dot("Expiry").Op("=").Qual(packageTime, "Now").Call().Dot("Add").Call(Qual(packageTime, "Duration").Call(Id("tokenResponse").Dot("ExpiresIn")).Op("*").Qual(packageTime, "Second")),
+		),
+		Return(),
+	)
+
+	srcFile.Line().Comment("HMACSigner sets header to hex encoded HMAC-SHA256 of request body")
+	srcFile.Func().Id("HMACSigner").Params(Id("header").String(), Id("secret").Op("[]").Byte()).Params(Id("RequestSigner")).Block(
+		Return(Func().Params(Id("request").Op("*").Id("HTTPRequest")).Params(Error()).Block(
+			Id("mac").Op(":=").Qual(packageHMAC, "New").Call(Qual(packageSHA256, "New"), Id("secret")),
+			Id("mac").Dot("Write").Call(Id("request").Dot("Body")),
+			Id("request").Dot("Header").Dot("Set").Call(Id("header"), Qual(packageHex, "EncodeToString").Call(Id("mac").Dot("Sum").Call(Nil()))),
+			Return(Nil()),
+		)),
+	)
+
+	srcFile.Line().Comment("authorize adds token and signature to request")
+	srcFile.Func().Params(Id("cli").Op("*").Id("ClientJsonRPC")).Id("authorize").Params(Id(_ctx_).Qual(packageContext, "Context"), Id("request").Op("*").Id("HTTPRequest")).Params(Err().Error()).Block(
+		If(Id("cli").Dot("tokenSource").Op("!=").Nil()).Block(
+			Var().Id("token").Id("Token"),
+			If(List(Id("token"), Err()).Op("=").Id("cli").Dot("tokenSource").Dot("Token").Call(Id(_ctx_)).Op(";").Err().Op("!=").Nil()).Block(
+				Return(),
+			),
+			Id("request").Dot("Header").Dot("Set").Call(Lit("Authorization"), Id("token").Dot("Type").Op("+").Lit(" ").Op("+").Id("token").Dot("Value")),
+		),
+		For(List(Id("_"), Id("signer")).Op(":=").Range().Id("cli").Dot("signers")).Block(
+			If(Err().Op("=").Id("signer").Call(Id("request")).Op(";").Err().Op("!=").Nil()).Block(
+				Return(),
+			),
+		),
+		Return(),
+	)
+	for _, scheme := range tr.securitySchemes() {
+		srcFile.Add(scheme.clientAuth())
+	}
+	return srcFile.Save(path.Join(outDir, "auth.go"))
+}
+
+// clientAuth renders constructor of credentials for security scheme declared in package
+func (scheme securityScheme) clientAuth() Code {
+
+	name := "Security" + utils.ToCamel(scheme.name)
+	switch scheme.kind {
+	case securityAPIKey:
+		code := Line().Comment(fmt.Sprintf("%s returns signer, which sends API key in %s '%s' of security scheme '%s'", name, scheme.in, scheme.param, scheme.name))
+		signer := Func().Params(Id("request").Op("*").Id("HTTPRequest")).Params(Err().Error())
+		switch scheme.in {
+		case "query":
+			signer.Block(
+				Var().Id("requestURL").Op("*").Qual(packageURL, "URL"),
+				If(List(Id("requestURL"), Err()).Op("=").Qual(packageURL, "Parse").Call(Id("request").Dot("URL")).Op(";").Err().Op("!=").Nil()).Block(
+					Return(),
+				),
+				Id("query").Op(":=").Id("requestURL").Dot("Query").Call(),
+				Id("query").Dot("Set").Call(Lit(scheme.param), Id("key")),
+				Id("requestURL").Dot("RawQuery").Op("=").Id("query").Dot("Encode").Call(),
+				Id("request").Dot("URL").Op("=").Id("requestURL").Dot("String").Call(),
+				Return(),
+			)
+		case "cookie":
+			signer.Block(
+				Id("request").Dot("Header").Dot("Add").Call(Lit("Cookie"), Parens(Op("&").Qual(packageHttp, "Cookie").Values(Dict{Id("Name"): Lit(scheme.param), Id("Value"): Id("key")})).Dot("String").Call()),
+				Return(),
+			)
+		default:
+			signer.Block(
+				Id("request").Dot("Header").Dot("Set").Call(Lit(scheme.param), Id("key")),
+				Return(),
+			)
+		}
+		return code.Line().Func().Id(name).Params(Id("key").String()).Params(Id("RequestSigner")).Block(Return(signer))
+	case securityBasic:
+		return Line().Comment(fmt.Sprintf("%s returns signer, which sends basic credentials of security scheme '%s'", name, scheme.name)).
+			Line().Func().Id(name).Params(List(Id("username"), Id("password")).String()).Params(Id("RequestSigner")).Block(
+			Return(Func().Params(Id("request").Op("*").Id("HTTPRequest")).Params(Error()).Block(
+				Id("request").Dot("Header").Dot("Set").Call(Lit("Authorization"), Lit("Basic ").Op("+").Qual(packageBase64, "StdEncoding").Dot("EncodeToString").Call(Index().Byte().Call(Id("username").Op("+").Lit(":").Op("+").Id("password")))),
+				Return(Nil()),
+			)),
+		)
+	case securityOAuth2:
+		if scheme.flow != flowClientCredentials || scheme.token == "" {
+			return Null()
+		}
+		return Line().Comment(fmt.Sprintf("%s returns client credentials token source of security scheme '%s'", name, scheme.name)).
+			Line().Func().Id(name).Params(List(Id("clientID"), Id("clientSecret")).String(), Id("scopes").Op("...").String()).Params(Id("TokenSource")).Block(
+			Return(Id("ClientCredentials").Call(Lit(scheme.token), Id("clientID"), Id("clientSecret"), Id("scopes").Op("..."))),
+		)
+	}
+	return Null()
+}
@@ -0,0 +1,239 @@
+package generator
+
+import (
+	"path"
+	"path/filepath"
+
+	. "github.com/dave/jennifer/jen"
+)
+
+const (
+	defaultResolveInterval = 30
+	defaultEjectTimeout    = 10
+)
+
+func (tr Transport) renderClientBalancer(outDir string) (err error) {
+
+	srcFile := newSrc(filepath.Base(outDir))
+	srcFile.PackageComment(doNotEdit)
+
+	srcFile.ImportName(packageZeroLog, "zerolog")
+
+	srcFile.Line().Comment("Resolver returns base URLs (scheme://host:port) of service endpoints")
+	srcFile.Type().Id("Resolver").Func().Params(Id(_ctx_).Qual(packageContext, "Context")).Params(Id("endpoints").Op("[]").String(), Err().Error())
+
+	srcFile.Line().Type().Id("BalanceStrategy").Int()
+
+	srcFile.Line().Const().Op("(").
+		Line().Id("RoundRobin").Id("BalanceStrategy").Op("=").Iota().
+		Line().Id("LeastInFlight").
+		Line().Op(")")
+
+	srcFile.Line().Comment("StaticResolver returns fixed list of endpoints")
+	srcFile.Func().Id("StaticResolver").Params(Id("endpoints").Op("...").String()).Params(Id("Resolver")).Block(
+		Return(Func().Params(Id("_").Qual(packageContext, "Context")).Params(Op("[]").String(), Error()).Block(
+			Return(Id("endpoints"), Nil()),
+		)),
+	)
+
+	srcFile.Line().Comment("DNSResolver returns endpoint for every address (A/AAAA records) of baseURL host. Requests are sent to addresses,")
+	srcFile.Comment("so Host header is an address too and https is not supported, because certificate of host can not be verified")
+	srcFile.Func().Id("DNSResolver").Params(Id("baseURL").String()).Params(Id("Resolver")).Block(
+		Return(Func().Params(Id(_ctx_).Qual(packageContext, "Context")).Params(Id("endpoints").Op("[]").String(), Err().Error()).Block(
+			Var().Id("u").Op("*").Qual(packageURL, "URL"),
+			If(List(Id("u"), Err()).Op("=").Qual(packageURL, "Parse").Call(Id("baseURL")).Op(";").Err().Op("!=").Nil()).Block(
+				Return(),
+			),
+			If(Id("u").Dot("Scheme").Op("!=").Lit("http")).Block(
+				Return(Nil(), Qual(packageFmt, "Errorf").Call(Lit("DNSResolver supports http only, %s is given"), Id("u").Dot("Scheme"))),
+			),
+			Id("port").Op(":=").Id("u").Dot("Port").Call(),
+			If(Id("port").Op("==").Lit("")).Block(
+				Id("port").Op("=").Lit("80"),
+			),
+			Var().Id("addrs").Op("[]").String(),
+			If(List(Id("addrs"), Err()).Op("=").Qual(packageNet, "DefaultResolver").Dot("LookupHost").Call(Id(_ctx_), Id("u").Dot("Hostname").Call()).Op(";").Err().Op("!=").Nil()).Block(
+				Return(),
+			),
+			For(List(Id("_"), Id("addr")).Op(":=").Range().Id("addrs")).Block(
+				Id("endpoints").Op("=").Append(Id("endpoints"), Id("u").Dot("Scheme").Op("+").Lit("://").Op("+").Qual(packageNet, "JoinHostPort").Call(Id("addr"), Id("port"))),
+			),
+			Return(),
+		)),
+	)
+
+	srcFile.Line().Comment("SRVResolver returns endpoint for every target of SRV record, see net.LookupSRV")
+	srcFile.Func().Id("SRVResolver").Params(Id("scheme"), Id("service"), Id("proto"), Id("name").String()).Params(Id("Resolver")).Block(
+		Return(Func().Params(Id(_ctx_).Qual(packageContext, "Context")).Params(Id("endpoints").Op("[]").String(), Err().Error()).Block(
+			Var().Id("records").Op("[]*").Qual(packageNet, "SRV"),
+			If(List(Id("_"), Id("records"), Err()).Op("=").Qual(packageNet, "DefaultResolver").Dot("LookupSRV").Call(Id(_ctx_), Id("service"), Id("proto"), Id("name")).Op(";").Err().Op("!=").Nil()).Block(
+				Return(),
+			),
+			For(List(Id("_"), Id("record")).Op(":=").Range().Id("records")).Block(
+				Id("host").Op(":=").Qual(packageStrings, "TrimSuffix").Call(Id("record").Dot("Target"), Lit(".")),
+				Id("endpoints").Op("=").Append(Id("endpoints"), Id("scheme").Op("+").Lit("://").Op("+").Qual(packageNet, "JoinHostPort").Call(Id("host"), Qual(packageStrconv, "Itoa").Call(Int().Call(Id("record").Dot("Port"))))),
+			),
+			Return(),
+		)),
+	)
+
+	srcFile.Line().Type().Id("endpoint").Struct(
+		Id("scheme").String(),
+		Id("host").String(),
+		Id("inFlight").Int(),
+		Id("ejectedUntil").Qual(packageTime, "Time"),
+	)
+
+	srcFile.Line().Type().Id("balancer").Struct(
+		Id("resolver").Id("Resolver"),
+		Id("strategy").Id("BalanceStrategy"),
+		Id("interval").Qual(packageTime, "Duration"),
+		Id("ejectTimeout").Qual(packageTime, "Duration"),
+		Id("log").Qual(packageZeroLog, "Logger"),
+		Line().Id("lock").Qual(packageSync, "Mutex"),
+		Id("next").Int(),
+		Id("resolvedAt").Qual(packageTime, "Time"),
+		Id("resolving").Chan().Struct(),
+		Id("resolveErr").Error(),
+		Id("endpoints").Op("[]*").Id("endpoint"),
+	)
+
+	srcFile.Line().Func().Id("newBalancer").Params(Id("log").Qual(packageZeroLog, "Logger")).Params(Op("*").Id("balancer")).Block(
+		Return(Op("&").Id("balancer").Values(Dict{
+			Id("log"):          Id("log"),
+			Id("strategy"):     Id("RoundRobin"),
+			Id("interval"):     Lit(defaultResolveInterval).Op("*").Qual(packageTime, "Second"),
+			Id("ejectTimeout"): Lit(defaultEjectTimeout).Op("*").Qual(packageTime, "Second"),
+		})),
+	)
+
+	srcFile.Line().Comment("resolve refreshes endpoints, state of known endpoints is kept. Resolver is called without lock,")
+	srcFile.Comment("concurrent calls share it and wait for its result only if there are no endpoints yet")
+	srcFile.Func().Params(Id("b").Op("*").Id("balancer")).Id("resolve").Params(Id(_ctx_).Qual(packageContext, "Context")).Params(Err().Error()).Block(
+		Id("b").Dot("lock").Dot("Lock").Call(),
+		If(Id("resolving").Op(":=").Id("b").Dot("resolving"), Id("resolving").Op("!=").Nil()).Block(
+			Id("wait").Op(":=").Len(Id("b").Dot("endpoints")).Op("==").Lit(0),
+			Id("b").Dot("lock").Dot("Unlock").Call(),
+			If(Op("!").Id("wait")).Block(
+				Return(),
+			),
+			Select().Block(
+				Case(Op("<-").Id("resolving")),
+				Case(Op("<-").Id(_ctx_).Dot("Done").Call()).Block(
+					Return(Id(_ctx_).Dot("Err").Call()),
+				),
+			),
+			Id("b").Dot("lock").Dot("Lock").Call(),
+			Defer().Id("b").Dot("lock").Dot("Unlock").Call(),
+			Return(Id("b").Dot("resolveErr")),
+		),
+		Id("resolving").Op(":=").Make(Chan().Struct()),
+		Id("b").Dot("resolving").Op("=").Id("resolving"),
+		Id("b").Dot("lock").Dot("Unlock").Call(),
+		Line(),
+		List(Id("urls"), Err()).Op(":=").Id("b").Dot("resolver").Call(Id(_ctx_)),
+		Line(),
+		Id("b").Dot("lock").Dot("Lock").Call(),
+		Defer().Func().Params().Block(
+			List(Id("b").Dot("resolving"), Id("b").Dot("resolveErr")).Op("=").List(Nil(), Err()),
+			Id("b").Dot("lock").Dot("Unlock").Call(),
+			Close(Id("resolving")),
+		).Call(),
+		Id("b").Dot("resolvedAt").Op("=").Qual(packageTime, "Now").Call(),
+		If(Err().Op("!=").Nil()).Block(
+			Return(),
+		),
+		Id("known").Op(":=").Make(Map(String()).Op("*").Id("endpoint")),
+		For(List(Id("_"), Id("ep")).Op(":=").Range().Id("b").Dot("endpoints")).Block(
+			Id("known").Index(Id("ep").Dot("scheme").Op("+").Lit("://").Op("+").Id("ep").Dot("host")).Op("=").Id("ep"),
+		),
+		Id("endpoints").Op(":=").Make(Op("[]*").Id("endpoint"), Lit(0), Len(Id("urls"))),
+		For(List(Id("_"), Id("rawURL")).Op(":=").Range().Id("urls")).Block(
+			List(Id("u"), Id("parseErr")).Op(":=").Qual(packageURL, "Parse").Call(Id("rawURL")),
+			If(Id("parseErr").Op("!=").Nil().Op("||").Id("u").Dot("Host").Op("==").Lit("")).Block(
+				Id("b").Dot("log").Dot("Warn").Call().Dot("Err").Call(Id("parseErr")).Dot("Str").Call(Lit("endpoint"), Id("rawURL")).Dot("Msg").Call(Lit("skip invalid endpoint")),
+				Continue(),
+			),
+			If(List(Id("ep"), Id("found")).Op(":=").Id("known").Index(Id("u").Dot("Scheme").Op("+").Lit("://").Op("+").Id("u").Dot("Host")).Op(";").Id("found")).Block(
+				Id("endpoints").Op("=").Append(Id("endpoints"), Id("ep")),
+				Continue(),
+			),
+			Id("endpoints").Op("=").Append(Id("endpoints"), Op("&").Id("endpoint").Values(Dict{
+				Id("scheme"): Id("u").Dot("Scheme"),
+				Id("host"):   Id("u").Dot("Host"),
+			})),
+		),
+		If(Len(Id("endpoints")).Op("==").Lit(0)).Block(
+			Return(Qual(packageErrors, "New").Call(Lit("resolver returned no endpoints"))),
+		),
+		Id("b").Dot("endpoints").Op("=").Id("endpoints"),
+		Return(),
+	)
+
+	srcFile.Line().Comment("pick selects endpoint for next request, ejected endpoints are used only if all endpoints are ejected")
+	srcFile.Func().Params(Id("b").Op("*").Id("balancer")).Id("pick").Params(Id(_ctx_).Qual(packageContext, "Context")).Params(Id("ep").Op("*").Id("endpoint"), Err().Error()).Block(
+		Id("b").Dot("lock").Dot("Lock").Call(),
+		Id("stale").Op(":=").Len(Id("b").Dot("endpoints")).Op("==").Lit(0).Op("||").Qual(packageTime, "Since").Call(Id("b").Dot("resolvedAt")).Op(">=").Id("b").Dot("interval"),
+		Id("b").Dot("lock").Dot("Unlock").Call(),
+		If(Id("stale")).Block(
+			Err().Op("=").Id("b").Dot("resolve").Call(Id(_ctx_)),
+		),
+		Id("b").Dot("lock").Dot("Lock").Call(),
+		Defer().Id("b").Dot("lock").Dot("Unlock").Call(),
+		If(Err().Op("!=").Nil()).Block(
+			If(Len(Id("b").Dot("endpoints")).Op("==").Lit(0)).Block(
+				Return(),
+			),
+			Id("b").Dot("log").Dot("Warn").Call().Dot("Err").Call(Err()).Dot("Msg").Call(Lit("resolve endpoints, previous endpoints are used")),
+			Err().Op("=").Nil(),
+		),
+		If(Len(Id("b").Dot("endpoints")).Op("==").Lit(0)).Block(
+			Return(Nil(), Qual(packageErrors, "New").Call(Lit("no endpoints resolved"))),
+		),
+		Id("now").Op(":=").Qual(packageTime, "Now").Call(),
+		Id("candidates").Op(":=").Make(Op("[]*").Id("endpoint"), Lit(0), Len(Id("b").Dot("endpoints"))),
+		For(List(Id("_"), Id("candidate")).Op(":=").Range().Id("b").Dot("endpoints")).Block(
+			If(Id("now").Dot("After").Call(Id("candidate").Dot("ejectedUntil"))).Block(
+				Id("candidates").Op("=").Append(Id("candidates"), Id("candidate")),
+			),
+		),
+		If(Len(Id("candidates")).Op("==").Lit(0)).Block(
+			Id("candidates").Op("=").Id("b").Dot("endpoints"),
+		),
+		Id("b").Dot("next").Op("++"),
+		Id("ep").Op("=").Id("candidates").Index(Id("b").Dot("next").Op("%").Len(Id("candidates"))),
+		If(Id("b").Dot("strategy").Op("==").Id("LeastInFlight")).Block(
+			For(Id("i").Op(":=").Lit(1).Op(";").Id("i").Op("<").Len(Id("candidates")).Op(";").Id("i").Op("++")).Block(
+				If(Id("candidate").Op(":=").Id("candidates").Index(Parens(Id("b").Dot("next").Op("+").Id("i")).Op("%").Len(Id("candidates"))).Op(";").Id("candidate").Dot("inFlight").Op("<").Id("ep").Dot("inFlight")).Block(
+					Id("ep").Op("=").Id("candidate"),
+				),
+			),
+		),
+		Id("ep").Dot("inFlight").Op("++"),
+		Return(),
+	)
+
+	srcFile.Line().Comment("release returns endpoint after request, endpoint is ejected on transport error")
+	srcFile.Func().Params(Id("b").Op("*").Id("balancer")).Id("release").Params(Id("ep").Op("*").Id("endpoint"), Id("failed").Bool()).Block(
+		Id("b").Dot("lock").Dot("Lock").Call(),
+		Defer().Id("b").Dot("lock").Dot("Unlock").Call(),
+		Id("ep").Dot("inFlight").Op("--"),
+		If(Id("failed")).Block(
+			Id("ep").Dot("ejectedUntil").Op("=").Qual(packageTime, "Now").Call().Dot("Add").Call(Id("b").Dot("ejectTimeout")),
+			Id("b").Dot("log").Dot("Warn").Call().Dot("Str").Call(Lit("endpoint"), Id("ep").Dot("host")).Dot("Dur").Call(Lit("timeout"), Id("b").Dot("ejectTimeout")).Dot("Msg").Call(Lit("endpoint ejected")),
+		),
+	)
+
+	srcFile.Line().Comment("route directs request to endpoint")
+	srcFile.Func().Params(Id("ep").Op("*").Id("endpoint")).Id("route").Params(Id("request").Op("*").Id("HTTPRequest")).Params(Err().Error()).Block(
+		Var().Id("u").Op("*").Qual(packageURL, "URL"),
+		If(List(Id("u"), Err()).Op("=").Qual(packageURL, "Parse").Call(Id("request").Dot("URL")).Op(";").Err().Op("!=").Nil()).Block(
+			Return(),
+		),
+		Id("u").Dot("Scheme").Op("=").Id("ep").Dot("scheme"),
+		Id("u").Dot("Host").Op("=").Id("ep").Dot("host"),
+		Id("request").Dot("URL").Op("=").Id("u").Dot("String").Call(),
+		Return(),
+	)
+	return srcFile.Save(path.Join(outDir, "balancer.go"))
+}
@@ -0,0 +1,231 @@
+package generator
+
+import (
+	"path"
+	"path/filepath"
+
+	. "github.com/dave/jennifer/jen"
+)
+
+const (
+	defaultBreakerFailures    = 5
+	defaultBreakerOpenTimeout = 30
+)
+
+func (tr Transport) renderClientBreaker(outDir string) (err error) {
+
+	srcFile := newSrc(filepath.Base(outDir))
+	srcFile.PackageComment(doNotEdit)
+
+	srcFile.ImportName(packageZeroLog, "zerolog")
+	srcFile.ImportName(packageGoKitMetrics, "metrics")
+	srcFile.ImportAlias(packageKitPrometheus, "kitPrometheus")
+	srcFile.ImportAlias(packageStdPrometheus, "stdPrometheus")
+
+	srcFile.Line().Var().Op("(").
+		Line().Id("ErrBreakerOpen").Op("=").Qual(packageErrors, "New").Call(Lit("circuit breaker is open")).
+		Line().Id("ErrBulkheadFull").Op("=").Qual(packageErrors, "New").Call(Lit("too many concurrent requests")).
+		Line().Op(")")
+
+	srcFile.Line().Type().Id("BreakerState").Int()
+
+	srcFile.Line().Const().Op("(").
+		Line().Id("BreakerClosed").Id("BreakerState").Op("=").Iota().
+		Line().Id("BreakerOpen").
+		Line().Id("BreakerHalfOpen").
+		Line().Op(")")
+
+	srcFile.Line().Func().Params(Id("state").Id("BreakerState")).Id("String").Params().Params(String()).Block(
+		Switch(Id("state")).Block(
+			Case(Id("BreakerOpen")).Block(Return(Lit("open"))),
+			Case(Id("BreakerHalfOpen")).Block(Return(Lit("half-open"))),
+		),
+		Return(Lit("closed")),
+	)
+
+	srcFile.Line().Comment("BreakerConfig defines circuit breaker thresholds, zero values are replaced by defaults")
+	srcFile.Type().Id("BreakerConfig").Struct(
+		Comment("FailureThreshold is the number of consecutive failures which opens breaker"),
+		Id("FailureThreshold").Int(),
+		Comment("OpenTimeout is the time breaker stays open before half-open probing"),
+		Id("OpenTimeout").Qual(packageTime, "Duration"),
+		Comment("HalfOpenRequests is the number of probe calls, which must succeed to close breaker"),
+		Id("HalfOpenRequests").Int(),
+		Comment("OnStateChange is called on every state change, breaker is empty for client breaker"),
+		Id("OnStateChange").Func().Params(Id("breaker").String(), List(Id("from"), Id("to")).Id("BreakerState")),
+	)
+
+	srcFile.Line().Var().Id("CircuitBreakerState").Op("=").Id("newBreakerStateGauge").Call()
+
+	srcFile.Line().Func().Id("newBreakerStateGauge").Params().Params(Qual(packageGoKitMetrics, "Gauge")).Block(
+		Id("gaugeVec").Op(":=").Qual(packageStdPrometheus, "NewGaugeVec").Call(Qual(packageStdPrometheus, "GaugeOpts").Values(Dict{
+			Id("Name"):      Lit("state"),
+			Id("Namespace"): Lit("client"),
+			Id("Subsystem"): Lit("circuit_breaker"),
+			Id("Help"):      Lit("State of client circuit breaker: 0 closed, 1 open, 2 half-open"),
+		}), Index().String().Values(Lit("client"), Lit("breaker"))),
+		If(Err().Op(":=").Qual(packageStdPrometheus, "Register").Call(Id("gaugeVec")).Op(";").Err().Op("!=").Nil()).Block(
+			If(List(Id("registered"), Id("ok")).Op(":=").Err().Op(".(").Qual(packageStdPrometheus, "AlreadyRegisteredError").Op(")").Op(";").Id("ok")).Block(
+				Id("gaugeVec").Op("=").Id("registered").Dot("ExistingCollector").Op(".(*").Qual(packageStdPrometheus, "GaugeVec").Op(")"),
+			),
+		),
+		Return(Qual(packageKitPrometheus, "NewGauge").Call(Id("gaugeVec"))),
+	)
+
+	srcFile.Line().Type().Id("circuitBreaker").Struct(
+		Id("name").String(),
+		Id("client").String(),
+		Id("config").Id("BreakerConfig"),
+		Id("log").Qual(packageZeroLog, "Logger"),
+		Id("metrics").Bool(),
+		Line().Id("lock").Qual(packageSync, "Mutex"),
+		Id("state").Id("BreakerState"),
+		Id("failures").Int(),
+		Id("probes").Int(),
+		Id("successes").Int(),
+		Id("openedAt").Qual(packageTime, "Time"),
+	)
+
+	srcFile.Line().Func().Id("newCircuitBreaker").Params(Id("client"), Id("name").String(), Id("log").Qual(packageZeroLog, "Logger"), Id("config").Id("BreakerConfig")).Params(Op("*").Id("circuitBreaker")).Block(
+		If(Id("config").Dot("FailureThreshold").Op("<=").Lit(0)).Block(
+			Id("config").Dot("FailureThreshold").Op("=").Lit(defaultBreakerFailures),
+		),
+		If(Id("config").Dot("OpenTimeout").Op("<=").Lit(0)).Block(
+			Id("config").Dot("OpenTimeout").Op("=").Lit(defaultBreakerOpenTimeout).Op("*").Qual(packageTime, "Second"),
+		),
+		If(Id("config").Dot("HalfOpenRequests").Op("<=").Lit(0)).Block(
+			Id("config").Dot("HalfOpenRequests").Op("=").Lit(1),
+		),
+		Return(Op("&").Id("circuitBreaker").Values(Dict{
+			Id("name"):   Id("name"),
+			Id("client"): Id("client"),
+			Id("config"): Id("config"),
+			Id("log"):    Id("log"),
+		})),
+	)
+
+	srcFile.Line().Comment("allow reports whether call may be sent, open breaker switches to half-open after timeout")
+	srcFile.Func().Params(Id("cb").Op("*").Id("circuitBreaker")).Id("allow").Params().Params(Err().Error()).Block(
+		Id("cb").Dot("lock").Dot("Lock").Call(),
+		Id("from").Op(":=").Id("cb").Dot("state"),
+		If(Id("cb").Dot("state").Op("==").Id("BreakerOpen").Op("&&").Qual(packageTime, "Since").Call(Id("cb").Dot("openedAt")).Op(">=").Id("cb").Dot("config").Dot("OpenTimeout")).Block(
+			Id("cb").Dot("state").Op("=").Id("BreakerHalfOpen"),
+			Id("cb").Dot("probes").Op("=").Lit(0),
+			Id("cb").Dot("successes").Op("=").Lit(0),
+		),
+		Switch(Id("cb").Dot("state")).Block(
+			Case(Id("BreakerOpen")).Block(
+				Err().Op("=").Id("cb").Dot("openError").Call(),
+			),
+			Case(Id("BreakerHalfOpen")).Block(
+				If(Id("cb").Dot("probes").Op(">=").Id("cb").Dot("config").Dot("HalfOpenRequests")).Block(
+					Err().Op("=").Id("cb").Dot("openError").Call(),
+				).Else().Block(
+					Id("cb").Dot("probes").Op("++"),
+				),
+			),
+		),
+		Id("to").Op(":=").Id("cb").Dot("state"),
+		Id("cb").Dot("lock").Dot("Unlock").Call(),
+		Id("cb").Dot("changed").Call(Id("from"), Id("to")),
+		Return(),
+	)
+
+	srcFile.Line().Comment("done reports result of allowed call")
+	srcFile.Func().Params(Id("cb").Op("*").Id("circuitBreaker")).Id("done").Params(Id("success").Bool()).Block(
+		Id("cb").Dot("lock").Dot("Lock").Call(),
+		Id("from").Op(":=").Id("cb").Dot("state"),
+		Switch(Id("cb").Dot("state")).Block(
+			Case(Id("BreakerClosed")).Block(
+				If(Id("success")).Block(
+					Id("cb").Dot("failures").Op("=").Lit(0),
+				).Else().Block(
+					Id("cb").Dot("failures").Op("++"),
+				),
+				If(Id("cb").Dot("failures").Op(">=").Id("cb").Dot("config").Dot("FailureThreshold")).Block(
+					Id("cb").Dot("state").Op("=").Id("BreakerOpen"),
+					Id("cb").Dot("openedAt").Op("=").Qual(packageTime, "Now").Call(),
+				),
+			),
+			Case(Id("BreakerHalfOpen")).Block(
+				If(Op("!").Id("success")).Block(
+					Id("cb").Dot("state").Op("=").Id("BreakerOpen"),
+					Id("cb").Dot("openedAt").Op("=").Qual(packageTime, "Now").Call(),
+				).Else().If(Id("cb").Dot("successes").Op("++").Op(";").Id("cb").Dot("successes").Op(">=").Id("cb").Dot("config").Dot("HalfOpenRequests")).Block(
+					Id("cb").Dot("state").Op("=").Id("BreakerClosed"),
+					Id("cb").Dot("failures").Op("=").Lit(0),
+				),
+			),
+		),
+		Id("to").Op(":=").Id("cb").Dot("state"),
+		Id("cb").Dot("lock").Dot("Unlock").Call(),
+		Id("cb").Dot("changed").Call(Id("from"), Id("to")),
+	)
+
+	srcFile.Line().Comment("cancel returns probe of call, which was not sent or was canceled by caller")
+	srcFile.Func().Params(Id("cb").Op("*").Id("circuitBreaker")).Id("cancel").Params().Block(
+		Id("cb").Dot("lock").Dot("Lock").Call(),
+		Defer().Id("cb").Dot("lock").Dot("Unlock").Call(),
+		If(Id("cb").Dot("state").Op("==").Id("BreakerHalfOpen").Op("&&").Id("cb").Dot("probes").Op(">").Lit(0)).Block(
+			Id("cb").Dot("probes").Op("--"),
+		),
+	)
+
+	srcFile.Line().Func().Params(Id("cb").Op("*").Id("circuitBreaker")).Id("openError").Params().Params(Error()).Block(
+		If(Id("cb").Dot("name").Op("==").Lit("")).Block(
+			Return(Id("ErrBreakerOpen")),
+		),
+		Return(Qual(packageFmt, "Errorf").Call(Lit("%w: %s"), Id("ErrBreakerOpen"), Id("cb").Dot("name"))),
+	)
+
+	srcFile.Line().Func().Params(Id("cb").Op("*").Id("circuitBreaker")).Id("changed").Params(List(Id("from"), Id("to")).Id("BreakerState")).BlockFunc(func(bg *Group) {
+		bg.If(Id("from").Op("==").Id("to")).Block(
+			Return(),
+		)
+		bg.Id("cb").Dot("log").Dot("Warn").Call().
+			Dot("Str").Call(Lit("client"), Id("cb").Dot("client")).
+			Dot("Str").Call(Lit("breaker"), Id("cb").Dot("name")).
+			Dot("Stringer").Call(Lit("from"), Id("from")).
+			Dot("Stringer").Call(Lit("to"), Id("to")).
+			Dot("Msg").Call(Lit("circuit breaker state changed"))
+		bg.If(Id("cb").Dot("metrics")).Block(
+			Id("CircuitBreakerState").Dot("With").Call(Lit("client"), Id("cb").Dot("client"), Lit("breaker"), Id("cb").Dot("name")).Dot("Set").Call(Float64().Call(Id("to"))),
+		)
+		bg.If(Id("cb").Dot("config").Dot("OnStateChange").Op("!=").Nil()).Block(
+			Id("cb").Dot("config").Dot("OnStateChange").Call(Id("cb").Dot("name"), Id("from"), Id("to")),
+		)
+	})
+
+	srcFile.Line().Type().Id("bulkhead").Struct(
+		Id("slots").Chan().Struct(),
+		Id("maxWait").Qual(packageTime, "Duration"),
+	)
+
+	srcFile.Line().Func().Params(Id("bh").Op("*").Id("bulkhead")).Id("acquire").Params(Id(_ctx_).Qual(packageContext, "Context")).Params(Err().Error()).Block(
+		Select().Block(
+			Case(Id("bh").Dot("slots").Op("<-").Struct().Values()).Block(
+				Return(Nil()),
+			),
+			Default().Block(),
+		),
+		Id("timer").Op(":=").Qual(packageTime, "NewTimer").Call(Id("bh").Dot("maxWait")),
+		Defer().Id("timer").Dot("Stop").Call(),
+		Select().Block(
+			Case(Id("bh").Dot("slots").Op("<-").Struct().Values()).Block(
+				Return(Nil()),
+			),
+			Case(Op("<-").Id(_ctx_).Dot("Done").Call()).Block(
+				Return(Id(_ctx_).Dot("Err").Call()),
+			),
+			Case(Op("<-").Id("timer").Dot("C")).Block(
+				Return(Id("ErrBulkheadFull")),
+			),
+		),
+	)
+
+	srcFile.Line().Func().Params(Id("bh").Op("*").Id("bulkhead")).Id("release").Params().Block(
+		Op("<-").Id("bh").Dot("slots"),
+	)
+
+	return srcFile.Save(path.Join(outDir, "breaker.go"))
+}
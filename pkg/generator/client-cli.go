@@ -0,0 +1,445 @@
+package generator
+
+import (
+	"context"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	. "github.com/dave/jennifer/jen"
+	"github.com/vetcher/go-astra/types"
+
+	"github.com/tundrik/tg/v2/pkg/utils"
+)
+
+type clientCLI struct {
+	*Transport
+	clientPkg string
+}
+
+func (tr Transport) RenderClientCLI(outDir, modulePath string) (err error) {
+
+	if modulePath == "" {
+		modulePath = tr.tags.Value(tagClientModule)
+	}
+	if modulePath == "" {
+		absOutDir, _ := filepath.Abs(outDir)
+		if modulePath, err = utils.GetPkgPath(absOutDir, true); err != nil {
+			return
+		}
+	}
+	return newClientCLI(&tr, modulePath).render(outDir)
+}
+
+func newClientCLI(tr *Transport, clientPkg string) (cli *clientCLI) {
+	cli = &clientCLI{
+		Transport: tr,
+		clientPkg: clientPkg,
+	}
+	return
+}
+
+func (cli *clientCLI) render(outDir string) (err error) {
+
+	name := filepath.Base(outDir)
+	cliDir := path.Join(outDir, "cmd", name+"-cli")
+	cli.cleanup(cliDir)
+	if err = os.MkdirAll(cliDir, 0777); err != nil {
+		return
+	}
+
+	srcFile := newSrc("main")
+	srcFile.PackageComment(doNotEdit)
+
+	ctx := context.WithValue(context.Background(), "code", srcFile)
+
+	srcFile.ImportName(cli.clientPkg, name)
+	srcFile.ImportName(packageJson, "json")
+	srcFile.ImportName(packageZeroLog, "zerolog")
+
+	srcFile.Line().Const().Defs(
+		Id("clientName").Op("=").Lit(name+"-cli"),
+		Id("defaultURL").Op("=").Lit(cli.defaultURL()),
+	)
+
+	srcFile.Line().Type().Id("cliRequest").Interface(
+		Id("flags").Params(Id("fs").Op("*").Qual(packageFlag, "FlagSet")),
+		Id("call").Params(Id(_ctx_).Qual(packageContext, "Context"), Id("cli").Op("*").Qual(cli.clientPkg, "ClientJsonRPC")).Params(Interface(), Error()),
+	)
+	srcFile.Line().Type().Id("batchRequest").Interface(
+		Id("append").Params(Id("batch").Op("*").Qual(cli.clientPkg, "Batch"), Id("cli").Op("*").Qual(cli.clientPkg, "ClientJsonRPC"), Id("ret").Func().Params(Interface(), Error())),
+	)
+	srcFile.Line().Type().Id("command").Struct(
+		Id("service").String(),
+		Id("method").String(),
+		Id("summary").String(),
+		Id("request").Func().Params().Id("cliRequest"),
+	)
+
+	srcFile.Line().Var().Id("commands").Op("=").Index().Id("command").ValuesFunc(func(vg *Group) {
+		for _, svcName := range cli.serviceKeys() {
+			svc := cli.services[svcName]
+			for _, method := range svc.clientMethods() {
+				vg.Line().Values(Dict{
+					Id("service"): Lit(svc.lcName()),
+					Id("method"):  Lit(method.lccName()),
+					Id("summary"): Lit(method.tags.Value(tagSummary)),
+					Id("request"): Func().Params().Id("cliRequest").Block(
+						Return(Op("&").Id(cli.requestName(svc, method)).Values()),
+					),
+				})
+			}
+		}
+		vg.Line()
+	})
+
+	srcFile.Add(cli.mainFunc())
+	srcFile.Add(cli.runFunc())
+	srcFile.Add(cli.batchFunc())
+	srcFile.Add(cli.helpers())
+
+	for _, svcName := range cli.serviceKeys() {
+		svc := cli.services[svcName]
+		for _, method := range svc.clientMethods() {
+			srcFile.Add(cli.methodRequest(ctx, svc, method))
+		}
+	}
+	return srcFile.Save(path.Join(cliDir, "main.go"))
+}
+
+func (cli *clientCLI) defaultURL() string {
+
+	if servers := cli.tags.Value("servers"); servers != "" {
+		return strings.Split(strings.Split(servers, "|")[0], ";")[0]
+	}
+	return "http://localhost:9000"
+}
+
+func (cli *clientCLI) requestName(svc *service, method *method) string {
+	return "request" + svc.Name + method.Name
+}
+
+func (cli *clientCLI) responseName(svc *service, method *method) string {
+	return "response" + svc.Name + method.Name
+}
+
+func cliFieldName(field types.StructField) string {
+
+	if name := fieldJsonName(field); name != "-" {
+		return name
+	}
+	return field.Name
+}
+
+func (cli *clientCLI) mainFunc() Code {
+
+	return Line().Func().Id("main").Params().Block(
+		Id("fs").Op(":=").Qual(packageFlag, "NewFlagSet").Call(Id("clientName"), Qual(packageFlag, "ExitOnError")),
+		Id("url").Op(":=").Id("fs").Dot("String").Call(Lit("url"), Id("defaultURL"), Lit("service URL")),
+		Var().Id("headers").Id("headerFlags"),
+		Id("fs").Dot("Var").Call(Op("&").Id("headers"), Lit("H"), Lit("request header 'Name: value', can be repeated")),
+		Id("timeout").Op(":=").Id("fs").Dot("Duration").Call(Lit("timeout"), Lit(30).Op("*").Qual(packageTime, "Second"), Lit("request timeout")),
+		Id("batchFile").Op(":=").Id("fs").Dot("String").Call(Lit("batch"), Lit(""), Lit("JSON file with array of {\"method\": \"service.method\", \"params\": {...}} calls, - for stdin")),
+		Id("raw").Op(":=").Id("fs").Dot("Bool").Call(Lit("raw"), False(), Lit("print compact JSON instead of pretty")),
+		Id("verbose").Op(":=").Id("fs").Dot("Bool").Call(Lit("v"), False(), Lit("log requests to stderr")),
+		Id("fs").Dot("Usage").Op("=").Func().Params().Block(
+			Id("usage").Call(Id("fs")),
+		),
+		Id("_").Op("=").Id("fs").Dot("Parse").Call(Qual(packageOS, "Args").Index(Lit(1).Op(":"))),
+		Line(),
+		Id("log").Op(":=").Qual(packageZeroLog, "Nop").Call(),
+		If(Op("*").Id("verbose")).Block(
+			Id("log").Op("=").Qual(packageZeroLog, "New").Call(Qual(packageOS, "Stderr")).Dot("With").Call().Dot("Timestamp").Call().Dot("Logger").Call(),
+		),
+		Id("cli").Op(":=").Qual(cli.clientPkg, "New").Call(Id("clientName"), Id("log"), Op("*").Id("url"), Qual(cli.clientPkg, "Sign").Call(Id("headers").Dot("sign"))),
+		List(Id(_ctx_), Id("cancel")).Op(":=").Qual(packageContext, "WithTimeout").Call(Qual(packageContext, "Background").Call(), Op("*").Id("timeout")),
+		Line(),
+		Var().Err().Error(),
+		Var().Id("response").Interface(),
+		If(Op("*").Id("batchFile").Op("!=").Lit("")).Block(
+			List(Id("response"), Err()).Op("=").Id("runBatch").Call(Id(_ctx_), Id("cli"), Op("*").Id("batchFile")),
+		).Else().Block(
+			List(Id("response"), Err()).Op("=").Id("run").Call(Id(_ctx_), Id("cli"), Id("fs")),
+		),
+		Id("cancel").Call(),
+		If(Err().Op("!=").Nil()).Block(
+			Qual(packageFmt, "Fprintln").Call(Qual(packageOS, "Stderr"), Err()),
+			Qual(packageOS, "Exit").Call(Lit(1)),
+		),
+		If(Err().Op("=").Id("printJSON").Call(Id("response"), Op("*").Id("raw")).Op(";").Err().Op("!=").Nil()).Block(
+			Qual(packageFmt, "Fprintln").Call(Qual(packageOS, "Stderr"), Err()),
+			Qual(packageOS, "Exit").Call(Lit(1)),
+		),
+	)
+}
+
+func (cli *clientCLI) runFunc() Code {
+
+	code := Line().Func().Id("usage").Params(Id("fs").Op("*").Qual(packageFlag, "FlagSet")).Block(
+		Id("out").Op(":=").Id("fs").Dot("Output").Call(),
+		Qual(packageFmt, "Fprintf").Call(Id("out"), Lit("Usage: %s [options] <service> <method> [-json file] [method flags]\n\nOptions:\n"), Id("fs").Dot("Name").Call()),
+		Id("fs").Dot("PrintDefaults").Call(),
+		Qual(packageFmt, "Fprintf").Call(Id("out"), Lit("\nMethods:\n")),
+		For(List(Id("_"), Id("cmd")).Op(":=").Range().Id("commands")).Block(
+			Qual(packageFmt, "Fprintf").Call(Id("out"), Lit("  %-40s %s\n"), Id("cmd").Dot("service").Op("+").Lit(" ").Op("+").Id("cmd").Dot("method"), Id("cmd").Dot("summary")),
+		),
+	)
+	code.Line().Line().Func().Id("findCommand").Params(Id("service"), Id("method").String()).Params(Id("cmd").Id("command"), Id("found").Bool()).Block(
+		For(List(Id("_"), Id("cmd")).Op("=").Range().Id("commands")).Block(
+			If(Id("cmd").Dot("service").Op("==").Id("service").Op("&&").Id("cmd").Dot("method").Op("==").Id("method")).Block(
+				Return(Id("cmd"), True()),
+			),
+		),
+		Return(),
+	)
+	code.Line().Line().Func().Id("run").Params(
+		Id(_ctx_).Qual(packageContext, "Context"),
+		Id("cli").Op("*").Qual(cli.clientPkg, "ClientJsonRPC"),
+		Id("fs").Op("*").Qual(packageFlag, "FlagSet"),
+	).Params(Id("response").Interface(), Err().Error()).Block(
+		Id("args").Op(":=").Id("fs").Dot("Args").Call(),
+		If(Len(Id("args")).Op("<").Lit(2)).Block(
+			Id("fs").Dot("Usage").Call(),
+			Qual(packageOS, "Exit").Call(Lit(2)),
+		),
+		List(Id("cmd"), Id("found")).Op(":=").Id("findCommand").Call(Id("args").Index(Lit(0)), Id("args").Index(Lit(1))),
+		If(Op("!").Id("found")).Block(
+			Return(Nil(), Qual(packageFmt, "Errorf").Call(Lit("unknown method %s %s"), Id("args").Index(Lit(0)), Id("args").Index(Lit(1)))),
+		),
+		Id("request").Op(":=").Id("cmd").Dot("request").Call(),
+		Id("methodFlags").Op(":=").Qual(packageFlag, "NewFlagSet").Call(Id("cmd").Dot("service").Op("+").Lit(" ").Op("+").Id("cmd").Dot("method"), Qual(packageFlag, "ExitOnError")),
+		Id("input").Op(":=").Id("methodFlags").Dot("String").Call(Lit("json"), Lit(""), Lit("read arguments from JSON file, - for stdin")),
+		Id("request").Dot("flags").Call(Id("methodFlags")),
+		Id("_").Op("=").Id("methodFlags").Dot("Parse").Call(Id("args").Index(Lit(2).Op(":"))),
+		If(Op("*").Id("input").Op("!=").Lit("")).Block(
+			If(Err().Op("=").Id("readJSON").Call(Op("*").Id("input"), Id("request")).Op(";").Err().Op("!=").Nil()).Block(
+				Return(),
+			),
+			Comment("flags override arguments from JSON"),
+			Id("_").Op("=").Id("methodFlags").Dot("Parse").Call(Id("args").Index(Lit(2).Op(":"))),
+		),
+		Return(Id("request").Dot("call").Call(Id(_ctx_), Id("cli"))),
+	)
+	return code
+}
+
+func (cli *clientCLI) batchFunc() Code {
+
+	code := Line().Type().Id("batchCall").Struct(
+		Id("Method").String().Tag(map[string]string{"json": "method"}),
+		Id("Params").Qual(packageJson, "RawMessage").Tag(map[string]string{"json": "params,omitempty"}),
+	)
+	code.Line().Line().Type().Id("batchResult").Struct(
+		Id("Method").String().Tag(map[string]string{"json": "method"}),
+		Id("Result").Interface().Tag(map[string]string{"json": "result,omitempty"}),
+		Id("Error").String().Tag(map[string]string{"json": "error,omitempty"}),
+	)
+	code.Line().Line().Func().Params(Id("result").Op("*").Id("batchResult")).Id("set").Params(Id("response").Interface(), Err().Error()).Block(
+		If(Err().Op("!=").Nil()).Block(
+			Id("result").Dot("Error").Op("=").Err().Dot("Error").Call(),
+			Return(),
+		),
+		Id("result").Dot("Result").Op("=").Id("response"),
+	)
+	code.Line().Line().Func().Id("runBatch").Params(
+		Id(_ctx_).Qual(packageContext, "Context"),
+		Id("cli").Op("*").Qual(cli.clientPkg, "ClientJsonRPC"),
+		Id("fileName").String(),
+	).Params(Id("response").Interface(), Err().Error()).Block(
+		Var().Id("calls").Index().Id("batchCall"),
+		If(Err().Op("=").Id("readJSON").Call(Id("fileName"), Op("&").Id("calls")).Op(";").Err().Op("!=").Nil()).Block(
+			Return(),
+		),
+		Id("results").Op(":=").Make(Index().Id("batchResult"), Len(Id("calls"))),
+		Id("pending").Op(":=").Make(Map(Int()).Id("batchRequest")),
+		For(List(Id("i"), Id("call")).Op(":=").Range().Id("calls")).Block(
+			Id("results").Index(Id("i")).Dot("Method").Op("=").Id("call").Dot("Method"),
+			Id("tokens").Op(":=").Qual(packageStrings, "SplitN").Call(Id("call").Dot("Method"), Lit("."), Lit(2)),
+			If(Len(Id("tokens")).Op("!=").Lit(2)).Block(
+				Id("results").Index(Id("i")).Dot("Error").Op("=").Lit("method must be in form service.method"),
+				Continue(),
+			),
+			List(Id("cmd"), Id("found")).Op(":=").Id("findCommand").Call(Id("tokens").Index(Lit(0)), Id("tokens").Index(Lit(1))),
+			If(Op("!").Id("found")).Block(
+				Id("results").Index(Id("i")).Dot("Error").Op("=").Lit("unknown method"),
+				Continue(),
+			),
+			Id("request").Op(":=").Id("cmd").Dot("request").Call(),
+			If(Len(Id("call").Dot("Params")).Op("!=").Lit(0)).Block(
+				If(Err().Op(":=").Qual(packageJson, "Unmarshal").Call(Id("call").Dot("Params"), Id("request")).Op(";").Err().Op("!=").Nil()).Block(
+					Id("results").Index(Id("i")).Dot("Error").Op("=").Err().Dot("Error").Call(),
+					Continue(),
+				),
+			),
+			If(List(Id("batchReq"), Id("ok")).Op(":=").Id("request").Assert(Id("batchRequest")).Op(";").Id("ok")).Block(
+				Id("pending").Index(Id("i")).Op("=").Id("batchReq"),
+				Continue(),
+			),
+			Comment("REST methods can not be batched and are called one by one"),
+			Id("results").Index(Id("i")).Dot("set").Call(Id("request").Dot("call").Call(Id(_ctx_), Id("cli"))),
+		),
+		If(Len(Id("pending")).Op("!=").Lit(0)).Block(
+			Err().Op("=").Id("cli").Dot("BatchFunc").Call(Id(_ctx_), Func().Params(Id("batch").Op("*").Qual(cli.clientPkg, "Batch")).Block(
+				For(List(Id("i"), Id("request")).Op(":=").Range().Id("pending")).Block(
+					Id("request").Dot("append").Call(Id("batch"), Id("cli"), Id("results").Index(Id("i")).Dot("set")),
+				),
+			)),
+		),
+		Return(Id("results"), Err()),
+	)
+	return code
+}
+
+func (cli *clientCLI) helpers() Code {
+
+	code := Line().Type().Id("headerFlags").Index().String()
+	code.Line().Line().Func().Params(Id("headers").Op("*").Id("headerFlags")).Id("String").Params().String().Block(
+		Return(Qual(packageStrings, "Join").Call(Op("*").Id("headers"), Lit(", "))),
+	)
+	code.Line().Line().Func().Params(Id("headers").Op("*").Id("headerFlags")).Id("Set").Params(Id("value").String()).Error().Block(
+		If(Op("!").Qual(packageStrings, "Contains").Call(Id("value"), Lit(":"))).Block(
+			Return(Qual(packageErrors, "New").Call(Lit("header must be in form 'Name: value'"))),
+		),
+		Op("*").Id("headers").Op("=").Append(Op("*").Id("headers"), Id("value")),
+		Return(Nil()),
+	)
+	code.Line().Line().Func().Params(Id("headers").Id("headerFlags")).Id("sign").Params(Id("request").Op("*").Qual(cli.clientPkg, "HTTPRequest")).Error().Block(
+		For(List(Id("_"), Id("header")).Op(":=").Range().Id("headers")).Block(
+			Id("tokens").Op(":=").Qual(packageStrings, "SplitN").Call(Id("header"), Lit(":"), Lit(2)),
+			Id("request").Dot("Header").Dot("Set").Call(Qual(packageStrings, "TrimSpace").Call(Id("tokens").Index(Lit(0))), Qual(packageStrings, "TrimSpace").Call(Id("tokens").Index(Lit(1)))),
+		),
+		Return(Nil()),
+	)
+	code.Line().Line().Comment("jsonFlag parses flag value as JSON, values which are not valid JSON are taken as strings")
+	code.Line().Type().Id("jsonFlag").Struct(
+		Id("value").Interface(),
+		Id("isBool").Bool(),
+	)
+	code.Line().Line().Func().Params(Id("f").Op("*").Id("jsonFlag")).Id("String").Params().String().Block(
+		Return(Lit("")),
+	)
+	code.Line().Line().Func().Params(Id("f").Op("*").Id("jsonFlag")).Id("IsBoolFlag").Params().Bool().Block(
+		Return(Id("f").Dot("isBool")),
+	)
+	code.Line().Line().Func().Params(Id("f").Op("*").Id("jsonFlag")).Id("Set").Params(Id("value").String()).Error().Block(
+		If(Err().Op(":=").Qual(packageJson, "Unmarshal").Call(Index().Byte().Call(Id("value")), Id("f").Dot("value")).Op(";").Err().Op("!=").Nil()).Block(
+			If(Qual(packageJson, "Unmarshal").Call(Index().Byte().Call(Qual(packageStrconv, "Quote").Call(Id("value"))), Id("f").Dot("value")).Op("!=").Nil()).Block(
+				Return(Err()),
+			),
+		),
+		Return(Nil()),
+	)
+	code.Line().Line().Func().Id("readJSON").Params(Id("fileName").String(), Id("value").Interface()).Params(Err().Error()).Block(
+		Var().Id("data").Index().Byte(),
+		If(Id("fileName").Op("==").Lit("-")).Block(
+			List(Id("data"), Err()).Op("=").Qual(packageIO, "ReadAll").Call(Qual(packageOS, "Stdin")),
+		).Else().Block(
+			List(Id("data"), Err()).Op("=").Qual(packageOS, "ReadFile").Call(Id("fileName")),
+		),
+		If(Err().Op("!=").Nil()).Block(
+			Return(),
+		),
+		Return(Qual(packageJson, "Unmarshal").Call(Id("data"), Id("value"))),
+	)
+	code.Line().Line().Func().Id("printJSON").Params(Id("value").Interface(), Id("raw").Bool()).Params(Err().Error()).Block(
+		Var().Id("data").Index().Byte(),
+		If(Id("raw")).Block(
+			List(Id("data"), Err()).Op("=").Qual(packageJson, "Marshal").Call(Id("value")),
+		).Else().Block(
+			List(Id("data"), Err()).Op("=").Qual(packageJson, "MarshalIndent").Call(Id("value"), Lit(""), Lit("  ")),
+		),
+		If(Err().Op("!=").Nil()).Block(
+			Return(),
+		),
+		List(Id("_"), Err()).Op("=").Qual(packageOS, "Stdout").Dot("Write").Call(Append(Id("data"), LitRune('\n'))),
+		Return(),
+	)
+	return code
+}
+
+func (cli *clientCLI) methodRequest(ctx context.Context, svc *service, method *method) Code {
+
+	requestName := cli.requestName(svc, method)
+	responseName := cli.responseName(svc, method)
+	args := method.fieldsArgument()
+	results := method.fieldsResult()
+
+	code := Line().Type().Id(requestName).StructFunc(func(sg *Group) {
+		for _, arg := range args {
+			sg.Id(utils.ToCamel(arg.Name)).Add(fieldType(ctx, arg.Type, false)).Tag(map[string]string{"json": cliFieldName(arg) + ",omitempty"})
+		}
+	})
+	code.Line().Line().Type().Id(responseName).StructFunc(func(sg *Group) {
+		for _, ret := range results {
+			sg.Id(utils.ToCamel(ret.Name)).Add(fieldType(ctx, ret.Type, false)).Tag(map[string]string{"json": cliFieldName(ret)})
+		}
+	})
+	code.Line().Line().Func().Params(Id("r").Op("*").Id(requestName)).Id("flags").Params(Id("fs").Op("*").Qual(packageFlag, "FlagSet")).BlockFunc(func(bg *Group) {
+		for _, arg := range args {
+			flagValue := Dict{Id("value"): Op("&").Id("r").Dot(utils.ToCamel(arg.Name))}
+			if arg.Type.String() == "bool" {
+				flagValue[Id("isBool")] = True()
+			}
+			bg.Id("fs").Dot("Var").Call(Op("&").Id("jsonFlag").Values(flagValue), Lit(cliFieldName(arg)), Lit(arg.Type.String()))
+		}
+	})
+	callArgs := func(cg *Group) {
+		for _, arg := range args {
+			argCode := Id("r").Dot(utils.ToCamel(arg.Name))
+			if types.IsEllipsis(arg.Type) {
+				argCode.Op("...")
+			}
+			cg.Add(argCode)
+		}
+	}
+	code.Line().Line().Func().Params(Id("r").Op("*").Id(requestName)).Id("call").Params(
+		Id(_ctx_).Qual(packageContext, "Context"),
+		Id("cli").Op("*").Qual(cli.clientPkg, "ClientJsonRPC"),
+	).Params(Interface(), Error()).BlockFunc(func(bg *Group) {
+		bg.Var().Err().Error()
+		bg.Var().Id("response").Id(responseName)
+		bg.ListFunc(func(lg *Group) {
+			for _, ret := range results {
+				lg.Id("response").Dot(utils.ToCamel(ret.Name))
+			}
+			if isErrorLast(method.Results) {
+				lg.Err()
+			}
+		}).Op("=").Id("cli").Dot(svc.Name).Call().Dot(method.Name).CallFunc(func(cg *Group) {
+			if isContextFirst(method.Args) {
+				cg.Id(_ctx_)
+			}
+			callArgs(cg)
+		})
+		bg.Return(Id("response"), Err())
+	})
+	if !method.isJsonRPC() {
+		return code
+	}
+	code.Line().Line().Func().Params(Id("r").Op("*").Id(requestName)).Id("append").Params(
+		Id("batch").Op("*").Qual(cli.clientPkg, "Batch"),
+		Id("cli").Op("*").Qual(cli.clientPkg, "ClientJsonRPC"),
+		Id("ret").Func().Params(Interface(), Error()),
+	).Block(
+		Id("batch").Dot("Append").Call(Id("cli").Dot(svc.Name).Call().Dot("Req" + method.Name).CallFunc(func(cg *Group) {
+			cg.Func().ParamsFunc(func(pg *Group) {
+				for _, ret := range method.Results {
+					pg.Id("_" + utils.ToLowerCamel(ret.Name)).Add(fieldType(ctx, ret.Type, false))
+				}
+			}).BlockFunc(func(bg *Group) {
+				errCode := Code(Nil())
+				if isErrorLast(method.Results) {
+					errCode = Id("_" + utils.ToLowerCamel(method.Results[len(method.Results)-1].Name))
+				}
+				bg.Id("ret").Call(Id(responseName).Values(DictFunc(func(dict Dict) {
+					for _, ret := range results {
+						dict[Id(utils.ToCamel(ret.Name))] = Id("_" + utils.ToLowerCamel(ret.Name))
+					}
+				})), errCode)
+			})
+			callArgs(cg)
+		})),
+	)
+	return code
+}
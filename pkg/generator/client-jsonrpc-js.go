@@ -5,16 +5,20 @@ import (
 	"io/ioutil"
 	"os"
 	"path"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/vetcher/go-astra/types"
 
-	"github.com/tundrik/tg/pkg/tags"
-	"github.com/tundrik/tg/pkg/utils"
+	"github.com/tundrik/tg/v2/pkg/tags"
+	"github.com/tundrik/tg/v2/pkg/utils"
 )
 
 type clientJS struct {
 	*Transport
+	pkgName    string
 	knownTypes map[string]int
 	typeDef    map[string]typeDef
 }
@@ -34,106 +38,328 @@ func newClientJS(tr *Transport) (js *clientJS) {
 
 func (js *clientJS) render(outDir string) (err error) {
 
-	outFilename := path.Join(outDir, "jsonrpc-client.js")
-	_ = os.Remove(outFilename)
 	if err = os.MkdirAll(outDir, 0777); err != nil {
 		return
 	}
-	var jsFile bytesWriter
-	jsFile.add(jsonRPCClientBase)
+	var services []*service
 	for _, name := range js.serviceKeys() {
-		svc := js.services[name]
-		if !svc.isJsonRPC() {
-			continue
+		if svc := js.services[name]; len(svc.clientMethods()) != 0 {
+			services = append(services, svc)
 		}
-		jsFile.add("class JSONRPCClient%s {\n", svc.Name)
-		jsFile.add("constructor(transport) {\n")
-		jsFile.add("this.scheduler = new JSONRPCScheduler(transport);\n")
+	}
+	var jsFile bytesWriter
+	jsFile.add("const defaultMaxBatchSize = %d;\n\n", js.tags.ValueInt(tagBatchSize, 0))
+	jsFile.add("const errorNames = {\n")
+	for _, clientErr := range js.clientErrors() {
+		jsFile.add("\"%d\": %q,\n", clientErr.code, clientErr.className)
+	}
+	jsFile.add("};\n")
+	jsFile.WriteString(jsonRPCClientBase)
+	exports := []string{"JSONRPCClient", "JSONRPCError", "HTTPError", "convertError", "fetchTransport", "staticToken", "clientCredentials", "bearerAuth", "hmacSigner"}
+	for _, svc := range services {
+		exports = append(exports, "JSONRPCClient"+svc.Name)
+		jsFile.add("\nclass JSONRPCClient%s {\n", svc.Name)
+		jsFile.add("/**\n")
+		jsFile.add("* @param {string|JSONRPCScheduler|{doRequest: Function, doHTTP: Function, url: string}} transport URL, transport or scheduler of client\n")
+		jsFile.add("* @param {{batchWindow: number, maxBatchSize: number, fetch: Function}} options\n")
+		jsFile.add("**/\n")
+		jsFile.add("constructor(transport, options = {}) {\n")
+		jsFile.add("this.scheduler = transport instanceof JSONRPCScheduler ? transport : new JSONRPCScheduler(transport, options);\n")
 		jsFile.add("}\n\n")
-		for _, method := range svc.methods {
-			jsFile.add("/**\n")
-			if comment := method.tags.Value("summary", ""); comment != "" {
-				jsFile.add("* %s\n", comment)
-				jsFile.add("*\n")
-			}
-			for _, arg := range method.arguments() {
-				switch vType := arg.Variable.Type.(type) {
-				case types.TEllipsis:
-					jsFile.add("* @param {...%s} %s\n", js.walkVariable(arg.Name, svc.pkgPath, vType, method.tags).typeLink(), arg.Name)
-				default:
-					jsFile.add("* @param {%s} %s\n", js.walkVariable(arg.Name, svc.pkgPath, vType, method.tags).typeLink(), arg.Name)
-				}
+		jsFile.add("beforeRequest(...hooks) {\n")
+		jsFile.add("this.scheduler.beforeRequest(...hooks);\n")
+		jsFile.add("return this;\n")
+		jsFile.add("}\n")
+		for _, method := range svc.clientMethods() {
+			jsFile.WriteString(js.methodDoc(method))
+			if method.isJsonRPC() {
+				jsFile.WriteString(js.jsonrpcMethod(method))
+				continue
 			}
-			if len(method.results()) > 0 {
-				var fields []string
-				jsFile.add("* @return {PromiseLike<{")
-				for _, ret := range method.results() {
-					fields = append(fields, fmt.Sprintf("%s: %s", ret.Name, js.walkVariable(ret.Name, svc.pkgPath, ret.Type, method.tags).typeLink()))
-				}
-				jsFile.add(strings.Join(fields, ","))
-				jsFile.add("}>}\n")
+			jsFile.WriteString(js.restMethod(method))
+		}
+		jsFile.add("}\n")
+	}
+	jsFile.add("\nclass JSONRPCClient {\n")
+	jsFile.add("/**\n")
+	jsFile.add("* @param {string|{doRequest: Function, doHTTP: Function, url: string}} transport URL or transport\n")
+	jsFile.add("* @param {{batchWindow: number, maxBatchSize: number, fetch: Function}} options\n")
+	jsFile.add("**/\n")
+	jsFile.add("constructor(transport, options = {}) {\n")
+	jsFile.add("this.scheduler = new JSONRPCScheduler(transport, options);\n")
+	for _, svc := range services {
+		jsFile.add("this.%s = new JSONRPCClient%s(this.scheduler);\n", svc.lccName(), svc.Name)
+	}
+	jsFile.add("}\n\n")
+	jsFile.add("beforeRequest(...hooks) {\n")
+	jsFile.add("this.scheduler.beforeRequest(...hooks);\n")
+	jsFile.add("return this;\n")
+	jsFile.add("}\n")
+	jsFile.add("}\n\n")
+	typeNames := make([]string, 0, len(js.typeDef))
+	for name := range js.typeDef {
+		typeNames = append(typeNames, name)
+	}
+	sort.Strings(typeNames)
+	for _, name := range typeNames {
+		jsFile.WriteString(js.typeDef[name].js())
+	}
+	body := jsFile.String()
+
+	var esmFile bytesWriter
+	esmFile.add("// %s\n", doNotEdit)
+	esmFile.WriteString(body)
+	esmFile.add("export { %s };\n", strings.Join(exports, ", "))
+	esmFile.add("export default JSONRPCClient;\n")
+	if err = ioutil.WriteFile(path.Join(outDir, "jsonrpc-client.js"), esmFile.Bytes(), 0600); err != nil {
+		return
+	}
+	var cjsFile bytesWriter
+	cjsFile.add("// %s\n", doNotEdit)
+	cjsFile.add("\"use strict\";\n")
+	cjsFile.WriteString(body)
+	cjsFile.add("module.exports = { default: JSONRPCClient, %s };\n", strings.Join(exports, ", "))
+	if err = ioutil.WriteFile(path.Join(outDir, "jsonrpc-client.cjs"), cjsFile.Bytes(), 0600); err != nil {
+		return
+	}
+	return ioutil.WriteFile(path.Join(outDir, "package.json"), js.renderPackage(outDir), 0600)
+}
+
+func (js *clientJS) methodDoc(method *method) string {
+
+	var doc bytesWriter
+	doc.add("\n/**\n")
+	if comment := method.tags.Value("summary", ""); comment != "" {
+		doc.add("* %s\n", comment)
+		doc.add("*\n")
+	}
+	for _, arg := range js.methodArgs(method) {
+		switch vType := arg.Variable.Type.(type) {
+		case types.TEllipsis:
+			doc.add("* @param {...%s} %s\n", js.walkVariable(arg.Name, method.svc.pkgPath, vType, method.tags).typeLink(), arg.Name)
+		default:
+			typeLink := js.walkVariable(arg.Name, method.svc.pkgPath, vType, method.tags).typeLink()
+			if _, isUpload := method.uploadVarsMap()[arg.Name]; isUpload && !method.isJsonRPC() {
+				typeLink = "Blob"
 			}
-			jsFile.add("**/\n")
-			jsFile.add("%s(", method.lccName())
-			var fields []string
-			for _, arg := range method.arguments() {
-				var prefix string
-				switch arg.Variable.Type.(type) {
-				case types.TEllipsis:
-					prefix = "..."
-				}
-				fields = append(fields, prefix+utils.ToLowerCamel(arg.Name))
+			doc.add("* @param {%s} %s\n", typeLink, arg.Name)
+		}
+	}
+	doc.add("* @param {{signal: AbortSignal}} [options]\n")
+	var fields []string
+	for _, ret := range method.results() {
+		fields = append(fields, fmt.Sprintf("%s: %s", fieldJsonName(ret), js.walkVariable(ret.Name, method.svc.pkgPath, ret.Type, method.tags).typeLink()))
+	}
+	if !method.isJsonRPC() {
+		for _, retName := range sortedKeys(method.varHeaderMap()) {
+			if ret := method.resultByName(retName); ret != nil {
+				fields = append(fields, fmt.Sprintf("%s: string", ret.Name))
 			}
-			jsFile.add(strings.Join(fields, ","))
-			jsFile.add(") {\n")
-			jsFile.add("return this.scheduler.__scheduleRequest(\"%s\", {", svc.lccName()+"."+method.lccName())
-			fields = []string{}
-			for _, arg := range method.arguments() {
-				fields = append(fields, fmt.Sprintf("%[1]s:%[1]s", utils.ToLowerCamel(arg.Name)))
+		}
+		for _, retName := range sortedKeys(method.downloadVarsMap()) {
+			if ret := method.resultByName(retName); ret != nil {
+				fields = append(fields, fmt.Sprintf("%s: Blob", ret.Name))
 			}
-			jsFile.add(strings.Join(fields, ","))
-			jsFile.add("}).catch(e => { throw ")
-			jsFile.add("%sConvertError(e)", utils.ToLowerCamel(method.fullName()))
-			jsFile.add("; })\n")
-			jsFile.add("}\n")
 		}
-		jsFile.add("}\n\n")
 	}
-	jsFile.add("class JSONRPCClient {\n")
-	jsFile.add("constructor(transport) {\n")
-	for _, name := range js.serviceKeys() {
-		svc := js.services[name]
-		if !svc.isJsonRPC() {
+	doc.add("* @return {Promise<{%s}>}\n", strings.Join(fields, ","))
+	doc.add("**/\n")
+	return doc.String()
+}
+
+func (js *clientJS) methodArgs(method *method) (args []types.StructField) {
+	if method.isJsonRPC() {
+		return method.arguments()
+	}
+	for _, arg := range method.argsWithoutContext() {
+		args = append(args, types.StructField{Variable: arg})
+	}
+	return
+}
+
+// methodParams renders parameters of method, options with AbortSignal are the last parameter
+// or the last value of variadic parameter
+func (js *clientJS) methodParams(method *method) (params string, prologue string) {
+
+	var fields []string
+	variadic := false
+	for _, arg := range js.methodArgs(method) {
+		if types.IsEllipsis(arg.Type) {
+			variadic = true
+			fields = append(fields, "..."+utils.ToLowerCamel(arg.Name))
+			prologue = fmt.Sprintf("let options;\n[%s, options] = splitOptions(%[1]s);\n", utils.ToLowerCamel(arg.Name))
 			continue
 		}
-		jsFile.add("this.%s = new JSONRPCClient%s(transport);\n", svc.lccName(), svc.Name)
+		fields = append(fields, utils.ToLowerCamel(arg.Name))
 	}
-	jsFile.add("}\n")
-	jsFile.add("}\n")
-	jsFile.add("export default JSONRPCClient\n\n")
-	for _, name := range js.serviceKeys() {
-		svc := js.services[name]
-		if !svc.isJsonRPC() {
+	if !variadic {
+		fields = append(fields, "options = {}")
+	}
+	return strings.Join(fields, ", "), prologue
+}
+
+func (js *clientJS) jsonrpcMethod(method *method) string {
+
+	var code bytesWriter
+	params, prologue := js.methodParams(method)
+	code.add("%s(%s) {\n", method.lccName(), params)
+	code.WriteString(prologue)
+	var fields []string
+	for _, arg := range method.arguments() {
+		fields = append(fields, fmt.Sprintf("%s: %s", strconv.Quote(fieldJsonName(arg)), utils.ToLowerCamel(arg.Name)))
+	}
+	code.add("return this.scheduler.__scheduleRequest(%q, {%s}, options, %t);\n", method.jsonrpcName(), strings.Join(fields, ", "), method.svc.isBatchMethod(method))
+	code.add("}\n")
+	return code.String()
+}
+
+func (js *clientJS) restMethod(method *method) string {
+
+	var code bytesWriter
+	params, prologue := js.methodParams(method)
+	code.add("async %s(%s) {\n", method.lccName(), params)
+	code.WriteString(prologue)
+	code.add("const query = new URLSearchParams();\n")
+	for _, argName := range sortedKeys(method.argParamMap()) {
+		if arg := method.argByName(argName); arg != nil {
+			code.WriteString(jsArgValue(arg, fmt.Sprintf("query.set(%q, %%s);", method.argParamMap()[argName])))
+		}
+	}
+	code.add("const request = { method: %q, url: this.scheduler.restURL(%s, query), headers: {} };\n", strings.ToUpper(method.httpMethod()), js.restPath(method))
+	for _, argName := range sortedKeys(method.varHeaderMap()) {
+		if arg := method.argByName(argName); arg != nil {
+			code.WriteString(jsArgValue(arg, fmt.Sprintf("request.headers[%q] = %%s;", method.varHeaderMap()[argName])))
+		}
+	}
+	if cookies := method.argCookieMap(); len(cookies) != 0 {
+		code.add("const cookies = [];\n")
+		for _, argName := range sortedKeys(cookies) {
+			if arg := method.argByName(argName); arg != nil {
+				code.WriteString(jsArgValue(arg, fmt.Sprintf("cookies.push(%q + encodeURIComponent(%%s));", cookies[argName]+"=")))
+			}
+		}
+		code.add("if (cookies.length) {\n")
+		code.add("request.headers[\"Cookie\"] = cookies.join(\"; \");\n")
+		code.add("}\n")
+	}
+	if uploads := method.uploadVarsMap(); len(uploads) != 0 {
+		code.add("const form = new FormData();\n")
+		for _, argName := range sortedKeys(uploads) {
+			if arg := method.argByName(argName); arg != nil {
+				code.add("form.append(%[1]q, %[2]s, %[1]q);\n", uploads[argName], utils.ToLowerCamel(arg.Name))
+			}
+		}
+		code.add("request.body = form;\n")
+	} else if len(method.arguments()) != 0 {
+		var fields []string
+		for _, arg := range method.arguments() {
+			fields = append(fields, fmt.Sprintf("%s: %s", strconv.Quote(fieldJsonName(arg)), utils.ToLowerCamel(arg.Name)))
+		}
+		code.add("request.headers[\"Content-Type\"] = \"application/json\";\n")
+		code.add("request.body = JSON.stringify({%s});\n", strings.Join(fields, ", "))
+	}
+	code.add("const response = await this.scheduler.__send(request, options);\n")
+	for _, retName := range sortedKeys(method.downloadVarsMap()) {
+		if ret := method.resultByName(retName); ret != nil {
+			code.add("return { %s: await response.blob() };\n", strconv.Quote(ret.Name))
+			code.add("}\n")
+			return code.String()
+		}
+	}
+	code.add("const text = await response.text();\n")
+	code.add("const results = text ? JSON.parse(text) : {};\n")
+	for _, retName := range sortedKeys(method.varHeaderMap()) {
+		if ret := method.resultByName(retName); ret != nil {
+			code.add("results[%q] = response.headers.get(%q) || \"\";\n", ret.Name, method.varHeaderMap()[retName])
+		}
+	}
+	code.add("return results;\n")
+	code.add("}\n")
+	return code.String()
+}
+
+// restPath builds expression of URL path with path arguments substituted
+func (js *clientJS) restPath(method *method) string {
+
+	var parts []string
+	var literal string
+	for _, token := range strings.Split(method.httpPath(), "/") {
+		if token == "" {
+			continue
+		}
+		literal += "/"
+		if !strings.HasPrefix(token, ":") {
+			literal += token
 			continue
 		}
-		for _, method := range svc.methods {
-			jsFile.add("function %sConvertError(e) {\n", utils.ToLowerCamel(method.fullName()))
-			jsFile.add("switch(e.code) {\n")
-			jsFile.add("default:\n")
-			jsFile.add("return new JSONRPCError(e.message, \"UnknownError\", e.code, e.data);\n")
-			jsFile.add("}\n}\n")
+		arg := method.argByName(strings.TrimPrefix(token, ":"))
+		if arg == nil {
+			literal += token
+			continue
 		}
+		parts = append(parts, strconv.Quote(literal), fmt.Sprintf("encodeURIComponent(String(%s))", utils.ToLowerCamel(arg.Name)))
+		literal = ""
 	}
-	for _, def := range js.typeDef {
-		jsFile.add(def.js())
+	if literal != "" || len(parts) == 0 {
+		parts = append(parts, strconv.Quote(literal))
 	}
-	return ioutil.WriteFile(outFilename, jsFile.Bytes(), 0600)
+	return strings.Join(parts, " + ")
 }
 
+func (js *clientJS) renderPackage(outDir string) []byte {
+
+	name := js.pkgName
+	if name == "" {
+		name = npmPackageName(outDir)
+	}
+	return []byte(fmt.Sprintf(jsPackage, name, js.tags.Value("version", "0.0.1"), strconv.Quote(js.tags.Value("description", ""))))
+}
+
+// npmPackageName makes name of client package from name of output directory
+func npmPackageName(outDir string) string {
+
+	name := strings.ToLower(strings.Trim(regexp.MustCompile(`[^A-Za-z0-9]+`).ReplaceAllString(path.Base(outDir), "-"), "-"))
+	if name == "" {
+		name = "jsonrpc"
+	}
+	return name + "-client"
+}
+
+// jsArgValue renders statement with argument converted to string, nullable arguments are skipped if not set
+func jsArgValue(arg *types.Variable, statement string) string {
+
+	argName := utils.ToLowerCamel(arg.Name)
+	if _, isPointer := arg.Type.(types.TPointer); isPointer {
+		return fmt.Sprintf("if (%s !== null && %[1]s !== undefined) {\n%s\n}\n", argName, fmt.Sprintf(statement, "String("+argName+")"))
+	}
+	return fmt.Sprintf("%s\n", fmt.Sprintf(statement, "String("+argName+")"))
+}
+
+const jsPackage = `{
+  "name": %q,
+  "version": %q,
+  "description": %s,
+  "type": "module",
+  "main": "./jsonrpc-client.cjs",
+  "module": "./jsonrpc-client.js",
+  "exports": {
+    ".": {
+      "import": "./jsonrpc-client.js",
+      "require": "./jsonrpc-client.cjs"
+    }
+  },
+  "files": [
+    "jsonrpc-client.js",
+    "jsonrpc-client.cjs"
+  ]
+}
+`
+
 type typeDef struct {
 	name       string
 	kind       string
 	typeName   string
+	optional   bool
 	properties map[string]typeDef
 }
 
@@ -166,6 +392,9 @@ func (def typeDef) js() (js string) {
 	case "struct":
 		js += fmt.Sprintf("* @typedef {Object} %s\n", def.name)
 		for name, property := range def.properties {
+			if property.optional {
+				name = "[" + name + "]"
+			}
 			js += fmt.Sprintf("* @property {%s} %s\n", property.def(), name)
 		}
 	default:
@@ -209,7 +438,7 @@ func (js *clientJS) walkVariable(typeName, pkgPath string, varType types.Type, v
 		}
 		if nextType := searchType(pkgPath, vType.TypeName); nextType != nil {
 			if js.knownCount(vType.TypeName) < 2 {
-				js.typeDef[vType.TypeName] = js.walkVariable(typeName, pkgPath, nextType, varTags)
+				js.typeDef[vType.TypeName] = jsEnum(js.walkVariable(typeName, pkgPath, nextType, varTags), pkgPath, vType.TypeName)
 			}
 			js.knownInc(vType.TypeName)
 			return js.typeDef[vType.TypeName]
@@ -239,22 +468,32 @@ func (js *clientJS) walkVariable(typeName, pkgPath string, varType types.Type, v
 		schema.name = vType.Name
 		schema.kind = "struct"
 		schema.typeName = "struct"
+		declared := make(map[string]bool)
+		for _, field := range vType.Fields {
+			if fieldName, inline := jsonName(field); !inline {
+				declared[fieldName] = true
+			}
+		}
 		for _, field := range vType.Fields {
 			if fieldName, inline := jsonName(field); fieldName != "-" {
-				embed := js.walkVariable(field.Name, pkgPath, field.Type, tags.ParseTags(field.Docs))
-				if !inline {
+				fieldTags := tags.ParseTags(field.Docs)
+				embed := js.walkVariable(field.Name, pkgPath, field.Type, fieldTags)
+				embed.optional = !isRequired(field.Type, field.Tags["json"], fieldTags)
+				if !inline || embed.kind != "struct" {
 					schema.properties[fieldName] = embed
 					continue
 				}
-				for eField, def := range js.typeDef[field.Type.String()].properties {
-					schema.properties[eField] = def
+				for eField, def := range embed.properties {
+					if !declared[eField] {
+						schema.properties[eField] = def
+					}
 				}
 			}
 		}
 	case types.TImport:
 		if nextType := searchType(vType.Import.Package, vType.Next.String()); nextType != nil {
 			if js.knownCount(vType.Next.String()) < 2 {
-				js.typeDef[vType.Next.String()] = js.walkVariable(typeName, vType.Import.Package, nextType, varTags)
+				js.typeDef[vType.Next.String()] = jsEnum(js.walkVariable(typeName, vType.Import.Package, nextType, varTags), vType.Import.Package, vType.Next.String())
 			}
 			js.knownInc(vType.Next.String())
 			return js.typeDef[vType.Next.String()]
@@ -276,6 +515,22 @@ func (js *clientJS) walkVariable(typeName, pkgPath string, varType types.Type, v
 	return
 }
 
+// jsEnum replaces scalar type by union of constants declared for named type
+func jsEnum(def typeDef, pkgPath, typeName string) typeDef {
+
+	if def.kind != "scalar" {
+		return def
+	}
+	var literals []string
+	for _, value := range searchEnum(pkgPath, typeName) {
+		literals = append(literals, value.literal())
+	}
+	if len(literals) != 0 {
+		def.typeName = strings.Join(literals, "|")
+	}
+	return def
+}
+
 func (js *clientJS) knownCount(typeName string) int {
 	if _, found := js.knownTypes[typeName]; !found {
 		return 0
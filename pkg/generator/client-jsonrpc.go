@@ -25,6 +25,12 @@ func (tr Transport) renderClientJsonRPC(outDir string) (err error) {
 
 	srcFile.Line().Add(tr.jsonrpcConstants(true))
 
+	srcFile.Line().Comment("defaultMaxBatchSize defines the maximum number of calls in one batch request, 0 means unlimited")
+	srcFile.Const().Id("defaultMaxBatchSize").Op("=").Lit(tr.tags.ValueInt(tagBatchSize, 0))
+
+	srcFile.Line().Comment("ErrMethodUnsupported is returned by methods, which client can not call, for example methods with own handler")
+	srcFile.Var().Id("ErrMethodUnsupported").Op("=").Qual(packageErrors, "New").Call(Lit("method is not supported by client"))
+
 	srcFile.Line().Add(tr.idJsonRPC())
 	srcFile.Type().Id("ErrorDecoder").Func().Params(Id("errData").Qual(packageJson, "RawMessage")).Params(Error())
 	srcFile.Line().Add(tr.baseJsonRPC(true))
@@ -34,30 +40,54 @@ func (tr Transport) renderClientJsonRPC(outDir string) (err error) {
 
 	srcFile.Line().Func().Id("New").Params(Id("name").String(), Id("log").Qual(packageZeroLog, "Logger"), Id("url").String(), Id("opts").Op("...").Id("Option")).Params(Id("cli").Op("*").Id("ClientJsonRPC")).Block(
 
-		Id("cli").Op("=").Op("&").Id("ClientJsonRPC").Values(Dict{
-			Id("name"):         Id("name"),
-			Id("log"):          Id("log"),
-			Id("url"):          Id("url"),
-			Id("errorDecoder"): Id("defaultErrorDecoder"),
-		}),
+		Id("cli").Op("=").Op("&").Id("ClientJsonRPC").Values(DictFunc(func(d Dict) {
+			d[Id("name")] = Id("name")
+			d[Id("log")] = Id("log")
+			d[Id("url")] = Id("url")
+			d[Id("retry")] = Id("defaultRetryPolicy").Call()
+			d[Id("maxBatchSize")] = Id("defaultMaxBatchSize")
+			d[Id("httpTransport")] = Id("defaultHTTPTransport").Call()
+			d[Id("balancer")] = Id("newBalancer").Call(Id("log"))
+			d[Id("errorDecoder")] = Id("defaultErrorDecoder")
+			if tr.hasREST() {
+				d[Id("httpErrorDecoder")] = Id("defaultHTTPErrorDecoder")
+			}
+		})),
 
 		Line().For(List(Id("_"), Id("opt")).Op(":=").Range().Id("opts")).Block(
 			Id("opt").Call(Id("cli")),
 		),
+		If(Id("cli").Dot("transport").Op("==").Nil()).Block(
+			Id("cli").Dot("transport").Op("=").Id("NetHTTPTransport").Call(Op("&").Qual(packageHttp, "Client").Values(Dict{
+				Id("Transport"): Id("cli").Dot("httpTransport"),
+			})),
+		),
+		Comment("breakers report state only with Metrics option, which may follow breaker options"),
+		If(Id("cli").Dot("breaker").Op("!=").Nil()).Block(
+			Id("cli").Dot("breaker").Dot("metrics").Op("=").Id("cli").Dot("metrics"),
+		),
+		For(List(Id("_"), Id("breaker")).Op(":=").Range().Id("cli").Dot("methodBreakers")).Block(
+			Id("breaker").Dot("metrics").Op("=").Id("cli").Dot("metrics"),
+		),
+		If(List(Id("source"), Id("ok")).Op(":=").Id("cli").Dot("tokenSource").Op(".").Parens(Op("*").Id("clientCredentials")).Op(";").Id("ok")).Block(
+			Id("source").Dot("useTransport").Call(Id("cli").Dot("transport")),
+		),
+		Do(func(s *Statement) {
+			for _, name := range tr.serviceKeys() {
+				svc := tr.services[name]
+				if svc.tags.Contains(tagServerJsonRPC) || svc.hasREST() {
+					s.Id("cli").Dot("client" + svc.Name).Op("=").Id("newClient" + svc.Name).Call(Id("cli")).Line()
+				}
+			}
+		}),
 		Return(),
 	)
 
-	var hasTrace bool
 	for _, name := range tr.serviceKeys() {
 		svc := tr.services[name]
-		if svc.tags.IsSet(tagTrace) {
-			hasTrace = true
-		}
-		if svc.tags.Contains(tagServerJsonRPC) {
+		if svc.tags.Contains(tagServerJsonRPC) || svc.hasREST() {
 			srcFile.Line().Func().Params(Id("cli").Op("*").Id("ClientJsonRPC")).Id(svc.Name).Params().Params(Op("*").Id("Client" + svc.Name)).Block(
-				Return(Op("&").Id("Client" + svc.Name).Values(Dict{
-					Id("ClientJsonRPC"): Id("cli"),
-				})),
+				Return(Id("cli").Dot("client" + svc.Name)),
 			)
 		}
 	}
@@ -70,100 +100,177 @@ func (tr Transport) renderClientJsonRPC(outDir string) (err error) {
 	)
 	srcFile.Line().Func().Params(Id("cli").Op("*").Id("ClientJsonRPC")).Id("Batch").
 		Params(Id(_ctx_).Qual(packageContext, "Context"), Id("requests").Op("...").Id("baseJsonRPC")).Params(Err().Error()).BlockFunc(func(pg *Group) {
-		if hasTrace {
-			pg.Id("span").Op(":=").Id("extractSpan").Call(Id("cli").Dot("log"), Id(_ctx_), Id("cli").Dot("name"))
-			pg.Return(Id("cli").Dot("jsonrpcCall").Call(Id(_ctx_), Id("cli").Dot("log"), Id("span"), Id("requests").Op("...")))
-		} else {
-			pg.Return(Id("cli").Dot("jsonrpcCall").Call(Id(_ctx_), Id("cli").Dot("log"), Id("requests").Op("...")))
-		}
+		pg.Return(Id("cli").Dot("jsonrpcCall").Call(Id(_ctx_), Id("cli").Dot("log"), Id("requests").Op("...")))
 	})
 	srcFile.Line().Func().Params(Id("cli").Op("*").Id("ClientJsonRPC")).Id("BatchFunc").Params(Id(_ctx_).Qual(packageContext, "Context"), Id("batchFunc").Func().
 		Params(Id("requests").Op("*").Id("Batch"))).Params(Err().Error()).BlockFunc(func(pg *Group) {
 		pg.Var().Id("requests").Id("Batch")
 		pg.Id("batchFunc").Call(Op("&").Id("requests"))
-		if hasTrace {
-			pg.Id("span").Op(":=").Id("extractSpan").Call(Id("cli").Dot("log"), Id(_ctx_), Id("cli").Dot("name"))
-			pg.Return(Id("cli").Dot("jsonrpcCall").Call(Id(_ctx_), Id("cli").Dot("log"), Id("span"), Id("requests").Op("...")))
-		} else {
-			pg.Return(Id("cli").Dot("jsonrpcCall").Call(Id(_ctx_), Id("cli").Dot("log"), Id("requests").Op("...")))
-		}
+		pg.Return(Id("cli").Dot("jsonrpcCall").Call(Id(_ctx_), Id("cli").Dot("log"), Id("requests").Op("...")))
 	})
-	srcFile.Line().Add(tr.jsonrpcClientCallFunc(hasTrace))
+	srcFile.Line().Add(tr.jsonrpcClientCallFunc())
+	srcFile.Line().Add(tr.jsonrpcClientAttemptFunc())
 	return srcFile.Save(path.Join(outDir, "jsonrpc.go"))
 }
 
 func (tr Transport) jsonrpcClientStructFunc() Code {
 
-	return Type().Id("ClientJsonRPC").Struct(
-		Id("url").String(),
-		Id("name").String(),
-		Id("log").Qual(packageZeroLog, "Logger"),
-		Id("headers").Op("[]").String(),
-		Line().Id("errorDecoder").Id("ErrorDecoder"),
-	)
+	return Type().Id("ClientJsonRPC").StructFunc(func(g *Group) {
+		g.Id("url").String()
+		g.Id("name").String()
+		g.Id("log").Qual(packageZeroLog, "Logger")
+		g.Id("headers").Op("[]").String()
+		g.Id("maxBatchSize").Int()
+		g.Id("transport").Id("HTTPTransport")
+		g.Id("httpTransport").Op("*").Qual(packageHttp, "Transport")
+		g.Id("timeout").Qual(packageTime, "Duration")
+		g.Id("methodTimeouts").Map(String()).Qual(packageTime, "Duration")
+		g.Id("retry").Id("retryPolicy")
+		g.Id("bulkhead").Op("*").Id("bulkhead")
+		g.Id("balancer").Op("*").Id("balancer")
+		g.Id("breaker").Op("*").Id("circuitBreaker")
+		g.Id("methodBreakers").Map(String()).Op("*").Id("circuitBreaker")
+		g.Id("tokenSource").Id("TokenSource")
+		g.Id("signers").Op("[]").Id("RequestSigner")
+		g.Id("logging").Bool()
+		g.Id("metrics").Bool()
+		g.Id("tracing").Bool()
+		g.Line().Id("errorDecoder").Id("ErrorDecoder")
+		if tr.hasREST() {
+			g.Id("httpErrorDecoder").Id("HTTPErrorDecoder")
+		}
+		g.Line()
+		for _, name := range tr.serviceKeys() {
+			svc := tr.services[name]
+			if svc.tags.Contains(tagServerJsonRPC) || svc.hasREST() {
+				g.Id("client" + svc.Name).Op("*").Id("Client" + svc.Name)
+			}
+		}
+	})
 }
 
-func (tr Transport) jsonrpcClientCallFunc(hasTrace bool) Code {
+// jsonrpcClientCallFunc renders batch call with retries, batches larger than maxBatchSize are sent by chunks.
+func (tr Transport) jsonrpcClientCallFunc() Code {
 
 	return Func().Params(Id("cli").Op("*").Id("ClientJsonRPC")).Id("jsonrpcCall").
 		ParamsFunc(func(pg *Group) {
 			pg.Id(_ctx_).Qual(packageContext, "Context")
 			pg.Id("log").Qual(packageZeroLog, "Logger")
-			if hasTrace {
-				pg.Id("span").Qual(packageOpentracing, "Span")
-			}
 			pg.Id("requests").Op("...").Id("baseJsonRPC")
 		}).Params(Err().Error()).BlockFunc(func(bg *Group) {
-		if hasTrace {
-			bg.Defer().Id("span").Dot("Finish").Call()
-		}
-		bg.Id("agent").Op(":=").Qual(packageFiber, "AcquireAgent").Call()
-		bg.Id("req").Op(":=").Id("agent").Dot("Request").Call()
-		bg.Id("resp").Op(":=").Qual(packageFiber, "AcquireResponse").Call()
-		bg.Id("agent").Dot("SetResponse").Call(Id("resp"))
-		bg.Defer().Qual(packageFiber, "ReleaseResponse").Call(Id("resp"))
-
-		bg.Line().Id("req").Dot("SetRequestURI").Call(Id("cli").Dot("url"))
-		bg.Id("agent").Dot("ContentType").Call(Id("contentTypeJson"))
-		bg.Id("req").Dot("Header").Dot("SetMethod").Call(Qual(packageFiber, "MethodPost"))
-		bg.If(Err().Op("=").Id("agent").Dot("Parse").Call().Op(";").Err().Op("!=").Nil()).Block(
+		bg.If(Id("cli").Dot("maxBatchSize").Op(">").Lit(0).Op("&&").Len(Id("requests")).Op(">").Id("cli").Dot("maxBatchSize")).Block(
+			For(Len(Id("requests")).Op(">").Lit(0)).Block(
+				Id("size").Op(":=").Id("cli").Dot("maxBatchSize"),
+				If(Id("size").Op(">").Len(Id("requests"))).Block(
+					Id("size").Op("=").Len(Id("requests")),
+				),
+				If(Id("chunkErr").Op(":=").Id("cli").Dot("jsonrpcCall").Call(Id(_ctx_), Id("log"), Id("requests").Op("[:").Id("size").Op("]...")).Op(";").Id("chunkErr").Op("!=").Nil().Op("&&").Err().Op("==").Nil()).Block(
+					Err().Op("=").Id("chunkErr"),
+				),
+				Id("requests").Op("=").Id("requests").Op("[").Id("size").Op(":]"),
+			),
 			Return(),
 		)
+		bg.Var().Id("lostErr").Error()
+		bg.For(Id("attempt").Op(":=").Lit(1).Op(";").Op(";").Id("attempt").Op("++")).BlockFunc(func(fg *Group) {
+			fg.Var().Id("failed").Op("[]").Id("baseJsonRPC")
+			fg.List(Id("failed"), Err()).Op("=").Id("cli").Dot("jsonrpcAttempt").CallFunc(func(cg *Group) {
+				cg.Id(_ctx_)
+				cg.Id("log")
+				cg.Id("requests").Op("...")
+			})
+			fg.Id("requests").Op("=").Id("requests").Op("[:0:0]")
+			fg.For(List(Id("_"), Id("request")).Op(":=").Range().Id("failed")).Block(
+				If(Id("request").Dot("idempotent")).Block(
+					Id("requests").Op("=").Append(Id("requests"), Id("request")),
+				).Else().If(Err().Op("!=").Nil()).Block(
+					Id("lostErr").Op("=").Err(),
+				),
+			)
+			fg.If(Len(Id("requests")).Op("==").Lit(0).Op("||").Id("attempt").Op(">=").Id("cli").Dot("retry").Dot("maxAttempts")).Block(
+				Break(),
+			)
+			fg.Id("log").Dot("Warn").Call().Dot("Err").Call(Err()).Dot("Int").Call(Lit("attempt"), Id("attempt")).Dot("Int").Call(Lit("requests"), Len(Id("requests"))).Dot("Msg").Call(Lit("retry jsonrpc call"))
+			fg.If(Id("waitErr").Op(":=").Id("cli").Dot("retry").Dot("wait").Call(Id(_ctx_), Id("attempt")).Op(";").Id("waitErr").Op("!=").Nil()).Block(
+				Break(),
+			)
+		})
+		bg.If(Err().Op("==").Nil()).Block(
+			Err().Op("=").Id("lostErr"),
+		)
+		bg.Return()
+	})
+}
 
-		bg.Line().List(Id("requestID"), Id("_")).Op(":=").Id(_ctx_).Dot("Value").Call(Id("headerRequestID")).Op(".(").String().Op(")")
-		bg.If(Id("requestID").Op("==").Lit("")).Block(
-			Id("requestID").Op("=").Qual(packageUUID, "New").Call().Dot("String").Call(),
+// jsonrpcClientAttemptFunc renders single HTTP exchange of batch, it returns requests which may be retried.
+func (tr Transport) jsonrpcClientAttemptFunc() Code {
+
+	return Func().Params(Id("cli").Op("*").Id("ClientJsonRPC")).Id("jsonrpcAttempt").
+		ParamsFunc(func(pg *Group) {
+			pg.Id(_ctx_).Qual(packageContext, "Context")
+			pg.Id("log").Qual(packageZeroLog, "Logger")
+			pg.Id("requests").Op("...").Id("baseJsonRPC")
+		}).Params(Id("failed").Op("[]").Id("baseJsonRPC"), Err().Error()).BlockFunc(func(bg *Group) {
+		bg.Id("httpRequest").Op(":=").Id("cli").Dot("newRequest").Call(Id(_ctx_), Qual(packageHttp, "MethodPost"), Id("cli").Dot("url"))
+		bg.Id("httpRequest").Dot("Header").Dot("Set").Call(Lit("Content-Type"), Id("contentTypeJson"))
+		bg.If(List(Id("httpRequest").Dot("Body"), Err()).Op("=").Qual(packageJson, "Marshal").Call(Id("requests")).Op(";").Err().Op("!=").Nil()).Block(
+			Return(),
 		)
-		bg.Id("req").Dot("Header").Dot("Set").Call(Id("headerRequestID"), Id("requestID"))
-		bg.For(List(Id("_"), Id("header")).Op(":=").Range().Id("cli").Dot("headers")).Block(
-			If(List(Id("value"), Id("ok")).Op(":=").Id(_ctx_).Dot("Value").Call(Id("header")).Op(".(").String().Op(")")).Op(";").Id("ok").Block(
-				Id("req").Dot("Header").Dot("Set").Call(Id("header"), Id("value")),
-			),
+		bg.Id("methods").Op(":=").Make(Index().String(), Lit(0), Len(Id("requests")))
+		bg.For(List(Id("_"), Id("request")).Op(":=").Range().Id("requests")).Block(
+			Id("methods").Op("=").Append(Id("methods"), Id("request").Dot("Method")),
 		)
-		bg.If(Err().Op("=").Qual(packageJson, "NewEncoder").Call(Id("req").Dot("BodyWriter").Call()).Dot("Encode").Call(Id("requests")).Op(";").Err().Op("!=").Nil()).Block(
+		bg.Var().Id("httpResponse").Op("*").Id("HTTPResponse")
+		bg.If(List(Id("httpResponse"), Err()).Op("=").Id("cli").Dot("exchange").Call(Id(_ctx_), Id("httpRequest"), Id("methods").Op("...")).Op(";").Err().Op("!=").Nil()).Block(
+			If(Id("cli").Dot("retry").Dot("retryIf").Call(Lit(0), Err())).Block(
+				Id("failed").Op("=").Id("requests"),
+			),
 			Return(),
 		)
-		if hasTrace {
-			bg.Id("injectSpan").Call(Id("log"), Id("span"), Id("req"))
-		}
-		bg.If(Err().Op("=").Id("agent").Dot("Do").Call(Id("req"), Id("resp")).Op(";").Err().Op("!=").Nil()).Block(
+		bg.If(Id("httpResponse").Dot("StatusCode").Op("<").Qual(packageHttp, "StatusOK").Op("||").Id("httpResponse").Dot("StatusCode").Op(">=").Qual(packageHttp, "StatusMultipleChoices")).Block(
+			Err().Op("=").Qual(packageFmt, "Errorf").Call(Lit("http status %d: %s"), Id("httpResponse").Dot("StatusCode"), Id("httpResponse").Dot("Body")),
+			If(Id("cli").Dot("retry").Dot("retryIf").Call(Id("httpResponse").Dot("StatusCode"), Err())).Block(
+				Id("failed").Op("=").Id("requests"),
+			),
 			Return(),
 		)
-		bg.Id("responseMap").Op(":=").Make(Map(String()).Func().Params(Id("baseJsonRPC")))
+		bg.Id("responseMap").Op(":=").Make(Map(String()).Id("baseJsonRPC"))
 		bg.For(List(Id("_"), Id("request")).Op(":=").Range().Id("requests")).Block(
 
 			If(Id("request").Dot("ID").Op("!=").Nil()).Block(
-				Id("responseMap").Op("[").String().Call(Id("request").Dot("ID")).Op("]").Op("=").Id("request").Dot("retHandler"),
+				Id("responseMap").Op("[").String().Call(Id("request").Dot("ID")).Op("]").Op("=").Id("request"),
 			),
 		)
 		bg.Var().Id("responses").Op("[]").Id("baseJsonRPC")
-		bg.If(Err().Op("=").Qual(packageJson, "Unmarshal").Call(Id("resp").Dot("Body").Call(), Op("&").Id("responses")).Op(";").Err().Op("!=").Nil()).Block(
-			Id("cli").Dot("log").Dot("Error").Call().Dot("Err").Call(Err()).Dot("Str").Call(Lit("response"), String().Call(Id("resp").Dot("Body").Call())).Dot("Msg").Call(Lit("unmarshal response error")),
-			Return(),
+		bg.If(Err().Op("=").Qual(packageJson, "Unmarshal").Call(Id("httpResponse").Dot("Body"), Op("&").Id("responses")).Op(";").Err().Op("!=").Nil()).Block(
+			Var().Id("response").Id("baseJsonRPC"),
+			If(Qual(packageJson, "Unmarshal").Call(Id("httpResponse").Dot("Body"), Op("&").Id("response")).Op("!=").Nil()).Block(
+				Id("cli").Dot("log").Dot("Error").Call().Dot("Err").Call(Err()).Dot("Str").Call(Lit("response"), String().Call(Id("httpResponse").Dot("Body"))).Dot("Msg").Call(Lit("unmarshal response error")),
+				Return(),
+			),
+			Err().Op("=").Nil(),
+			Comment("error with null id rejects whole batch, it is delivered to every request"),
+			If(Id("response").Dot("Error").Op("!=").Nil().Op("&&").Parens(Len(Id("response").Dot("ID")).Op("==").Lit(0).Op("||").String().Call(Id("response").Dot("ID")).Op("==").Lit("null"))).Block(
+				For(List(Id("_"), Id("request")).Op(":=").Range().Id("requests")).Block(
+					If(Id("request").Dot("ID").Op("!=").Nil()).Block(
+						Id("response").Dot("ID").Op("=").Id("request").Dot("ID"),
+						Id("responses").Op("=").Append(Id("responses"), Id("response")),
+					),
+				),
+			).Else().Block(
+				Id("responses").Op("=").Append(Id("responses"), Id("response")),
+			),
 		)
 		bg.For(List(Id("_"), Id("response")).Op(":=").Range().Id("responses")).Block(
-			If(List(Id("handler"), Id("found")).Op(":=").Id("responseMap").Op("[").String().Call(Id("response").Dot("ID")).Op("]").Op(";").Id("found")).Block(
-				Id("handler").Call(Id("response")),
+			List(Id("request"), Id("found")).Op(":=").Id("responseMap").Op("[").String().Call(Id("response").Dot("ID")).Op("]"),
+			If(Op("!").Id("found")).Block(
+				Continue(),
+			),
+			If(Id("request").Dot("retHandler").Op("!=").Nil()).Block(
+				Id("request").Dot("retHandler").Call(Id("response")),
+			),
+			If(Id("response").Dot("Error").Op("!=").Nil().Op("&&").Id("cli").Dot("retry").Dot("retryIf").Call(Id("httpResponse").Dot("StatusCode"), Id("cli").Dot("errorDecoder").Call(Id("response").Dot("Error")))).Block(
+				Id("failed").Op("=").Append(Id("failed"), Id("request")),
 			),
 		)
 		bg.Return()
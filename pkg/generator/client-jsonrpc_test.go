@@ -0,0 +1,36 @@
+package generator
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestClientMaxBatchSize(t *testing.T) {
+
+	modDir := newTestModule(t, map[string]string{
+		"service/service.go": `// @tg jsonRPC-batch-size=10
+package service
+
+import "context"
+
+// @tg jsonRPC-server log
+type Users interface {
+	Get(ctx context.Context, id int) (name string, err error)
+}
+`,
+	})
+	tr := newTestTransport(t, filepath.Join(modDir, "service"))
+	if err := tr.RenderClient(filepath.Join(modDir, "client")); err != nil {
+		t.Fatal(err)
+	}
+	assertContains(t, readTestFile(t, filepath.Join(modDir, "client", "jsonrpc.go")),
+		`const defaultMaxBatchSize = 10`,
+		"defaultMaxBatchSize,\n",
+		`if chunkErr := cli.jsonrpcCall(ctx, log, requests[:size]...); chunkErr != nil && err == nil {`,
+		`if response.Error != nil && (len(response.ID) == 0 || string(response.ID) == "null") {`,
+	)
+	assertContains(t, readTestFile(t, filepath.Join(modDir, "client", "options.go")),
+		`func MaxBatchSize(size int) Option {`,
+	)
+	typeCheck(t, modDir, filepath.Join(modDir, "client"))
+}
@@ -0,0 +1,77 @@
+package generator
+
+import (
+	"path"
+	"path/filepath"
+
+	. "github.com/dave/jennifer/jen"
+)
+
+func (tr Transport) renderClientMetrics(outDir string) (err error) {
+
+	srcFile := newSrc(filepath.Base(outDir))
+	srcFile.PackageComment(doNotEdit)
+
+	srcFile.ImportName(packageGoKitMetrics, "metrics")
+	srcFile.ImportAlias(packageStdPrometheus, "stdPrometheus")
+	srcFile.ImportAlias(packageKitPrometheus, "kitPrometheus")
+
+	labels := Index().String().Values(Lit("client"), Lit("service"), Lit("method"), Lit("code"))
+
+	srcFile.Line().Var().Id("RequestCount").Op("=").Id("newCounter").Call(Qual(packageStdPrometheus, "CounterOpts").Values(Dict{
+		Id("Name"):      Lit("count"),
+		Id("Namespace"): Lit("client"),
+		Id("Subsystem"): Lit("requests"),
+		Id("Help"):      Lit("Number of client calls"),
+	}), labels)
+	srcFile.Var().Id("ErrorCount").Op("=").Id("newCounter").Call(Qual(packageStdPrometheus, "CounterOpts").Values(Dict{
+		Id("Name"):      Lit("error_count"),
+		Id("Namespace"): Lit("client"),
+		Id("Subsystem"): Lit("requests"),
+		Id("Help"):      Lit("Number of failed client calls"),
+	}), labels)
+	srcFile.Var().Id("RequestLatency").Op("=").Id("newHistogram").Call(Qual(packageStdPrometheus, "HistogramOpts").Values(Dict{
+		Id("Name"):      Lit("latency_seconds"),
+		Id("Namespace"): Lit("client"),
+		Id("Subsystem"): Lit("requests"),
+		Id("Help"):      Lit("Duration of client calls in seconds"),
+		Id("Buckets"):   Qual(packageStdPrometheus, "DefBuckets"),
+	}), labels)
+
+	srcFile.Line().Func().Id("newCounter").Params(Id("opts").Qual(packageStdPrometheus, "CounterOpts"), Id("labels").Op("[]").String()).Params(Qual(packageGoKitMetrics, "Counter")).Block(
+		Id("counterVec").Op(":=").Qual(packageStdPrometheus, "NewCounterVec").Call(Id("opts"), Id("labels")),
+		If(Err().Op(":=").Qual(packageStdPrometheus, "Register").Call(Id("counterVec")).Op(";").Err().Op("!=").Nil()).Block(
+			If(List(Id("registered"), Id("ok")).Op(":=").Err().Op(".(").Qual(packageStdPrometheus, "AlreadyRegisteredError").Op(")").Op(";").Id("ok")).Block(
+				Id("counterVec").Op("=").Id("registered").Dot("ExistingCollector").Op(".(*").Qual(packageStdPrometheus, "CounterVec").Op(")"),
+			),
+		),
+		Return(Qual(packageKitPrometheus, "NewCounter").Call(Id("counterVec"))),
+	)
+
+	srcFile.Line().Func().Id("newHistogram").Params(Id("opts").Qual(packageStdPrometheus, "HistogramOpts"), Id("labels").Op("[]").String()).Params(Qual(packageGoKitMetrics, "Histogram")).Block(
+		Id("histogramVec").Op(":=").Qual(packageStdPrometheus, "NewHistogramVec").Call(Id("opts"), Id("labels")),
+		If(Err().Op(":=").Qual(packageStdPrometheus, "Register").Call(Id("histogramVec")).Op(";").Err().Op("!=").Nil()).Block(
+			If(List(Id("registered"), Id("ok")).Op(":=").Err().Op(".(").Qual(packageStdPrometheus, "AlreadyRegisteredError").Op(")").Op(";").Id("ok")).Block(
+				Id("histogramVec").Op("=").Id("registered").Dot("ExistingCollector").Op(".(*").Qual(packageStdPrometheus, "HistogramVec").Op(")"),
+			),
+		),
+		Return(Qual(packageKitPrometheus, "NewHistogram").Call(Id("histogramVec"))),
+	)
+
+	srcFile.Line().Comment("callCode returns metric label of call result: ok, jsonRPC or HTTP error code, or error")
+	srcFile.Func().Id("callCode").Params(Err().Error()).Params(String()).Block(
+		If(Err().Op("==").Nil()).Block(
+			Return(Lit("ok")),
+		),
+		Var().Id("jsonrpcError").Id("errorJsonRPC"),
+		If(Qual(packageErrors, "As").Call(Err(), Op("&").Id("jsonrpcError"))).Block(
+			Return(Qual(packageStrconv, "Itoa").Call(Id("jsonrpcError").Dot("Code"))),
+		),
+		Var().Id("coder").Interface(Id("Code").Params().Params(Int())),
+		If(Qual(packageErrors, "As").Call(Err(), Op("&").Id("coder"))).Block(
+			Return(Qual(packageStrconv, "Itoa").Call(Id("coder").Dot("Code").Call())),
+		),
+		Return(Lit("error")),
+	)
+	return srcFile.Save(path.Join(outDir, "metrics.go"))
+}
@@ -0,0 +1,545 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+
+	"github.com/tundrik/tg/v2/pkg/utils"
+)
+
+type clientModule struct {
+	*Transport
+	outDir     string
+	modulePath string
+	version    string
+	srvModule  string
+	srvRoot    string
+	srvMod     *modfile.File
+	packages   map[string]*typesPackage
+}
+
+type typesPackage struct {
+	name    string
+	fset    *token.FileSet
+	sources map[*ast.File][]byte
+	types   map[string]typeSource
+	consts  []typeSource
+	imports map[string]string
+	copied  map[token.Pos]typeSource
+}
+
+type typeSource struct {
+	file *ast.File
+	doc  *ast.CommentGroup
+	node ast.Node
+	decl bool
+}
+
+func (tr Transport) RenderClientModule(outDir, modulePath, version string) (err error) {
+
+	if modulePath == "" {
+		modulePath = tr.tags.Value(tagClientModule)
+	}
+	if modulePath == "" {
+		return
+	}
+	if version == "" {
+		version = tr.tags.Value(tagClientVersion, tr.tags.Value("version", "0.0.1"))
+	}
+	if !strings.HasPrefix(version, "v") {
+		version = "v" + version
+	}
+	return newClientModule(&tr, outDir, modulePath, version).render()
+}
+
+func newClientModule(tr *Transport, outDir, modulePath, version string) (cm *clientModule) {
+	cm = &clientModule{
+		Transport:  tr,
+		outDir:     outDir,
+		version:    version,
+		modulePath: modulePath,
+		packages:   make(map[string]*typesPackage),
+	}
+	return
+}
+
+func (cm *clientModule) render() (err error) {
+
+	var goModPath string
+	if goModPath, err = utils.GoModPath(cm.svcDir, true); err != nil {
+		return
+	}
+	var modBytes []byte
+	if modBytes, err = os.ReadFile(goModPath); err != nil {
+		return
+	}
+	if cm.srvMod, err = modfile.Parse(goModPath, modBytes, nil); err != nil {
+		return
+	}
+	cm.srvRoot = filepath.Dir(goModPath)
+	cm.srvModule = cm.srvMod.Module.Mod.Path
+
+	var clientFiles []string
+	err = filepath.Walk(cm.outDir, func(filePath string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() && strings.HasSuffix(filePath, ".go") {
+			clientFiles = append(clientFiles, filePath)
+		}
+		return err
+	})
+	if err != nil {
+		return
+	}
+	for _, filePath := range clientFiles {
+		fset := token.NewFileSet()
+		var file *ast.File
+		if file, err = parser.ParseFile(fset, filePath, nil, 0); err != nil {
+			return
+		}
+		ast.Inspect(file, func(node ast.Node) bool {
+			if sel, ok := node.(*ast.SelectorExpr); ok {
+				if ident, ok := sel.X.(*ast.Ident); ok {
+					if pkgPath, _ := cm.fileImport(file, ident.Name); cm.isServerPkg(pkgPath) {
+						cm.copyType(pkgPath, sel.Sel.Name)
+					}
+				}
+			}
+			return true
+		})
+	}
+	for _, pkgPath := range cm.packageKeys() {
+		if err = cm.renderTypes(pkgPath); err != nil {
+			return
+		}
+	}
+	for _, filePath := range clientFiles {
+		if err = cm.rewriteImports(filePath); err != nil {
+			return
+		}
+	}
+	if err = cm.renderVersion(); err != nil {
+		return
+	}
+	if err = cm.renderGoMod(); err != nil {
+		return
+	}
+	return os.WriteFile(path.Join(cm.outDir, "README.md"), cm.renderReadme(), 0644)
+}
+
+func (cm *clientModule) isServerPkg(pkgPath string) bool {
+	return pkgPath != "" && (pkgPath == cm.srvModule || strings.HasPrefix(pkgPath, cm.srvModule+"/"))
+}
+
+func (cm *clientModule) localPath(pkgPath string) string {
+	return path.Join(cm.modulePath, strings.TrimPrefix(pkgPath, cm.srvModule))
+}
+
+func (cm *clientModule) packageKeys() (keys []string) {
+	for pkgPath, pkg := range cm.packages {
+		if len(pkg.copied) != 0 {
+			keys = append(keys, pkgPath)
+		}
+	}
+	sort.Strings(keys)
+	return
+}
+
+func (cm *clientModule) fileImport(file *ast.File, name string) (pkgPath, alias string) {
+
+	for _, imp := range file.Imports {
+		impPath, _ := strconv.Unquote(imp.Path.Value)
+		if imp.Name != nil {
+			if imp.Name.Name == name {
+				return impPath, name
+			}
+			continue
+		}
+		if cm.isServerPkg(impPath) {
+			if pkg := cm.loadPackage(impPath); pkg != nil && pkg.name == name {
+				return impPath, ""
+			}
+			continue
+		}
+		if guessPkgName(impPath) == name {
+			return impPath, ""
+		}
+	}
+	return
+}
+
+func guessPkgName(pkgPath string) string {
+
+	tokens := strings.Split(pkgPath, "/")
+	name := tokens[len(tokens)-1]
+	if len(tokens) > 1 && len(name) > 1 && name[0] == 'v' && strings.Trim(name[1:], "0123456789") == "" {
+		name = tokens[len(tokens)-2]
+	}
+	name = strings.Split(name, ".")[0]
+	return strings.ReplaceAll(strings.TrimPrefix(name, "go-"), "-", "")
+}
+
+func (cm *clientModule) loadPackage(pkgPath string) (pkg *typesPackage) {
+
+	if pkg, found := cm.packages[pkgPath]; found {
+		return pkg
+	}
+	pkgDir := filepath.Join(cm.srvRoot, filepath.FromSlash(strings.TrimPrefix(pkgPath, cm.srvModule)))
+	files, err := filepath.Glob(filepath.Join(pkgDir, "*.go"))
+	if err != nil || len(files) == 0 {
+		cm.log.WithField("package", pkgPath).Warn("exchange types package not found")
+		return nil
+	}
+	pkg = &typesPackage{
+		fset:    token.NewFileSet(),
+		sources: make(map[*ast.File][]byte),
+		types:   make(map[string]typeSource),
+		imports: make(map[string]string),
+		copied:  make(map[token.Pos]typeSource),
+	}
+	for _, filePath := range files {
+		if strings.HasSuffix(filePath, "_test.go") {
+			continue
+		}
+		var src []byte
+		if src, err = os.ReadFile(filePath); err != nil {
+			continue
+		}
+		var file *ast.File
+		if file, err = parser.ParseFile(pkg.fset, filePath, src, parser.ParseComments); err != nil {
+			cm.log.WithError(err).WithField("file", filePath).Warn("parse exchange types")
+			continue
+		}
+		pkg.name = file.Name.Name
+		pkg.sources[file] = src
+		for _, decl := range file.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok {
+				continue
+			}
+			switch genDecl.Tok {
+			case token.TYPE:
+				for _, spec := range genDecl.Specs {
+					typeSpec := spec.(*ast.TypeSpec)
+					doc := typeSpec.Doc
+					if genDecl.Lparen == token.NoPos {
+						doc = genDecl.Doc
+					}
+					pkg.types[typeSpec.Name.Name] = typeSource{file: file, doc: doc, node: typeSpec}
+				}
+			case token.CONST:
+				pkg.consts = append(pkg.consts, typeSource{file: file, doc: genDecl.Doc, node: genDecl, decl: true})
+			}
+		}
+	}
+	cm.packages[pkgPath] = pkg
+	return
+}
+
+func (cm *clientModule) copyType(pkgPath, typeName string) {
+
+	pkg := cm.loadPackage(pkgPath)
+	if pkg == nil {
+		return
+	}
+	source, found := pkg.types[typeName]
+	if !found {
+		if constSource, isConst := pkg.constDecl(typeName); isConst {
+			if _, copied := pkg.copied[constSource.node.Pos()]; !copied {
+				pkg.copied[constSource.node.Pos()] = constSource
+				cm.copyRefs(pkgPath, constSource.file, constSource.node)
+			}
+			return
+		}
+		cm.log.WithField("type", pkgPath+"."+typeName).Warn("exchange type not found")
+		return
+	}
+	if _, copied := pkg.copied[source.node.Pos()]; copied {
+		return
+	}
+	pkg.copied[source.node.Pos()] = source
+	typeSpec := source.node.(*ast.TypeSpec)
+	if typeSpec.TypeParams != nil {
+		cm.copyRefs(pkgPath, source.file, typeSpec.TypeParams)
+	}
+	cm.copyRefs(pkgPath, source.file, typeSpec.Type)
+
+	for _, constSource := range pkg.consts {
+		genDecl := constSource.node.(*ast.GenDecl)
+		if _, copied := pkg.copied[genDecl.Pos()]; copied || len(genDecl.Specs) == 0 {
+			continue
+		}
+		if ident, ok := genDecl.Specs[0].(*ast.ValueSpec).Type.(*ast.Ident); ok && ident.Name == typeName {
+			pkg.copied[genDecl.Pos()] = constSource
+			cm.copyRefs(pkgPath, constSource.file, genDecl)
+		}
+	}
+}
+
+// constDecl returns const declaration, which declares constant with given name
+func (pkg *typesPackage) constDecl(name string) (source typeSource, found bool) {
+
+	for _, source = range pkg.consts {
+		for _, spec := range source.node.(*ast.GenDecl).Specs {
+			for _, ident := range spec.(*ast.ValueSpec).Names {
+				if ident.Name == name {
+					return source, true
+				}
+			}
+		}
+	}
+	return typeSource{}, false
+}
+
+func (cm *clientModule) copyRefs(pkgPath string, file *ast.File, node ast.Node) {
+
+	pkg := cm.packages[pkgPath]
+	var inspect func(node ast.Node) bool
+	inspect = func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.Field:
+			ast.Inspect(node.Type, inspect)
+			return false
+		case *ast.ValueSpec:
+			if node.Type != nil {
+				ast.Inspect(node.Type, inspect)
+			}
+			for _, value := range node.Values {
+				ast.Inspect(value, inspect)
+			}
+			return false
+		case *ast.SelectorExpr:
+			if ident, ok := node.X.(*ast.Ident); ok {
+				if impPath, alias := cm.fileImport(file, ident.Name); impPath != "" {
+					pkg.imports[impPath] = alias
+					if cm.isServerPkg(impPath) {
+						cm.copyType(impPath, node.Sel.Name)
+					}
+				}
+			}
+			return false
+		case *ast.Ident:
+			if _, found := pkg.types[node.Name]; found {
+				cm.copyType(pkgPath, node.Name)
+			} else if _, found = pkg.constDecl(node.Name); found {
+				cm.copyType(pkgPath, node.Name)
+			}
+		}
+		return true
+	}
+	ast.Inspect(node, inspect)
+}
+
+func (cm *clientModule) renderTypes(pkgPath string) (err error) {
+
+	pkg := cm.packages[pkgPath]
+	sources := make([]typeSource, 0, len(pkg.copied))
+	for _, source := range pkg.copied {
+		sources = append(sources, source)
+	}
+	sort.Slice(sources, func(i, j int) bool {
+		left, right := pkg.fset.Position(sources[i].node.Pos()), pkg.fset.Position(sources[j].node.Pos())
+		if left.Filename != right.Filename {
+			return left.Filename < right.Filename
+		}
+		return left.Offset < right.Offset
+	})
+	var typesFile bytesWriter
+	typesFile.add("// %s\n", doNotEdit)
+	typesFile.add("package %s\n\n", pkg.name)
+	if len(pkg.imports) != 0 {
+		typesFile.add("import (\n")
+		for _, impPath := range sortedKeys(pkg.imports) {
+			alias := pkg.imports[impPath]
+			if cm.isServerPkg(impPath) {
+				impPath = cm.localPath(impPath)
+			}
+			typesFile.add("\t%s %q\n", alias, impPath)
+		}
+		typesFile.add(")\n")
+	}
+	for _, source := range sources {
+		src := pkg.sources[source.file]
+		typesFile.Line()
+		if source.doc != nil {
+			typesFile.Write(src[pkg.fset.Position(source.doc.Pos()).Offset:pkg.fset.Position(source.doc.End()).Offset])
+			typesFile.Line()
+		}
+		if !source.decl {
+			typesFile.add("type ")
+		}
+		typesFile.Write(src[pkg.fset.Position(source.node.Pos()).Offset:pkg.fset.Position(source.node.End()).Offset])
+		typesFile.Line()
+	}
+	var formatted []byte
+	if formatted, err = format.Source(typesFile.Bytes()); err != nil {
+		return fmt.Errorf("format types of %s: %w", pkgPath, err)
+	}
+	outDir := filepath.Join(cm.outDir, filepath.FromSlash(strings.TrimPrefix(pkgPath, cm.srvModule)))
+	if err = os.MkdirAll(outDir, 0777); err != nil {
+		return
+	}
+	return os.WriteFile(path.Join(outDir, "types.go"), formatted, 0644)
+}
+
+func (cm *clientModule) rewriteImports(filePath string) (err error) {
+
+	var src []byte
+	if src, err = os.ReadFile(filePath); err != nil {
+		return
+	}
+	fset := token.NewFileSet()
+	var file *ast.File
+	if file, err = parser.ParseFile(fset, filePath, src, parser.ImportsOnly); err != nil {
+		return
+	}
+	// import paths are replaced from the end of file, so offsets of preceding imports stay valid
+	for i := len(file.Imports) - 1; i >= 0; i-- {
+		imp := file.Imports[i]
+		pkgPath, _ := strconv.Unquote(imp.Path.Value)
+		if _, found := cm.packages[pkgPath]; !found {
+			continue
+		}
+		start, end := fset.Position(imp.Path.Pos()).Offset, fset.Position(imp.Path.End()).Offset
+		src = append(src[:start:start], append([]byte(strconv.Quote(cm.localPath(pkgPath))), src[end:]...)...)
+	}
+	if src, err = format.Source(src); err != nil {
+		return
+	}
+	return os.WriteFile(filePath, src, 0644)
+}
+
+func (cm *clientModule) renderVersion() (err error) {
+
+	srcFile := newSrc(filepath.Base(cm.outDir))
+	srcFile.PackageComment(doNotEdit)
+
+	srcFile.Line().Comment("ClientVersion is the version of client module")
+	srcFile.Const().Id("ClientVersion").Op("=").Lit(cm.version)
+
+	return srcFile.Save(path.Join(cm.outDir, "version.go"))
+}
+
+func (cm *clientModule) renderGoMod() (err error) {
+
+	imports := make(map[string]string)
+	err = filepath.Walk(cm.outDir, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() || !strings.HasSuffix(filePath, ".go") {
+			return err
+		}
+		file, err := parser.ParseFile(token.NewFileSet(), filePath, nil, parser.ImportsOnly)
+		if err != nil {
+			return err
+		}
+		for _, imp := range file.Imports {
+			impPath, _ := strconv.Unquote(imp.Path.Value)
+			if strings.Contains(strings.Split(impPath, "/")[0], ".") && !strings.HasPrefix(impPath, cm.modulePath) {
+				imports[impPath] = impPath
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return
+	}
+	required := make(map[string]bool)
+	goMod := new(modfile.File)
+	if err = goMod.AddModuleStmt(cm.modulePath); err != nil {
+		return
+	}
+	if cm.srvMod.Go != nil {
+		if err = goMod.AddGoStmt(cm.srvMod.Go.Version); err != nil {
+			return
+		}
+	}
+	for _, impPath := range sortedKeys(imports) {
+		var require *modfile.Require
+		for _, req := range cm.srvMod.Require {
+			if (impPath == req.Mod.Path || strings.HasPrefix(impPath, req.Mod.Path+"/")) && (require == nil || len(req.Mod.Path) > len(require.Mod.Path)) {
+				require = req
+			}
+		}
+		if require == nil {
+			cm.log.WithField("package", impPath).Warn("module version not found in go.mod")
+			continue
+		}
+		if !required[require.Mod.Path] {
+			required[require.Mod.Path] = true
+			goMod.AddNewRequire(require.Mod.Path, require.Mod.Version, false)
+		}
+	}
+	for _, replace := range cm.srvMod.Replace {
+		if !required[replace.Old.Path] {
+			continue
+		}
+		// local directory of service repository is not available to users of published client module
+		if replace.New.Version == "" {
+			cm.log.WithField("module", replace.Old.Path).WithField("replace", replace.New.Path).Warn("local replace is not copied to client module")
+			continue
+		}
+		if err = goMod.AddReplace(replace.Old.Path, replace.Old.Version, replace.New.Path, replace.New.Version); err != nil {
+			return
+		}
+	}
+	goMod.Cleanup()
+	var modBytes []byte
+	if modBytes, err = goMod.Format(); err != nil {
+		return
+	}
+	return os.WriteFile(path.Join(cm.outDir, "go.mod"), modBytes, 0644)
+}
+
+func (cm *clientModule) renderReadme() []byte {
+
+	pkgName := filepath.Base(cm.outDir)
+
+	var readme bytesWriter
+	readme.add("# %s\n\n", cm.modulePath)
+	readme.add("<!-- %s -->\n\n", doNotEdit)
+	if title := cm.tags.Value("title"); title != "" {
+		readme.add("Go client for %s.", title)
+	} else {
+		readme.add("Go client.")
+	}
+	if description := cm.tags.Value("description"); description != "" {
+		readme.add(" %s", description)
+	}
+	readme.add("\n\nVersion: `%s`\n\n", cm.version)
+	readme.add("## Installation\n\n```shell\ngo get %s@%s\n```\n\n", cm.modulePath, cm.version)
+	readme.add("## Usage\n\n```go\nimport \"%s\"\n\n", cm.modulePath)
+	readme.add("cli := %s.New(\"my-service\", zerolog.New(os.Stderr), \"http://localhost:9000\")\n```\n", pkgName)
+	for _, name := range cm.serviceKeys() {
+		svc := cm.services[name]
+		methods := svc.clientMethods()
+		if len(methods) == 0 {
+			continue
+		}
+		readme.add("\n## %s\n\n", svc.Name)
+		for _, method := range methods {
+			readme.add("- `cli.%s().%s`", svc.Name, strings.TrimPrefix(method.Function.String(), "func "))
+			if summary := method.tags.Value(tagSummary); summary != "" {
+				readme.add(" — %s", summary)
+			}
+			if method.tags.IsSet(tagDeprecated) {
+				readme.add(" (deprecated)")
+			}
+			readme.Line()
+		}
+	}
+	if len(cm.packages) != 0 {
+		readme.add("\n## Types\n\nExchange types are copied from the service module, methods of the types are not copied:\n\n")
+		for _, pkgPath := range cm.packageKeys() {
+			readme.add("- `%s`\n", cm.localPath(pkgPath))
+		}
+	}
+	return readme.Bytes()
+}
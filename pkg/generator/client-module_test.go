@@ -0,0 +1,99 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus/hooks/test"
+)
+
+func TestClientModuleConstsAndReplaces(t *testing.T) {
+
+	modDir := newTestModule(t, map[string]string{
+		"service/service.go": `package service
+
+import (
+	"context"
+
+	"example.com/tgtest/types"
+)
+
+// @tg jsonRPC-server log
+type Users interface {
+	Get(ctx context.Context, id int) (user types.User, err error)
+}
+`,
+		"types/types.go": `package types
+
+import "example.com/tgtest/limits"
+
+type Status string
+
+const (
+	StatusActive  Status = "active"
+	StatusBlocked Status = "blocked"
+)
+
+type User struct {
+	Status Status
+	Token  [limits.TokenSize]byte
+	Codes  [CodesCount]int
+}
+
+const CodesCount = limits.TokenSize / 4
+`,
+		"limits/limits.go": `package limits
+
+const TokenSize = 16
+`,
+	})
+	goMod := "module " + testModule + "\n\ngo 1.22\n\nrequire (\n\tgithub.com/rs/zerolog v1.33.0\n\tgithub.com/valyala/fasthttp v1.51.0\n)\n\n" +
+		"replace github.com/rs/zerolog => ../zerolog\n\nreplace github.com/valyala/fasthttp => github.com/valyala/fasthttp v1.50.0\n"
+	if err := os.WriteFile(filepath.Join(modDir, "go.mod"), []byte(goMod), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	log, hook := test.NewNullLogger()
+	tr, err := NewTransport(log, filepath.Join(modDir, "service"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	clientDir := filepath.Join(modDir, "client")
+	if err = tr.RenderClient(clientDir); err != nil {
+		t.Fatal(err)
+	}
+	extra := "package client\n\nimport \"example.com/tgtest/types\"\n\nconst typesPath = \"example.com/tgtest/types\"\n\nvar _ types.Status\n"
+	if err = os.WriteFile(filepath.Join(clientDir, "extra.go"), []byte(extra), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err = tr.RenderClientModule(clientDir, "example.com/client", "1.0.0"); err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range hook.AllEntries() {
+		if entry.Message == "exchange type not found" {
+			t.Errorf("unexpected warning for %v", entry.Data["type"])
+		}
+	}
+	modFile := readTestFile(t, filepath.Join(clientDir, "go.mod"))
+	assertContains(t, modFile,
+		`github.com/rs/zerolog v1.33.0`,
+		`replace github.com/valyala/fasthttp => github.com/valyala/fasthttp v1.50.0`,
+	)
+	if strings.Contains(modFile, "../zerolog") {
+		t.Errorf("local replace copied to client module:\n%s", modFile)
+	}
+	assertContains(t, readTestFile(t, filepath.Join(clientDir, "types", "types.go")),
+		`StatusActive  Status = "active"`,
+		`const CodesCount = limits.TokenSize / 4`,
+		`"example.com/client/limits"`,
+	)
+	assertContains(t, readTestFile(t, filepath.Join(clientDir, "limits", "types.go")),
+		`const TokenSize = 16`,
+	)
+	assertContains(t, readTestFile(t, filepath.Join(clientDir, "extra.go")),
+		`import "example.com/client/types"`,
+		`const typesPath = "example.com/tgtest/types"`,
+	)
+}
@@ -29,5 +29,161 @@ func (tr Transport) renderClientOptions(outDir string) (err error) {
 			Id("cli").Dot("headers").Op("=").Id("headers"),
 		),
 	)
+	if tr.hasJsonRPC {
+		srcFile.Line().Comment("MaxBatchSize splits batches into several requests of at most size calls, 0 means unlimited")
+		srcFile.Func().Id("MaxBatchSize").Params(Id("size").Int()).Params(Id("Option")).Block(
+			Return(Func().Params(Id("cli").Op("*").Id("ClientJsonRPC"))).Block(
+				Id("cli").Dot("maxBatchSize").Op("=").Id("size"),
+			),
+		)
+	}
+	if tr.hasJsonRPC || tr.hasREST() {
+		srcFile.Line().Comment("Retry sets maximum number of attempts for idempotent methods, 1 disables retries")
+		srcFile.Func().Id("Retry").Params(Id("maxAttempts").Int()).Params(Id("Option")).Block(
+			Return(Func().Params(Id("cli").Op("*").Id("ClientJsonRPC"))).Block(
+				Id("cli").Dot("retry").Dot("maxAttempts").Op("=").Id("maxAttempts"),
+			),
+		)
+		srcFile.Line().Func().Id("RetryBackoff").Params(Id("minBackoff"), Id("maxBackoff").Qual(packageTime, "Duration")).Params(Id("Option")).Block(
+			Return(Func().Params(Id("cli").Op("*").Id("ClientJsonRPC"))).Block(
+				Id("cli").Dot("retry").Dot("minBackoff").Op("=").Id("minBackoff"),
+				Id("cli").Dot("retry").Dot("maxBackoff").Op("=").Id("maxBackoff"),
+			),
+		)
+		srcFile.Line().Func().Id("RetryIf").Params(Id("predicate").Id("RetryPredicate")).Params(Id("Option")).Block(
+			Return(Func().Params(Id("cli").Op("*").Id("ClientJsonRPC"))).Block(
+				Id("cli").Dot("retry").Dot("retryIf").Op("=").Id("predicate"),
+			),
+		)
+		srcFile.Line().Comment("Transport replaces HTTP transport of client, options of default transport are ignored then")
+		srcFile.Func().Id("Transport").Params(Id("transport").Id("HTTPTransport")).Params(Id("Option")).Block(
+			Return(Func().Params(Id("cli").Op("*").Id("ClientJsonRPC"))).Block(
+				Id("cli").Dot("transport").Op("=").Id("transport"),
+			),
+		)
+		srcFile.Line().Comment("Timeout limits duration of every call attempt, deadline of call context is honoured as well")
+		srcFile.Func().Id("Timeout").Params(Id("timeout").Qual(packageTime, "Duration")).Params(Id("Option")).Block(
+			Return(Func().Params(Id("cli").Op("*").Id("ClientJsonRPC"))).Block(
+				Id("cli").Dot("timeout").Op("=").Id("timeout"),
+			),
+		)
+		srcFile.Line().Comment("MethodTimeout overrides Timeout for methods, methods are named as in MethodBreaker")
+		srcFile.Func().Id("MethodTimeout").Params(Id("timeout").Qual(packageTime, "Duration"), Id("methods").Op("...").String()).Params(Id("Option")).Block(
+			Return(Func().Params(Id("cli").Op("*").Id("ClientJsonRPC"))).Block(
+				If(Id("cli").Dot("methodTimeouts").Op("==").Nil()).Block(
+					Id("cli").Dot("methodTimeouts").Op("=").Make(Map(String()).Qual(packageTime, "Duration")),
+				),
+				For(List(Id("_"), Id("method")).Op(":=").Range().Id("methods")).Block(
+					Id("cli").Dot("methodTimeouts").Index(Id("method")).Op("=").Id("timeout"),
+				),
+			),
+		)
+		srcFile.Line().Comment("TLSConfig sets TLS configuration of default transport")
+		srcFile.Func().Id("TLSConfig").Params(Id("config").Op("*").Qual(packageTLS, "Config")).Params(Id("Option")).Block(
+			Return(Func().Params(Id("cli").Op("*").Id("ClientJsonRPC"))).Block(
+				Id("cli").Dot("httpTransport").Dot("TLSClientConfig").Op("=").Id("config"),
+			),
+		)
+		srcFile.Line().Comment("Proxy sets proxy of default transport, e.g. http.ProxyURL(proxyURL), environment is used by default")
+		srcFile.Func().Id("Proxy").Params(Id("proxy").Func().Params(Op("*").Qual(packageHttp, "Request")).Params(Op("*").Qual(packageURL, "URL"), Error())).Params(Id("Option")).Block(
+			Return(Func().Params(Id("cli").Op("*").Id("ClientJsonRPC"))).Block(
+				Id("cli").Dot("httpTransport").Dot("Proxy").Op("=").Id("proxy"),
+			),
+		)
+		srcFile.Line().Comment("ConnPool configures connection pool of default transport")
+		srcFile.Func().Id("ConnPool").Params(List(Id("maxConnsPerHost"), Id("maxIdleConnsPerHost")).Int(), Id("idleConnTimeout").Qual(packageTime, "Duration")).Params(Id("Option")).Block(
+			Return(Func().Params(Id("cli").Op("*").Id("ClientJsonRPC"))).Block(
+				Id("cli").Dot("httpTransport").Dot("MaxConnsPerHost").Op("=").Id("maxConnsPerHost"),
+				Id("cli").Dot("httpTransport").Dot("MaxIdleConnsPerHost").Op("=").Id("maxIdleConnsPerHost"),
+				Id("cli").Dot("httpTransport").Dot("IdleConnTimeout").Op("=").Id("idleConnTimeout"),
+			),
+		)
+		srcFile.Line().Comment("Breaker enables circuit breaker for all calls of client")
+		srcFile.Func().Id("Breaker").Params(Id("config").Id("BreakerConfig")).Params(Id("Option")).Block(
+			Return(Func().Params(Id("cli").Op("*").Id("ClientJsonRPC"))).Block(
+				Id("cli").Dot("breaker").Op("=").Id("newCircuitBreaker").Call(Id("cli").Dot("name"), Lit(""), Id("cli").Dot("log"), Id("config")),
+			),
+		)
+		srcFile.Line().Comment("MethodBreaker enables separate circuit breaker for every method, methods are named as in jsonRPC (service.method)")
+		srcFile.Func().Id("MethodBreaker").Params(Id("config").Id("BreakerConfig"), Id("methods").Op("...").String()).Params(Id("Option")).Block(
+			Return(Func().Params(Id("cli").Op("*").Id("ClientJsonRPC"))).Block(
+				If(Id("cli").Dot("methodBreakers").Op("==").Nil()).Block(
+					Id("cli").Dot("methodBreakers").Op("=").Make(Map(String()).Op("*").Id("circuitBreaker")),
+				),
+				For(List(Id("_"), Id("method")).Op(":=").Range().Id("methods")).Block(
+					Id("cli").Dot("methodBreakers").Index(Id("method")).Op("=").Id("newCircuitBreaker").Call(Id("cli").Dot("name"), Id("method"), Id("cli").Dot("log"), Id("config")),
+				),
+			),
+		)
+		srcFile.Line().Comment("Endpoints spreads calls over endpoints returned by resolver, URL passed to New defines only path of jsonRPC calls")
+		srcFile.Func().Id("Endpoints").Params(Id("resolver").Id("Resolver")).Params(Id("Option")).Block(
+			Return(Func().Params(Id("cli").Op("*").Id("ClientJsonRPC"))).Block(
+				Id("cli").Dot("balancer").Dot("resolver").Op("=").Id("resolver"),
+			),
+		)
+		srcFile.Line().Func().Id("Balance").Params(Id("strategy").Id("BalanceStrategy")).Params(Id("Option")).Block(
+			Return(Func().Params(Id("cli").Op("*").Id("ClientJsonRPC"))).Block(
+				Id("cli").Dot("balancer").Dot("strategy").Op("=").Id("strategy"),
+			),
+		)
+		srcFile.Line().Comment("ResolveInterval sets how often endpoints are resolved again")
+		srcFile.Func().Id("ResolveInterval").Params(Id("interval").Qual(packageTime, "Duration")).Params(Id("Option")).Block(
+			Return(Func().Params(Id("cli").Op("*").Id("ClientJsonRPC"))).Block(
+				Id("cli").Dot("balancer").Dot("interval").Op("=").Id("interval"),
+			),
+		)
+		srcFile.Line().Comment("EjectTimeout sets how long endpoint is skipped after transport error")
+		srcFile.Func().Id("EjectTimeout").Params(Id("timeout").Qual(packageTime, "Duration")).Params(Id("Option")).Block(
+			Return(Func().Params(Id("cli").Op("*").Id("ClientJsonRPC"))).Block(
+				Id("cli").Dot("balancer").Dot("ejectTimeout").Op("=").Id("timeout"),
+			),
+		)
+		srcFile.Line().Comment("Logging logs every call of service methods with client logger, arguments are logged according to log-skip annotations")
+		srcFile.Func().Id("Logging").Params().Params(Id("Option")).Block(
+			Return(Func().Params(Id("cli").Op("*").Id("ClientJsonRPC"))).Block(
+				Id("cli").Dot("logging").Op("=").True(),
+			),
+		)
+		srcFile.Line().Comment("Metrics counts calls and errors and measures latency of service methods")
+		srcFile.Func().Id("Metrics").Params().Params(Id("Option")).Block(
+			Return(Func().Params(Id("cli").Op("*").Id("ClientJsonRPC"))).Block(
+				Id("cli").Dot("metrics").Op("=").True(),
+			),
+		)
+		srcFile.Line().Comment("Tracing starts span for every call of service methods and sends it to server in HTTP headers")
+		srcFile.Func().Id("Tracing").Params().Params(Id("Option")).Block(
+			Return(Func().Params(Id("cli").Op("*").Id("ClientJsonRPC"))).Block(
+				Id("cli").Dot("tracing").Op("=").True(),
+			),
+		)
+		srcFile.Line().Comment("Auth sets token source, token is requested before every call attempt")
+		srcFile.Func().Id("Auth").Params(Id("source").Id("TokenSource")).Params(Id("Option")).Block(
+			Return(Func().Params(Id("cli").Op("*").Id("ClientJsonRPC"))).Block(
+				Id("cli").Dot("tokenSource").Op("=").Id("source"),
+			),
+		)
+		srcFile.Line().Comment("Sign adds hook, which signs every request right before it is sent, hooks are called in order of options")
+		srcFile.Func().Id("Sign").Params(Id("signer").Id("RequestSigner")).Params(Id("Option")).Block(
+			Return(Func().Params(Id("cli").Op("*").Id("ClientJsonRPC"))).Block(
+				Id("cli").Dot("signers").Op("=").Append(Id("cli").Dot("signers"), Id("signer")),
+			),
+		)
+		srcFile.Line().Comment("Bulkhead limits number of concurrent calls, call waits for free slot up to maxWait")
+		srcFile.Func().Id("Bulkhead").Params(Id("maxConcurrent").Int(), Id("maxWait").Qual(packageTime, "Duration")).Params(Id("Option")).Block(
+			Return(Func().Params(Id("cli").Op("*").Id("ClientJsonRPC"))).Block(
+				Id("cli").Dot("bulkhead").Op("=").Op("&").Id("bulkhead").Values(Dict{
+					Id("slots"):   Make(Chan().Struct(), Id("maxConcurrent")),
+					Id("maxWait"): Id("maxWait"),
+				}),
+			),
+		)
+	}
+	if tr.hasREST() {
+		srcFile.Line().Func().Id("DecodeHTTPError").Params(Id("decoder").Id("HTTPErrorDecoder")).Params(Id("Option")).Block(
+			Return(Func().Params(Id("cli").Op("*").Id("ClientJsonRPC"))).Block(
+				Id("cli").Dot("httpErrorDecoder").Op("=").Id("decoder"),
+			),
+		)
+	}
 	return srcFile.Save(path.Join(outDir, "options.go"))
 }
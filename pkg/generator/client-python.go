@@ -0,0 +1,756 @@
+package generator
+
+import (
+	"fmt"
+	"go/constant"
+	"io/ioutil"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/vetcher/go-astra/types"
+
+	"github.com/tundrik/tg/v2/pkg/tags"
+	"github.com/tundrik/tg/v2/pkg/utils"
+)
+
+var pyIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+var pyKeywords = map[string]bool{
+	"False": true, "None": true, "True": true, "and": true, "as": true, "assert": true, "async": true, "await": true,
+	"break": true, "class": true, "continue": true, "def": true, "del": true, "elif": true, "else": true, "except": true,
+	"finally": true, "for": true, "from": true, "global": true, "if": true, "import": true, "in": true, "is": true,
+	"lambda": true, "nonlocal": true, "not": true, "or": true, "pass": true, "raise": true, "return": true, "try": true,
+	"while": true, "with": true, "yield": true,
+}
+
+type clientPython struct {
+	*Transport
+	names   map[string]string
+	owners  map[string]string
+	structs map[string]bool
+	decls   []string
+}
+
+type pyField struct {
+	name     string
+	typeName string
+	optional bool
+}
+
+func (tr Transport) RenderClientPython(outDir string) (err error) {
+	return newClientPython(&tr).render(outDir)
+}
+
+func newClientPython(tr *Transport) (py *clientPython) {
+	py = &clientPython{
+		Transport: tr,
+		names:     make(map[string]string),
+		owners:    make(map[string]string),
+		structs:   make(map[string]bool),
+	}
+	return
+}
+
+func (py *clientPython) render(outDir string) (err error) {
+
+	pkgName := pyPackageName(outDir)
+	pkgDir := path.Join(outDir, pkgName)
+	if err = os.MkdirAll(pkgDir, 0777); err != nil {
+		return
+	}
+	var exchange bytesWriter
+	for _, name := range py.serviceKeys() {
+		for _, method := range py.services[name].clientMethods() {
+			exchange.WriteString(py.typedDict(py.requestName(method), nil, py.requestFields(method)))
+			exchange.WriteString(py.typedDict(py.responseName(method), nil, py.responseFields(method)))
+		}
+	}
+	var modelsFile bytesWriter
+	modelsFile.add("# %s\n", doNotEdit)
+	modelsFile.add("from __future__ import annotations\n\n")
+	modelsFile.add("from enum import Enum\n")
+	modelsFile.add("from typing import Any, Dict, List, Optional, TypedDict\n")
+	for _, decl := range py.decls {
+		modelsFile.WriteString(decl)
+	}
+	modelsFile.WriteString(exchange.String())
+	if err = ioutil.WriteFile(path.Join(pkgDir, "models.py"), modelsFile.Bytes(), 0600); err != nil {
+		return
+	}
+	if err = ioutil.WriteFile(path.Join(pkgDir, "errors.py"), py.renderErrors(), 0600); err != nil {
+		return
+	}
+	if err = ioutil.WriteFile(path.Join(pkgDir, "client.py"), py.renderClient(), 0600); err != nil {
+		return
+	}
+	var initFile bytesWriter
+	initFile.add("# %s\n", doNotEdit)
+	initFile.add("from .client import *  # noqa: F401,F403\n")
+	initFile.add("from .errors import *  # noqa: F401,F403\n")
+	initFile.add("from .models import *  # noqa: F401,F403\n")
+	if err = ioutil.WriteFile(path.Join(pkgDir, "__init__.py"), initFile.Bytes(), 0600); err != nil {
+		return
+	}
+	if err = ioutil.WriteFile(path.Join(pkgDir, "py.typed"), nil, 0600); err != nil {
+		return
+	}
+	return ioutil.WriteFile(path.Join(outDir, "pyproject.toml"), py.renderProject(pkgName), 0600)
+}
+
+func (py *clientPython) renderClient() []byte {
+
+	var pyFile bytesWriter
+	pyFile.add("# %s\n", doNotEdit)
+	pyFile.WriteString(pyClientImports)
+	if names := py.typeNames(); len(names) != 0 {
+		pyFile.add("from .models import (\n")
+		for _, name := range names {
+			pyFile.add("    %s,\n", name)
+		}
+		pyFile.add(")\n")
+	}
+	pyFile.add("\nDEFAULT_MAX_BATCH_SIZE = %d\n", py.tags.ValueInt(tagBatchSize, 0))
+	pyFile.WriteString(pyClientBase)
+
+	var services, batchServices []*service
+	for _, name := range py.serviceKeys() {
+		svc := py.services[name]
+		if len(svc.clientMethods()) != 0 {
+			services = append(services, svc)
+		}
+		if len(py.batchMethods(svc)) != 0 {
+			batchServices = append(batchServices, svc)
+		}
+	}
+	for _, svc := range services {
+		for _, method := range svc.restClientMethods() {
+			pyFile.WriteString(py.restRequestFunc(method))
+			pyFile.WriteString(py.restResponseFunc(method))
+		}
+	}
+	for _, svc := range services {
+		pyFile.WriteString(py.serviceClass(svc, false))
+		pyFile.WriteString(py.serviceClass(svc, true))
+	}
+	for _, svc := range batchServices {
+		pyFile.add("\n\nclass %sBatch:\n", svc.Name)
+		pyFile.add("    def __init__(self, batch: BaseBatch) -> None:\n")
+		pyFile.add("        self._batch = batch\n")
+		for _, method := range py.batchMethods(svc) {
+			pyFile.add("\n    def %s(%s) -> Call[%s]:\n", pyMethodName(method), py.methodArgs(method), py.responseName(method))
+			pyFile.WriteString(pyDocString(method))
+			pyFile.add("        params: %s = %s\n", py.requestName(method), py.params(method))
+			pyFile.add("        return self._batch.add(%q, params)\n", method.jsonrpcName())
+		}
+	}
+	pyFile.add("\n\nclass Client(BaseClient):\n")
+	pyFile.add("    def __init__(self, url: str, transport: Optional[Transport] = None, max_batch_size: int = DEFAULT_MAX_BATCH_SIZE) -> None:\n")
+	pyFile.add("        super().__init__(url, transport, max_batch_size)\n")
+	for _, svc := range services {
+		pyFile.add("        self.%s = %sClient(self)\n", utils.ToSnake(svc.Name), svc.Name)
+	}
+	if len(batchServices) != 0 {
+		pyFile.add("\n    def batch(self) -> Batch:\n")
+		pyFile.add("        return Batch(self)\n")
+		pyFile.add("\n\nclass Batch(BaseBatch):\n")
+		pyFile.add("    def __init__(self, client: BaseClient) -> None:\n")
+		pyFile.add("        super().__init__(client)\n")
+		for _, svc := range batchServices {
+			pyFile.add("        self.%s = %sBatch(self)\n", utils.ToSnake(svc.Name), svc.Name)
+		}
+	}
+	pyFile.add("\n\nclass AsyncClient(BaseAsyncClient):\n")
+	pyFile.add("    def __init__(self, url: str, transport: Optional[AsyncTransport] = None, max_batch_size: int = DEFAULT_MAX_BATCH_SIZE) -> None:\n")
+	pyFile.add("        super().__init__(url, transport, max_batch_size)\n")
+	for _, svc := range services {
+		pyFile.add("        self.%s = Async%sClient(self)\n", utils.ToSnake(svc.Name), svc.Name)
+	}
+	return pyFile.Bytes()
+}
+
+func (py *clientPython) serviceClass(svc *service, async bool) string {
+
+	var code bytesWriter
+	prefix, clientType, def, await := "", "BaseClient", "def", ""
+	if async {
+		prefix, clientType, def, await = "Async", "BaseAsyncClient", "async def", "await "
+	}
+	code.add("\n\nclass %s%sClient:\n", prefix, svc.Name)
+	code.add("    def __init__(self, client: %s) -> None:\n", clientType)
+	code.add("        self._client = client\n")
+	for _, method := range svc.clientMethods() {
+		code.add("\n    %s %s(%s) -> %s:\n", def, pyMethodName(method), py.methodArgs(method), py.responseName(method))
+		code.WriteString(pyDocString(method))
+		if !method.isJsonRPC() {
+			code.add("        request = _%s_%s_request(self._client, %s)\n", utils.ToSnake(svc.Name), utils.ToSnake(method.Name), strings.Join(py.argNames(method), ", "))
+			code.add("        return _%s_%s_response(%sself._client.send(request))\n", utils.ToSnake(svc.Name), utils.ToSnake(method.Name), await)
+			continue
+		}
+		code.add("        params: %s = %s\n", py.requestName(method), py.params(method))
+		if async && !svc.isBatchMethod(method) {
+			code.add("        return await self._client.call(%q, params, False)\n", method.jsonrpcName())
+			continue
+		}
+		code.add("        return %sself._client.call(%q, params)\n", await, method.jsonrpcName())
+	}
+	return code.String()
+}
+
+func (py *clientPython) restRequestFunc(method *method) string {
+
+	var code bytesWriter
+	args := "client: _Base" + strings.TrimPrefix(py.methodArgs(method), "self")
+	code.add("\n\ndef _%s_%s_request(%s) -> HTTPRequest:\n", utils.ToSnake(method.svc.Name), utils.ToSnake(method.Name), args)
+	code.add("    query: List[Tuple[str, str]] = []\n")
+	for _, argName := range sortedKeys(method.argParamMap()) {
+		if arg := method.argByName(argName); arg != nil {
+			code.WriteString(pyArgValue(arg, fmt.Sprintf("query.append((%q, %%s))", method.argParamMap()[argName])))
+		}
+	}
+	code.add("    request = HTTPRequest(%q, client.rest_url(%s, query))\n", strings.ToUpper(method.httpMethod()), py.restPath(method))
+	for _, argName := range sortedKeys(method.varHeaderMap()) {
+		if arg := method.argByName(argName); arg != nil {
+			code.WriteString(pyArgValue(arg, fmt.Sprintf("request.headers[%q] = %%s", method.varHeaderMap()[argName])))
+		}
+	}
+	if cookies := method.argCookieMap(); len(cookies) != 0 {
+		code.add("    cookies: List[str] = []\n")
+		for _, argName := range sortedKeys(cookies) {
+			if arg := method.argByName(argName); arg != nil {
+				code.WriteString(pyArgValue(arg, fmt.Sprintf("cookies.append(%q + urllib.parse.quote(%%s))", cookies[argName]+"=")))
+			}
+		}
+		code.add("    if cookies:\n")
+		code.add("        request.headers[\"Cookie\"] = \"; \".join(cookies)\n")
+	}
+	if uploads := method.uploadVarsMap(); len(uploads) != 0 {
+		var files []string
+		for _, argName := range sortedKeys(uploads) {
+			if arg := method.argByName(argName); arg != nil {
+				files = append(files, fmt.Sprintf("%q: %s", uploads[argName], pyArgName(arg.Name)))
+			}
+		}
+		code.add("    request.headers[\"Content-Type\"], request.body = _multipart({%s})\n", strings.Join(files, ", "))
+	} else if len(method.arguments()) != 0 {
+		code.add("    body: %s = %s\n", py.requestName(method), py.params(method))
+		code.add("    request.headers[\"Content-Type\"] = \"application/json\"\n")
+		code.add("    request.body = _dumps(body)\n")
+	}
+	code.add("    return request\n")
+	return code.String()
+}
+
+func (py *clientPython) restResponseFunc(method *method) string {
+
+	var code bytesWriter
+	code.add("\n\ndef _%s_%s_response(response: HTTPResponse) -> %s:\n", utils.ToSnake(method.svc.Name), utils.ToSnake(method.Name), py.responseName(method))
+	for _, retName := range sortedKeys(method.downloadVarsMap()) {
+		if ret := method.resultByName(retName); ret != nil {
+			code.add("    return cast(%s, {%q: response.body})\n", py.responseName(method), ret.Name)
+			return code.String()
+		}
+	}
+	code.add("    results = cast(%s, json.loads(response.body) if response.body else {})\n", py.responseName(method))
+	for _, retName := range sortedKeys(method.varHeaderMap()) {
+		if ret := method.resultByName(retName); ret != nil {
+			code.add("    results[%q] = response.header(%q)\n", ret.Name, method.varHeaderMap()[retName])
+		}
+	}
+	code.add("    return results\n")
+	return code.String()
+}
+
+// restPath builds expression of URL path with path arguments substituted
+func (py *clientPython) restPath(method *method) string {
+
+	var parts []string
+	var literal string
+	for _, token := range strings.Split(method.httpPath(), "/") {
+		if token == "" {
+			continue
+		}
+		literal += "/"
+		if !strings.HasPrefix(token, ":") {
+			literal += token
+			continue
+		}
+		arg := method.argByName(strings.TrimPrefix(token, ":"))
+		if arg == nil {
+			literal += token
+			continue
+		}
+		parts = append(parts, strconv.Quote(literal), fmt.Sprintf("urllib.parse.quote(_param(%s), safe=\"\")", pyArgName(arg.Name)))
+		literal = ""
+	}
+	if literal != "" || len(parts) == 0 {
+		parts = append(parts, strconv.Quote(literal))
+	}
+	return strings.Join(parts, " + ")
+}
+
+// pyArgValue renders statement with argument converted to string, optional arguments are skipped if None
+func pyArgValue(arg *types.Variable, statement string) string {
+
+	argName := pyArgName(arg.Name)
+	if _, isPointer := arg.Type.(types.TPointer); isPointer {
+		return fmt.Sprintf("    if %s is not None:\n        %s\n", argName, fmt.Sprintf(statement, "_param("+argName+")"))
+	}
+	return fmt.Sprintf("    %s\n", fmt.Sprintf(statement, "_param("+argName+")"))
+}
+
+// methodArgs returns parameters of method starting with self
+func (py *clientPython) methodArgs(method *method) string {
+
+	args := []string{"self"}
+	for _, arg := range method.argsWithoutContext() {
+		if vType, isEllipsis := arg.Type.(types.TEllipsis); isEllipsis {
+			args = append(args, fmt.Sprintf("*%s: %s", pyArgName(arg.Name), py.walkVariable(method.svc.pkgPath, vType.Next)))
+			continue
+		}
+		typeName := py.walkVariable(method.svc.pkgPath, arg.Type)
+		if _, isUpload := method.uploadVarsMap()[arg.Name]; isUpload && !method.isJsonRPC() {
+			typeName = "bytes"
+		}
+		args = append(args, fmt.Sprintf("%s: %s", pyArgName(arg.Name), typeName))
+	}
+	return strings.Join(args, ", ")
+}
+
+func (py *clientPython) argNames(method *method) (names []string) {
+
+	for _, arg := range method.argsWithoutContext() {
+		if types.IsEllipsis(arg.Type) {
+			names = append(names, "*"+pyArgName(arg.Name))
+			continue
+		}
+		names = append(names, pyArgName(arg.Name))
+	}
+	return
+}
+
+// params renders dictionary of jsonRPC params or REST request body
+func (py *clientPython) params(method *method) string {
+
+	var params []string
+	for _, arg := range method.clientRequestFields() {
+		value := pyArgName(arg.Name)
+		if types.IsEllipsis(arg.Type) {
+			value = "list(" + value + ")"
+		}
+		params = append(params, fmt.Sprintf("%q: %s", fieldJsonName(arg), value))
+	}
+	return "{" + strings.Join(params, ", ") + "}"
+}
+
+func (py *clientPython) batchMethods(svc *service) (methods []*method) {
+	for _, method := range svc.clientMethods() {
+		if method.isJsonRPC() && svc.isBatchMethod(method) {
+			methods = append(methods, method)
+		}
+	}
+	return
+}
+
+func (py *clientPython) requestName(method *method) string {
+	return "Request" + method.svc.Name + method.Name
+}
+
+func (py *clientPython) responseName(method *method) string {
+	return "Response" + method.svc.Name + method.Name
+}
+
+func (py *clientPython) requestFields(method *method) (fields []pyField) {
+
+	for _, arg := range method.clientRequestFields() {
+		fields = append(fields, py.field(method.svc.pkgPath, fieldJsonName(arg), arg, method.tags.Sub(arg.Name)))
+	}
+	return
+}
+
+// responseFields returns fields of jsonRPC result or REST response, REST response also contains header and download results
+func (py *clientPython) responseFields(method *method) (fields []pyField) {
+
+	for _, ret := range method.clientResponseFields() {
+		fields = append(fields, py.field(method.svc.pkgPath, fieldJsonName(ret), ret, method.tags.Sub(ret.Name)))
+	}
+	if method.isJsonRPC() {
+		return
+	}
+	for _, retName := range sortedKeys(method.varHeaderMap()) {
+		if ret := method.resultByName(retName); ret != nil {
+			fields = append(fields, pyField{name: ret.Name, typeName: "str"})
+		}
+	}
+	for _, retName := range sortedKeys(method.downloadVarsMap()) {
+		if ret := method.resultByName(retName); ret != nil {
+			fields = append(fields, pyField{name: ret.Name, typeName: "bytes"})
+		}
+	}
+	return
+}
+
+func (py *clientPython) field(pkgPath, name string, field types.StructField, fieldTags tags.DocTags) pyField {
+	return pyField{name: name, typeName: py.walkVariable(pkgPath, field.Type), optional: !isRequired(field.Type, field.Tags["json"], fieldTags)}
+}
+
+func (py *clientPython) walkVariable(pkgPath string, varType types.Type) string {
+
+	if typeName, found := castTypePython(varType.String()); found {
+		return typeName
+	}
+	switch vType := varType.(type) {
+	case types.TName:
+		if types.IsBuiltin(varType) || vType.TypeName == "any" {
+			return castBuiltinPython(vType.TypeName)
+		}
+		return py.declare(pkgPath, vType.TypeName)
+	case types.TImport:
+		return py.declare(vType.Import.Package, vType.Next.String())
+	case types.TMap:
+		return fmt.Sprintf("Optional[Dict[str, %s]]", py.walkVariable(pkgPath, vType.Value))
+	case types.TArray:
+		if !vType.IsSlice {
+			return fmt.Sprintf("List[%s]", py.walkVariable(pkgPath, vType.Next))
+		}
+		return fmt.Sprintf("Optional[List[%s]]", py.walkVariable(pkgPath, vType.Next))
+	case types.TEllipsis:
+		return fmt.Sprintf("Optional[List[%s]]", py.walkVariable(pkgPath, vType.Next))
+	case types.TPointer:
+		typeName := py.walkVariable(pkgPath, vType.Next)
+		if !strings.HasPrefix(typeName, "Optional[") {
+			typeName = "Optional[" + typeName + "]"
+		}
+		return typeName
+	case types.Struct:
+		return "Dict[str, Any]"
+	}
+	return "Any"
+}
+
+// declare renders declaration of named type once and returns its name
+func (py *clientPython) declare(pkgPath, name string) string {
+
+	key := pkgPath + "." + name
+	if pyName, found := py.names[key]; found {
+		return pyName
+	}
+	nextType := searchType(pkgPath, name)
+	if nextType == nil {
+		py.log.WithField("type", key).Warn("type not found")
+		py.names[key] = "Any"
+		return "Any"
+	}
+	pyName := name
+	if owner, found := py.owners[pyName]; found && owner != key {
+		pyName = utils.ToCamel(path.Base(pkgPath)) + name
+	}
+	py.names[key] = pyName
+	py.owners[pyName] = key
+
+	switch vType := nextType.(type) {
+	case types.Struct:
+		py.structs[pyName] = true
+		bases, fields := py.structFields(pkgPath, vType)
+		py.decls = append(py.decls, py.typedDict(pyName, bases, fields))
+	case types.TInterface:
+		py.decls = append(py.decls, fmt.Sprintf("\n%s = Any\n", pyName))
+	default:
+		if values := searchEnum(pkgPath, name); len(values) != 0 && pyEnumBase(values) != "" {
+			py.decls = append(py.decls, pyEnum(pyName, values))
+			break
+		}
+		py.decls = append(py.decls, fmt.Sprintf("\n%s = %s\n", pyName, py.walkVariable(pkgPath, nextType)))
+	}
+	return pyName
+}
+
+// structFields returns fields as encoding/json marshals them, embedded structs are returned as base classes
+func (py *clientPython) structFields(pkgPath string, vType types.Struct) (bases []string, fields []pyField) {
+
+	for _, field := range vType.Fields {
+
+		jsonTags := field.Tags["json"]
+		name, embedded := field.Name, field.Name == ""
+		if len(jsonTags) > 0 && jsonTags[0] == "-" && len(jsonTags) == 1 {
+			continue
+		}
+		if len(jsonTags) > 0 && jsonTags[0] != "" {
+			name, embedded = jsonTags[0], false
+		}
+		if len(jsonTags) > 1 && jsonTags[1] == "inline" {
+			embedded = true
+		}
+		if embedded {
+			embedType := field.Type
+			if pointer, isPointer := embedType.(types.TPointer); isPointer {
+				embedType = pointer.Next
+			}
+			if typeName := py.walkVariable(pkgPath, embedType); py.structs[typeName] {
+				bases = append(bases, typeName)
+				continue
+			}
+			if name == "" {
+				typeName := types.TypeName(embedType)
+				if typeName == nil {
+					continue
+				}
+				name = *typeName
+			}
+		} else if field.Name[:1] != strings.ToUpper(field.Name[:1]) {
+			continue
+		}
+		fields = append(fields, pyField{name: name, typeName: py.walkVariable(pkgPath, field.Type), optional: !isRequired(field.Type, jsonTags, tags.ParseTags(field.Docs))})
+	}
+	return
+}
+
+// typedDict renders TypedDict, optional fields are declared in class with total=False,
+// keys which are not identifiers are declared by functional syntax
+func (py *clientPython) typedDict(name string, bases []string, fields []pyField) string {
+
+	var required, optional []pyField
+	identifiers := true
+	for _, field := range fields {
+		if !pyIdentifier.MatchString(field.name) || pyKeywords[field.name] {
+			identifiers = false
+		}
+		if field.optional {
+			optional = append(optional, field)
+			continue
+		}
+		required = append(required, field)
+	}
+	var code bytesWriter
+	if !identifiers {
+		code.add("\n")
+		if len(required) != 0 {
+			code.add("\n_%sRequired = TypedDict(\"_%[1]sRequired\", %s)\n", name, pyFunctionalFields(required))
+			bases = append(bases, "_"+name+"Required")
+		}
+		if len(optional) != 0 {
+			code.add("\n_%sOptional = TypedDict(\"_%[1]sOptional\", %s, total=False)\n", name, pyFunctionalFields(optional))
+			bases = append(bases, "_"+name+"Optional")
+		}
+		code.WriteString(pyClass(name, bases, "", nil))
+		return code.String()
+	}
+	if len(required) != 0 && len(optional) != 0 {
+		code.WriteString(pyClass("_"+name, bases, "", required))
+		code.WriteString(pyClass(name, []string{"_" + name}, ", total=False", optional))
+		return code.String()
+	}
+	if len(optional) != 0 {
+		code.WriteString(pyClass(name, bases, ", total=False", optional))
+		return code.String()
+	}
+	code.WriteString(pyClass(name, bases, "", required))
+	return code.String()
+}
+
+func (py *clientPython) typeNames() (names []string) {
+
+	for name := range py.owners {
+		names = append(names, name)
+	}
+	for _, name := range py.serviceKeys() {
+		for _, method := range py.services[name].clientMethods() {
+			names = append(names, py.requestName(method), py.responseName(method))
+		}
+	}
+	sort.Strings(names)
+	return
+}
+
+func (py *clientPython) renderErrors() []byte {
+
+	var pyFile bytesWriter
+	pyFile.add("# %s\n", doNotEdit)
+	pyFile.add("from __future__ import annotations\n\n")
+	pyFile.add("import json\n")
+	pyFile.add("from typing import Any, Dict, Type\n")
+	pyFile.WriteString(pyErrorsBase)
+
+	errs := py.clientErrors()
+	for _, err := range errs {
+		pyFile.add("\n\nclass %s(JSONRPCError):\n", err.className)
+		pyFile.add("    def __init__(self, message: str, data: Any = None) -> None:\n")
+		pyFile.add("        super().__init__(message, %d, data)\n", err.code)
+	}
+	pyFile.add("\n\nERROR_CLASSES: Dict[int, Type[Any]] = {\n")
+	for _, err := range errs {
+		pyFile.add("    %d: %s,\n", err.code, err.className)
+	}
+	pyFile.add("}\n\n\n")
+	pyFile.add("def convert_error(error: Dict[str, Any]) -> JSONRPCError:\n")
+	pyFile.add("    \"\"\"Converts error object of JSON-RPC response to instance of error class by its code.\"\"\"\n")
+	pyFile.add("    code = error.get(\"code\", 0)\n")
+	pyFile.add("    error_class = ERROR_CLASSES.get(code)\n")
+	pyFile.add("    if error_class is None:\n")
+	pyFile.add("        return JSONRPCError(error.get(\"message\", \"\"), code, error.get(\"data\"))\n")
+	pyFile.add("    return error_class(error.get(\"message\", \"\"), error.get(\"data\"))\n")
+	return pyFile.Bytes()
+}
+
+func (py *clientPython) renderProject(pkgName string) []byte {
+	return []byte(fmt.Sprintf(pyProject, strings.ReplaceAll(pkgName, "_", "-"), py.tags.Value("version", "0.0.1"), strconv.Quote(py.tags.Value("description", "")), pkgName, pkgName))
+}
+
+func pyPackageName(outDir string) string {
+
+	name := strings.Trim(regexp.MustCompile(`[^a-z0-9]+`).ReplaceAllString(utils.ToSnake(path.Base(outDir)), "_"), "_")
+	if name == "" || name[0] >= '0' && name[0] <= '9' {
+		name = "jsonrpc"
+	}
+	return name + "_client"
+}
+
+func pyClass(name string, bases []string, options string, fields []pyField) string {
+
+	if len(bases) == 0 {
+		bases = []string{"TypedDict"}
+	}
+	code := fmt.Sprintf("\n\nclass %s(%s%s):\n", name, strings.Join(bases, ", "), options)
+	if len(fields) == 0 {
+		return code + "    pass\n"
+	}
+	for _, field := range fields {
+		code += fmt.Sprintf("    %s: %s\n", field.name, field.typeName)
+	}
+	return code
+}
+
+func pyFunctionalFields(fields []pyField) string {
+
+	var items []string
+	for _, field := range fields {
+		items = append(items, fmt.Sprintf("%q: %q", field.name, field.typeName))
+	}
+	return "{" + strings.Join(items, ", ") + "}"
+}
+
+func pyEnum(name string, values []enumValue) string {
+
+	code := fmt.Sprintf("\n\nclass %s(%s, Enum):\n", name, pyEnumBase(values))
+	for _, value := range values {
+		member := strings.ToUpper(utils.ToSnake(strings.TrimPrefix(value.Name, name)))
+		if member == "" || !pyIdentifier.MatchString(member) || member[0] >= '0' && member[0] <= '9' {
+			member = strings.ToUpper(utils.ToSnake(value.Name))
+		}
+		code += fmt.Sprintf("    %s = %s\n", member, value.literal())
+		if value.Desc != "" {
+			code += fmt.Sprintf("    \"\"\"%s\"\"\"\n", strings.ReplaceAll(value.Desc, "\"", "'"))
+		}
+	}
+	return code
+}
+
+func pyEnumBase(values []enumValue) string {
+
+	switch values[0].Value.Kind() {
+	case constant.String:
+		return "str"
+	case constant.Int:
+		return "int"
+	case constant.Float:
+		return "float"
+	}
+	return ""
+}
+
+func pyDocString(method *method) string {
+	if summary := method.tags.Value(tagSummary); summary != "" {
+		return fmt.Sprintf("        \"\"\"%s\"\"\"\n", strings.ReplaceAll(summary, "\"", "'"))
+	}
+	return ""
+}
+
+func pyMethodName(method *method) string {
+	return pyArgName(method.Name)
+}
+
+func pyArgName(name string) string {
+	if name = utils.ToSnake(name); pyKeywords[name] {
+		name += "_"
+	}
+	return name
+}
+
+func castTypePython(originName string) (typeName string, found bool) {
+
+	switch originName {
+	case "time.Time":
+		return "str", true
+	case "[]byte":
+		return "Optional[str]", true
+	case "time.Duration":
+		return "int", true
+	case "json.RawMessage":
+		return "Any", true
+	}
+	if strings.HasSuffix(originName, "UUID") {
+		return "str", true
+	}
+	if strings.HasSuffix(originName, "Decimal") {
+		return "float", true
+	}
+	return
+}
+
+func castBuiltinPython(originName string) string {
+
+	switch originName {
+	case "bool":
+		return "bool"
+	case "string", "error":
+		return "str"
+	case "float32", "float64":
+		return "float"
+	case "complex64", "complex128", "any":
+		return "Any"
+	}
+	return "int"
+}
+
+const pyClientImports = `from __future__ import annotations
+
+import asyncio
+import base64
+import enum
+import hashlib
+import hmac
+import itertools
+import json
+import ssl
+import threading
+import time
+import urllib.error
+import urllib.parse
+import urllib.request
+import uuid
+from dataclasses import dataclass, field
+from typing import Any, Callable, Dict, Generic, List, Mapping, Optional, Protocol, Sequence, Set, Tuple, TypeVar, Union, cast
+
+from .errors import HTTPError, convert_error
+`
+
+const pyProject = `[build-system]
+requires = ["setuptools>=61"]
+build-backend = "setuptools.build_meta"
+
+[project]
+name = %q
+version = %q
+description = %s
+requires-python = ">=3.8"
+dependencies = []
+
+[tool.setuptools]
+packages = [%q]
+
+[tool.setuptools.package-data]
+%s = ["py.typed"]
+`
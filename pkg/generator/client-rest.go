@@ -0,0 +1,99 @@
+package generator
+
+import (
+	"path"
+	"path/filepath"
+
+	. "github.com/dave/jennifer/jen"
+)
+
+func (tr Transport) renderClientREST(outDir string) (err error) {
+
+	srcFile := newSrc(filepath.Base(outDir))
+	srcFile.PackageComment(doNotEdit)
+
+	srcFile.ImportName(packageJson, "json")
+	srcFile.ImportName(packageZeroLog, "zerolog")
+
+	srcFile.Line().Type().Id("HTTPErrorDecoder").Func().Params(Id("statusCode").Int(), Id("body").Op("[]").Byte()).Params(Error())
+
+	srcFile.Line().Comment("ErrorHTTP returned by REST methods when server responds with unsuccessful status code")
+	srcFile.Type().Id("ErrorHTTP").Struct(
+		Id("StatusCode").Int(),
+		Id("Body").Op("[]").Byte(),
+	)
+	srcFile.Line().Func().Params(Id("e").Id("ErrorHTTP")).Id("Code").Params().Params(Int()).Block(
+		Return(Id("e").Dot("StatusCode")),
+	)
+	srcFile.Line().Func().Params(Id("e").Id("ErrorHTTP")).Id("Error").Params().Params(String()).Block(
+		Var().Id("msg").String(),
+		If(Qual(packageJson, "Unmarshal").Call(Id("e").Dot("Body"), Op("&").Id("msg")).Op("==").Nil().Op("&&").Id("msg").Op("!=").Lit("")).Block(
+			Return(Id("msg")),
+		),
+		Return(Qual(packageFmt, "Sprintf").Call(Lit("http status %d: %s"), Id("e").Dot("StatusCode"), Id("e").Dot("Body"))),
+	)
+	srcFile.Line().Func().Id("defaultHTTPErrorDecoder").Params(Id("statusCode").Int(), Id("body").Op("[]").Byte()).Params(Error()).Block(
+		Return(Id("ErrorHTTP").Values(Dict{
+			Id("StatusCode"): Id("statusCode"),
+			Id("Body"):       Append(Op("[]").Byte().Call(Nil()), Id("body").Op("...")),
+		})),
+	)
+	srcFile.Line().Comment("restURL joins path of method with path of client URL")
+	srcFile.Func().Params(Id("cli").Op("*").Id("ClientJsonRPC")).Id("restURL").
+		Params(Id("path").String(), Id("query").Qual(packageURL, "Values")).Params(Id("restURL").String(), Err().Error()).Block(
+		Var().Id("baseURL").Op("*").Qual(packageURL, "URL"),
+		If(List(Id("baseURL"), Err()).Op("=").Qual(packageURL, "Parse").Call(Id("cli").Dot("url")).Op(";").Err().Op("!=").Nil()).Block(
+			Return(),
+		),
+		Id("restURL").Op("=").Id("baseURL").Dot("Scheme").Op("+").Lit("://").Op("+").Id("baseURL").Dot("Host").Op("+").Qual(packageStrings, "TrimSuffix").Call(Id("baseURL").Dot("EscapedPath").Call(), Lit("/")).Op("+").Id("path"),
+		If(Len(Id("query")).Op("!=").Lit(0)).Block(
+			Id("restURL").Op("+=").Lit("?").Op("+").Id("query").Dot("Encode").Call(),
+		),
+		Return(),
+	)
+	srcFile.Line().Func().Id("writeFormFile").Params(Id("form").Op("*").Qual(packageMultipart, "Writer"), Id("field").String(), Id("content").Op("[]").Byte()).Params(Err().Error()).Block(
+		Var().Id("part").Qual(packageIO, "Writer"),
+		If(List(Id("part"), Err()).Op("=").Id("form").Dot("CreateFormFile").Call(Id("field"), Id("field")).Op(";").Err().Op("!=").Nil()).Block(
+			Return(),
+		),
+		List(Id("_"), Err()).Op("=").Id("part").Dot("Write").Call(Id("content")),
+		Return(),
+	)
+	srcFile.Line().Func().Params(Id("cli").Op("*").Id("ClientJsonRPC")).Id("restCall").
+		ParamsFunc(func(pg *Group) {
+			pg.Id(_ctx_).Qual(packageContext, "Context")
+			pg.Id("method").String()
+			pg.Id("request").Op("*").Id("HTTPRequest")
+			pg.Id("idempotent").Bool()
+		}).Params(Id("response").Op("*").Id("HTTPResponse"), Err().Error()).BlockFunc(func(bg *Group) {
+		bg.For(Id("attempt").Op(":=").Lit(1).Op(";").Op(";").Id("attempt").Op("++")).Block(
+			Var().Id("statusCode").Int(),
+			Comment("every attempt is sent with own copy of request, exchange modifies URL and headers"),
+			Id("attemptRequest").Op(":=").Op("*").Id("request"),
+			Id("attemptRequest").Dot("Header").Op("=").Id("request").Dot("Header").Dot("Clone").Call(),
+			If(List(Id("response"), Err()).Op("=").Id("cli").Dot("exchange").Call(Id(_ctx_), Op("&").Id("attemptRequest"), Id("method")).Op(";").Err().Op("==").Nil()).Block(
+				Id("statusCode").Op("=").Id("response").Dot("StatusCode"),
+				If(Id("statusCode").Op("<").Qual(packageHttp, "StatusOK").Op("||").Id("statusCode").Op(">=").Qual(packageHttp, "StatusMultipleChoices")).Block(
+					Err().Op("=").Id("cli").Dot("httpErrorDecoder").Call(Id("statusCode"), Id("response").Dot("Body")),
+				),
+			),
+			If(Err().Op("==").Nil().Op("||").Op("!").Id("idempotent").Op("||").Id("attempt").Op(">=").Id("cli").Dot("retry").Dot("maxAttempts").Op("||").Op("!").Id("cli").Dot("retry").Dot("retryIf").Call(Id("statusCode"), Err())).Block(
+				Return(),
+			),
+			Id("cli").Dot("log").Dot("Warn").Call().Dot("Err").Call(Err()).Dot("Int").Call(Lit("attempt"), Id("attempt")).Dot("Str").Call(Lit("url"), Id("request").Dot("URL")).Dot("Msg").Call(Lit("retry http call")),
+			If(Id("waitErr").Op(":=").Id("cli").Dot("retry").Dot("wait").Call(Id(_ctx_), Id("attempt")).Op(";").Id("waitErr").Op("!=").Nil()).Block(
+				Return(),
+			),
+		)
+	})
+	return srcFile.Save(path.Join(outDir, "rest.go"))
+}
+
+func (tr Transport) hasREST() bool {
+	for _, serviceName := range tr.serviceKeys() {
+		if tr.services[serviceName].hasREST() {
+			return true
+		}
+	}
+	return false
+}
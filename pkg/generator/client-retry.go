@@ -0,0 +1,80 @@
+package generator
+
+import (
+	"path"
+	"path/filepath"
+
+	. "github.com/dave/jennifer/jen"
+)
+
+const (
+	defaultRetryMinBackoff = 100
+	defaultRetryMaxBackoff = 5000
+)
+
+func (tr Transport) renderClientRetry(outDir string) (err error) {
+
+	srcFile := newSrc(filepath.Base(outDir))
+	srcFile.PackageComment(doNotEdit)
+
+	srcFile.Line().Comment("RetryPredicate reports whether failed call should be retried, statusCode is 0 if server did not respond")
+	srcFile.Type().Id("RetryPredicate").Func().Params(Id("statusCode").Int(), Err().Error()).Params(Bool())
+
+	srcFile.Line().Type().Id("retryPolicy").Struct(
+		Id("maxAttempts").Int(),
+		Id("minBackoff").Qual(packageTime, "Duration"),
+		Id("maxBackoff").Qual(packageTime, "Duration"),
+		Id("retryIf").Id("RetryPredicate"),
+	)
+
+	srcFile.Line().Func().Id("defaultRetryPolicy").Params().Params(Id("retryPolicy")).Block(
+		Return(Id("retryPolicy").Values(Dict{
+			Id("maxAttempts"): Lit(1),
+			Id("minBackoff"):  Lit(defaultRetryMinBackoff).Op("*").Qual(packageTime, "Millisecond"),
+			Id("maxBackoff"):  Lit(defaultRetryMaxBackoff).Op("*").Qual(packageTime, "Millisecond"),
+			Id("retryIf"):     Id("defaultRetryIf"),
+		})),
+	)
+
+	srcFile.Line().Func().Id("defaultRetryIf").Params(Id("statusCode").Int(), Err().Error()).Params(Bool()).Block(
+		If(Qual(packageErrors, "Is").Call(Err(), Id("ErrBreakerOpen")).Op("||").Qual(packageErrors, "Is").Call(Err(), Id("ErrBulkheadFull"))).Block(
+			Return(False()),
+		),
+		Switch(Id("statusCode")).Block(
+			Case(Lit(0)).Block(
+				Return(Err().Op("!=").Nil()),
+			),
+			Case(
+				Qual(packageHttp, "StatusTooManyRequests"),
+				Qual(packageHttp, "StatusBadGateway"),
+				Qual(packageHttp, "StatusServiceUnavailable"),
+				Qual(packageHttp, "StatusGatewayTimeout"),
+			).Block(
+				Return(True()),
+			),
+		),
+		Return(False()),
+	)
+
+	srcFile.Line().Comment("wait sleeps exponential backoff with full jitter before next attempt")
+	srcFile.Func().Params(Id("policy").Id("retryPolicy")).Id("wait").Params(Id(_ctx_).Qual(packageContext, "Context"), Id("attempt").Int()).Params(Err().Error()).Block(
+		Id("backoff").Op(":=").Id("policy").Dot("minBackoff").Op("<<").Id("uint").Call(Id("attempt").Op("-").Lit(1)),
+		If(Id("backoff").Op("<=").Lit(0).Op("||").Id("backoff").Op(">").Id("policy").Dot("maxBackoff")).Block(
+			Id("backoff").Op("=").Id("policy").Dot("maxBackoff"),
+		),
+		If(Id("backoff").Op(">").Lit(0)).Block(
+			Id("backoff").Op("=").Qual(packageTime, "Duration").Call(Qual(packageRand, "Int63n").Call(Int64().Call(Id("backoff"))).Op("+").Lit(1)),
+		),
+		Id("timer").Op(":=").Qual(packageTime, "NewTimer").Call(Id("backoff")),
+		Defer().Id("timer").Dot("Stop").Call(),
+		Select().Block(
+			Case(Op("<-").Id(_ctx_).Dot("Done").Call()).Block(
+				Return(Id(_ctx_).Dot("Err").Call()),
+			),
+			Case(Op("<-").Id("timer").Dot("C")).Block(
+				Return(Nil()),
+			),
+		),
+	)
+	return srcFile.Save(path.Join(outDir, "retry.go"))
+}
@@ -16,7 +16,6 @@ func (tr Transport) renderClientTracer(outDir string) (err error) {
 	srcFile.PackageComment(doNotEdit)
 
 	srcFile.ImportName(packageHttp, "http")
-	srcFile.ImportName(packageFiber, "fiber")
 	srcFile.ImportName(packageJaegerLog, "log")
 	srcFile.ImportName(packageZeroLog, "zerolog")
 	srcFile.ImportAlias(packageOpentracing, "otg")
@@ -46,8 +45,7 @@ func (tr Transport) extractSpanClientFunc() Code {
 }
 
 func (tr Transport) injectSpanClientFunc() Code {
-	return Func().Id("injectSpan").Params(Id("log").Qual(packageZeroLog, "Logger"), Id("span").Qual(packageOpentracing, "Span"), Id("request").Op("*").Qual(packageFiber, "Request")).Params().Block(
-		Id("headers").Op(":=").Make(Qual(packageHttp, "Header")),
+	return Func().Id("injectSpan").Params(Id("log").Qual(packageZeroLog, "Logger"), Id("span").Qual(packageOpentracing, "Span"), Id("headers").Qual(packageHttp, "Header")).Params().Block(
 		If(Err().Op(":=").Qual(packageOpentracing, "GlobalTracer").Call().
 			Dot("Inject").Call(
 			Id("span").Dot("Context").Call(),
@@ -56,8 +54,5 @@ func (tr Transport) injectSpanClientFunc() Code {
 		).Op(";").Err().Op("!=").Nil()).Block(
 			Id("log").Dot("Warn").Call().Dot("Err").Call(Err()).Dot("Msg").Call(Lit("inject span to HTTP headers")),
 		),
-		For(List(Id("key"), Id("values")).Op(":=").Range().Id("headers")).Block(
-			Id("request").Dot("Header").Dot("Set").Call(Id("key"), Qual(packageStrings, "Join").Call(Id("values"), Lit(";"))),
-		),
 	)
 }
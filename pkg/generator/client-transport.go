@@ -0,0 +1,241 @@
+package generator
+
+import (
+	"path"
+	"path/filepath"
+
+	. "github.com/dave/jennifer/jen"
+)
+
+func (tr Transport) renderClientTransport(outDir string) (err error) {
+
+	srcFile := newSrc(filepath.Base(outDir))
+	srcFile.PackageComment(doNotEdit)
+
+	srcFile.ImportName(packageFastHttp, "fasthttp")
+	srcFile.ImportAlias(packageUUID, "goUUID")
+
+	srcFile.Line().Type().Id("HTTPRequest").Struct(
+		Id("Method").String(),
+		Id("URL").String(),
+		Id("Header").Qual(packageHttp, "Header"),
+		Id("Body").Op("[]").Byte(),
+	)
+
+	srcFile.Line().Type().Id("HTTPResponse").Struct(
+		Id("StatusCode").Int(),
+		Id("Header").Qual(packageHttp, "Header"),
+		Id("Body").Op("[]").Byte(),
+	)
+
+	srcFile.Line().Comment("HTTPTransport sends requests of client, implementation must return when ctx is done")
+	srcFile.Type().Id("HTTPTransport").Interface(
+		Id("Do").Params(Id(_ctx_).Qual(packageContext, "Context"), Id("request").Op("*").Id("HTTPRequest")).Params(Id("response").Op("*").Id("HTTPResponse"), Err().Error()),
+	)
+
+	srcFile.Line().Comment("TransportFunc allows to use ordinary function as HTTPTransport")
+	srcFile.Type().Id("TransportFunc").Func().Params(Id(_ctx_).Qual(packageContext, "Context"), Id("request").Op("*").Id("HTTPRequest")).Params(Id("response").Op("*").Id("HTTPResponse"), Err().Error())
+
+	srcFile.Line().Func().Params(Id("fn").Id("TransportFunc")).Id("Do").Params(Id(_ctx_).Qual(packageContext, "Context"), Id("request").Op("*").Id("HTTPRequest")).Params(Op("*").Id("HTTPResponse"), Error()).Block(
+		Return(Id("fn").Call(Id(_ctx_), Id("request"))),
+	)
+
+	srcFile.Line().Type().Id("netHTTPTransport").Struct(
+		Id("client").Op("*").Qual(packageHttp, "Client"),
+	)
+
+	srcFile.Line().Comment("NetHTTPTransport sends requests by net/http client, use http.Client.Transport to substitute RoundTripper")
+	srcFile.Func().Id("NetHTTPTransport").Params(Id("client").Op("*").Qual(packageHttp, "Client")).Params(Id("HTTPTransport")).Block(
+		Return(Id("netHTTPTransport").Values(Dict{Id("client"): Id("client")})),
+	)
+
+	srcFile.Line().Func().Params(Id("t").Id("netHTTPTransport")).Id("Do").Params(Id(_ctx_).Qual(packageContext, "Context"), Id("request").Op("*").Id("HTTPRequest")).Params(Id("response").Op("*").Id("HTTPResponse"), Err().Error()).Block(
+		Var().Id("httpRequest").Op("*").Qual(packageHttp, "Request"),
+		If(List(Id("httpRequest"), Err()).Op("=").Qual(packageHttp, "NewRequestWithContext").Call(Id(_ctx_), Id("request").Dot("Method"), Id("request").Dot("URL"), Qual(packageBytes, "NewReader").Call(Id("request").Dot("Body"))).Op(";").Err().Op("!=").Nil()).Block(
+			Return(),
+		),
+		Id("httpRequest").Dot("Header").Op("=").Id("request").Dot("Header"),
+		Var().Id("httpResponse").Op("*").Qual(packageHttp, "Response"),
+		If(List(Id("httpResponse"), Err()).Op("=").Id("t").Dot("client").Dot("Do").Call(Id("httpRequest")).Op(";").Err().Op("!=").Nil()).Block(
+			Return(),
+		),
+		Defer().Id("httpResponse").Dot("Body").Dot("Close").Call(),
+		Id("response").Op("=").Op("&").Id("HTTPResponse").Values(Dict{
+			Id("StatusCode"): Id("httpResponse").Dot("StatusCode"),
+			Id("Header"):     Id("httpResponse").Dot("Header"),
+		}),
+		List(Id("response").Dot("Body"), Err()).Op("=").Qual(packageIO, "ReadAll").Call(Id("httpResponse").Dot("Body")),
+		Return(),
+	)
+
+	srcFile.Line().Type().Id("fastHTTPTransport").Struct(
+		Id("client").Op("*").Qual(packageFastHttp, "Client"),
+	)
+
+	srcFile.Line().Comment("FastHTTPTransport sends requests by fasthttp client")
+	srcFile.Func().Id("FastHTTPTransport").Params(Id("client").Op("*").Qual(packageFastHttp, "Client")).Params(Id("HTTPTransport")).Block(
+		Return(Id("fastHTTPTransport").Values(Dict{Id("client"): Id("client")})),
+	)
+
+	srcFile.Line().Func().Params(Id("t").Id("fastHTTPTransport")).Id("Do").Params(Id(_ctx_).Qual(packageContext, "Context"), Id("request").Op("*").Id("HTTPRequest")).Params(Id("response").Op("*").Id("HTTPResponse"), Err().Error()).Block(
+		Id("req").Op(":=").Op("&").Qual(packageFastHttp, "Request").Values(),
+		Id("resp").Op(":=").Op("&").Qual(packageFastHttp, "Response").Values(),
+		Id("req").Dot("SetRequestURI").Call(Id("request").Dot("URL")),
+		Id("req").Dot("Header").Dot("SetMethod").Call(Id("request").Dot("Method")),
+		For(List(Id("name"), Id("values")).Op(":=").Range().Id("request").Dot("Header")).Block(
+			For(List(Id("_"), Id("value")).Op(":=").Range().Id("values")).Block(
+				Id("req").Dot("Header").Dot("Add").Call(Id("name"), Id("value")),
+			),
+		),
+		Id("req").Dot("SetBody").Call(Id("request").Dot("Body")),
+		Line().Comment("request and response are owned by goroutine, which may outlive canceled call"),
+		Id("done").Op(":=").Make(Chan().Error(), Lit(1)),
+		Go().Func().Params().Block(
+			If(List(Id("deadline"), Id("ok")).Op(":=").Id(_ctx_).Dot("Deadline").Call().Op(";").Id("ok")).Block(
+				Id("done").Op("<-").Id("t").Dot("client").Dot("DoDeadline").Call(Id("req"), Id("resp"), Id("deadline")),
+				Return(),
+			),
+			Id("done").Op("<-").Id("t").Dot("client").Dot("Do").Call(Id("req"), Id("resp")),
+		).Call(),
+		Select().Block(
+			Case(Op("<-").Id(_ctx_).Dot("Done").Call()).Block(
+				Return(Nil(), Id(_ctx_).Dot("Err").Call()),
+			),
+			Case(Err().Op("=").Op("<-").Id("done")).Block(),
+		),
+		If(Err().Op("!=").Nil()).Block(
+			Return(),
+		),
+		Id("response").Op("=").Op("&").Id("HTTPResponse").Values(Dict{
+			Id("StatusCode"): Id("resp").Dot("StatusCode").Call(),
+			Id("Header"):     Make(Qual(packageHttp, "Header")),
+			Id("Body"):       Append(Op("[]").Byte().Call(Nil()), Id("resp").Dot("Body").Call().Op("...")),
+		}),
+		Id("resp").Dot("Header").Dot("VisitAll").Call(Func().Params(List(Id("key"), Id("value")).Op("[]").Byte()).Block(
+			Id("response").Dot("Header").Dot("Add").Call(String().Call(Id("key")), String().Call(Id("value"))),
+		)),
+		Return(),
+	)
+
+	srcFile.Line().Comment("HandlerTransport passes requests to handler in memory, it is intended for tests")
+	srcFile.Func().Id("HandlerTransport").Params(Id("handler").Qual(packageHttp, "Handler")).Params(Id("HTTPTransport")).Block(
+		Return(Id("TransportFunc").Call(Func().Params(Id(_ctx_).Qual(packageContext, "Context"), Id("request").Op("*").Id("HTTPRequest")).Params(Id("response").Op("*").Id("HTTPResponse"), Err().Error()).Block(
+			Var().Id("httpRequest").Op("*").Qual(packageHttp, "Request"),
+			If(List(Id("httpRequest"), Err()).Op("=").Qual(packageHttp, "NewRequestWithContext").Call(Id(_ctx_), Id("request").Dot("Method"), Id("request").Dot("URL"), Qual(packageBytes, "NewReader").Call(Id("request").Dot("Body"))).Op(";").Err().Op("!=").Nil()).Block(
+				Return(),
+			),
+			Id("httpRequest").Dot("Header").Op("=").Id("request").Dot("Header"),
+			Id("httpRequest").Dot("RequestURI").Op("=").Id("httpRequest").Dot("URL").Dot("RequestURI").Call(),
+			Id("recorder").Op(":=").Qual(packageHttpTest, "NewRecorder").Call(),
+			Id("handler").Dot("ServeHTTP").Call(Id("recorder"), Id("httpRequest")),
+			Return(Op("&").Id("HTTPResponse").Values(Dict{
+				Id("StatusCode"): Id("recorder").Dot("Code"),
+				Id("Header"):     Id("recorder").Dot("Header").Call(),
+				Id("Body"):       Id("recorder").Dot("Body").Dot("Bytes").Call(),
+			}), Nil()),
+		))),
+	)
+
+	srcFile.Line().Func().Id("defaultHTTPTransport").Params().Params(Op("*").Qual(packageHttp, "Transport")).Block(
+		Return(Qual(packageHttp, "DefaultTransport").Op(".(*").Qual(packageHttp, "Transport").Op(")").Dot("Clone").Call()),
+	)
+
+	srcFile.Line().Comment("newRequest creates request with request ID and headers taken from context")
+	srcFile.Func().Params(Id("cli").Op("*").Id("ClientJsonRPC")).Id("newRequest").Params(Id(_ctx_).Qual(packageContext, "Context"), Id("method"), Id("url").String()).Params(Id("request").Op("*").Id("HTTPRequest")).Block(
+		Id("request").Op("=").Op("&").Id("HTTPRequest").Values(Dict{
+			Id("Method"): Id("method"),
+			Id("URL"):    Id("url"),
+			Id("Header"): Make(Qual(packageHttp, "Header")),
+		}),
+		List(Id("requestID"), Id("_")).Op(":=").Id(_ctx_).Dot("Value").Call(Id("headerRequestID")).Op(".(").String().Op(")"),
+		If(Id("requestID").Op("==").Lit("")).Block(
+			Id("requestID").Op("=").Qual(packageUUID, "New").Call().Dot("String").Call(),
+		),
+		Id("request").Dot("Header").Dot("Set").Call(Id("headerRequestID"), Id("requestID")),
+		For(List(Id("_"), Id("header")).Op(":=").Range().Id("cli").Dot("headers")).Block(
+			If(List(Id("value"), Id("ok")).Op(":=").Id(_ctx_).Dot("Value").Call(Id("header")).Op(".(").String().Op(")")).Op(";").Id("ok").Block(
+				Id("request").Dot("Header").Dot("Set").Call(Id("header"), Id("value")),
+			),
+		),
+		If(Id("cli").Dot("tracing")).Block(
+			If(Id("span").Op(":=").Qual(packageOpentracing, "SpanFromContext").Call(Id(_ctx_)).Op(";").Id("span").Op("!=").Nil()).Block(
+				Id("injectSpan").Call(Id("cli").Dot("log"), Id("span"), Id("request").Dot("Header")),
+			),
+		),
+		Return(),
+	)
+	srcFile.Line().Comment("exchange sends request to balanced endpoint guarded by bulkhead, client and method circuit breakers")
+	srcFile.Func().Params(Id("cli").Op("*").Id("ClientJsonRPC")).Id("exchange").
+		Params(Id(_ctx_).Qual(packageContext, "Context"), Id("request").Op("*").Id("HTTPRequest"), Id("methods").Op("...").String()).
+		Params(Id("response").Op("*").Id("HTTPResponse"), Err().Error()).Block(
+		Id("callerCtx").Op(":=").Id(_ctx_),
+		Id("timeout").Op(":=").Id("cli").Dot("timeout"),
+		If(Len(Id("methods")).Op("==").Lit(1)).Block(
+			If(List(Id("methodTimeout"), Id("found")).Op(":=").Id("cli").Dot("methodTimeouts").Index(Id("methods").Index(Lit(0))).Op(";").Id("found")).Block(
+				Id("timeout").Op("=").Id("methodTimeout"),
+			),
+		),
+		If(Id("timeout").Op(">").Lit(0)).Block(
+			Var().Id("cancel").Qual(packageContext, "CancelFunc"),
+			List(Id(_ctx_), Id("cancel")).Op("=").Qual(packageContext, "WithTimeout").Call(Id(_ctx_), Id("timeout")),
+			Defer().Id("cancel").Call(),
+		),
+		If(Id("cli").Dot("bulkhead").Op("!=").Nil()).Block(
+			If(Err().Op("=").Id("cli").Dot("bulkhead").Dot("acquire").Call(Id(_ctx_)).Op(";").Err().Op("!=").Nil()).Block(
+				Return(),
+			),
+			Defer().Id("cli").Dot("bulkhead").Dot("release").Call(),
+		),
+		Var().Id("breakers").Op("[]*").Id("circuitBreaker"),
+		If(Id("cli").Dot("breaker").Op("!=").Nil()).Block(
+			Id("breakers").Op("=").Append(Id("breakers"), Id("cli").Dot("breaker")),
+		),
+		For(List(Id("_"), Id("method")).Op(":=").Range().Id("methods")).Block(
+			If(List(Id("breaker"), Id("found")).Op(":=").Id("cli").Dot("methodBreakers").Index(Id("method")).Op(";").Id("found")).Block(
+				Id("breakers").Op("=").Append(Id("breakers"), Id("breaker")),
+			),
+		),
+		For(List(Id("i"), Id("breaker")).Op(":=").Range().Id("breakers")).Block(
+			If(Err().Op("=").Id("breaker").Dot("allow").Call().Op(";").Err().Op("!=").Nil()).Block(
+				For(List(Id("_"), Id("allowed")).Op(":=").Range().Id("breakers").Index(Empty(), Id("i"))).Block(
+					Id("allowed").Dot("cancel").Call(),
+				),
+				Return(),
+			),
+		),
+		Var().Id("failed").Bool(),
+		If(Id("cli").Dot("balancer").Dot("resolver").Op("!=").Nil()).Block(
+			Var().Id("ep").Op("*").Id("endpoint"),
+			If(List(Id("ep"), Err()).Op("=").Id("cli").Dot("balancer").Dot("pick").Call(Id(_ctx_)).Op(";").Err().Op("==").Nil()).Block(
+				Defer().Func().Params().Block(
+					Id("cli").Dot("balancer").Dot("release").Call(Id("ep"), Id("failed")),
+				).Call(),
+				Err().Op("=").Id("ep").Dot("route").Call(Id("request")),
+			),
+		),
+		If(Err().Op("==").Nil()).Block(
+			Err().Op("=").Id("cli").Dot("authorize").Call(Id(_ctx_), Id("request")),
+		),
+		If(Err().Op("!=").Nil()).Block(
+			For(List(Id("_"), Id("breaker")).Op(":=").Range().Id("breakers")).Block(
+				Id("breaker").Dot("cancel").Call(),
+			),
+			Return(),
+		),
+		List(Id("response"), Err()).Op("=").Id("cli").Dot("transport").Dot("Do").Call(Id(_ctx_), Id("request")),
+		Comment("call canceled by caller or expired its deadline says nothing about service health"),
+		Id("failed").Op("=").Err().Op("!=").Nil().Op("&&").Id("callerCtx").Dot("Err").Call().Op("==").Nil(),
+		If(Err().Op("!=").Nil().Op("&&").Id("callerCtx").Dot("Err").Call().Op("!=").Nil()).Block(
+			For(List(Id("_"), Id("breaker")).Op(":=").Range().Id("breakers")).Block(
+				Id("breaker").Dot("cancel").Call(),
+			),
+			Return(),
+		),
+		Id("success").Op(":=").Err().Op("==").Nil().Op("&&").Id("response").Dot("StatusCode").Op("<").Qual(packageHttp, "StatusInternalServerError"),
+		For(List(Id("_"), Id("breaker")).Op(":=").Range().Id("breakers")).Block(
+			Id("breaker").Dot("done").Call(Id("success")),
+		),
+		Return(),
+	)
+	return srcFile.Save(path.Join(outDir, "transport.go"))
+}
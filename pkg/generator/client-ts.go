@@ -0,0 +1,661 @@
+package generator
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/vetcher/go-astra/types"
+
+	"github.com/tundrik/tg/v2/pkg/tags"
+	"github.com/tundrik/tg/v2/pkg/utils"
+)
+
+var tsIdentifier = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+type clientTS struct {
+	*Transport
+	pkgName string
+	names   map[string]string
+	owners  map[string]string
+	structs map[string]bool
+	decls   []string
+}
+
+type tsField struct {
+	name     string
+	typeName string
+	optional bool
+}
+
+func (tr Transport) RenderClientTS(outDir string) (err error) {
+	return newClientTS(&tr).render(outDir)
+}
+
+// RenderClientsJS renders js and typescript clients, each of them has own package manifest,
+// so when both are enabled they are rendered to 'js' and 'ts' subdirectories of outDir
+func (tr Transport) RenderClientsJS(outDir string, withJS, withTS bool) (err error) {
+
+	if !withJS || !withTS {
+		if withJS {
+			return tr.RenderClientJS(outDir)
+		}
+		if withTS {
+			return tr.RenderClientTS(outDir)
+		}
+		return
+	}
+	js := newClientJS(&tr)
+	js.pkgName = npmPackageName(outDir) + "-js"
+	if err = js.render(path.Join(outDir, "js")); err != nil {
+		return
+	}
+	ts := newClientTS(&tr)
+	ts.pkgName = npmPackageName(outDir) + "-ts"
+	return ts.render(path.Join(outDir, "ts"))
+}
+
+func newClientTS(tr *Transport) (ts *clientTS) {
+	ts = &clientTS{
+		Transport: tr,
+		names:     make(map[string]string),
+		owners:    make(map[string]string),
+		structs:   make(map[string]bool),
+	}
+	return
+}
+
+func (ts *clientTS) render(outDir string) (err error) {
+
+	srcDir := path.Join(outDir, "src")
+	if err = os.MkdirAll(srcDir, 0777); err != nil {
+		return
+	}
+	var exchange bytesWriter
+	for _, name := range ts.serviceKeys() {
+		svc := ts.services[name]
+		for _, method := range svc.clientMethods() {
+			exchange.WriteString(ts.interfaceDecl(ts.requestName(method), nil, ts.requestFields(method)))
+			exchange.WriteString(ts.interfaceDecl(ts.responseName(method), nil, ts.responseFields(method)))
+		}
+	}
+	var typesFile bytesWriter
+	typesFile.add("// %s\n", doNotEdit)
+	for _, decl := range ts.decls {
+		typesFile.WriteString(decl)
+	}
+	typesFile.WriteString(exchange.String())
+	if err = ioutil.WriteFile(path.Join(srcDir, "types.ts"), typesFile.Bytes(), 0600); err != nil {
+		return
+	}
+	if err = ioutil.WriteFile(path.Join(srcDir, "errors.ts"), ts.renderErrors(), 0600); err != nil {
+		return
+	}
+	if err = ioutil.WriteFile(path.Join(srcDir, "client.ts"), ts.renderClient(), 0600); err != nil {
+		return
+	}
+	var indexFile bytesWriter
+	indexFile.add("// %s\n", doNotEdit)
+	indexFile.add("export * from \"./types.js\";\n")
+	indexFile.add("export * from \"./errors.js\";\n")
+	indexFile.add("export * from \"./client.js\";\n")
+	indexFile.add("export { default } from \"./client.js\";\n")
+	if err = ioutil.WriteFile(path.Join(srcDir, "index.ts"), indexFile.Bytes(), 0600); err != nil {
+		return
+	}
+	if err = ioutil.WriteFile(path.Join(outDir, "package.json"), ts.renderPackage(outDir), 0600); err != nil {
+		return
+	}
+	return ioutil.WriteFile(path.Join(outDir, "tsconfig.json"), []byte(tsConfig), 0600)
+}
+
+func (ts *clientTS) renderClient() []byte {
+
+	var tsFile bytesWriter
+	tsFile.add("// %s\n", doNotEdit)
+	tsFile.add("import { HTTPError, convertError } from \"./errors.js\";\n")
+	tsFile.add("import type { JSONRPCErrorObject } from \"./errors.js\";\n")
+	if names := ts.typeNames(); len(names) != 0 {
+		tsFile.add("import type { %s } from \"./types.js\";\n", strings.Join(names, ", "))
+	}
+	tsFile.add("\nconst defaultMaxBatchSize = %d;\n", ts.tags.ValueInt(tagBatchSize, 0))
+	tsFile.WriteString(tsClientBase)
+
+	var services []*service
+	for _, name := range ts.serviceKeys() {
+		if svc := ts.services[name]; len(svc.clientMethods()) != 0 {
+			services = append(services, svc)
+		}
+	}
+	for _, svc := range services {
+		tsFile.add("\nexport class %sClient {\n", svc.Name)
+		tsFile.add("  private readonly client: BaseClient;\n\n")
+		tsFile.add("  constructor(client: BaseClient) {\n")
+		tsFile.add("    this.client = client;\n")
+		tsFile.add("  }\n")
+		for _, method := range svc.clientMethods() {
+			tsFile.add("\n")
+			if summary := method.tags.Value(tagSummary); summary != "" {
+				tsFile.add("  /**\n   * %s\n   */\n", summary)
+			}
+			if method.isJsonRPC() {
+				tsFile.WriteString(ts.jsonrpcMethod(method))
+				continue
+			}
+			tsFile.WriteString(ts.restMethod(method))
+		}
+		tsFile.add("}\n")
+	}
+	tsFile.add("\nexport class Client extends BaseClient {\n")
+	for _, svc := range services {
+		tsFile.add("  readonly %s: %sClient = new %[2]sClient(this);\n", svc.lccName(), svc.Name)
+	}
+	tsFile.add("}\n\n")
+	tsFile.add("export default Client;\n")
+	return tsFile.Bytes()
+}
+
+func (ts *clientTS) jsonrpcMethod(method *method) string {
+
+	var params []string
+	for _, arg := range method.fieldsArgument() {
+		if name := fieldJsonName(arg); name != "-" {
+			params = append(params, fmt.Sprintf("%s: %s", tsPropertyName(name), utils.ToLowerCamel(arg.Name)))
+		}
+	}
+	var code bytesWriter
+	code.add("  %s(%s): Promise<%s> {\n", method.lccName(), ts.methodArgs(method), ts.responseName(method))
+	code.add("    const params: %s = %s;\n", ts.requestName(method), tsLiteral(params))
+	if method.svc.isBatchMethod(method) {
+		code.add("    return this.client.call<%s>(%q, params);\n", ts.responseName(method), method.jsonrpcName())
+	} else {
+		code.add("    return this.client.call<%s>(%q, params, false);\n", ts.responseName(method), method.jsonrpcName())
+	}
+	code.add("  }\n")
+	return code.String()
+}
+
+func (ts *clientTS) restMethod(method *method) string {
+
+	var code bytesWriter
+	code.add("  async %s(%s): Promise<%s> {\n", method.lccName(), ts.methodArgs(method), ts.responseName(method))
+	code.add("    const query = new URLSearchParams();\n")
+	for _, argName := range sortedKeys(method.argParamMap()) {
+		if arg := method.argByName(argName); arg != nil {
+			code.WriteString(tsArgValue(arg, fmt.Sprintf("query.set(%q, %%s);", method.argParamMap()[argName])))
+		}
+	}
+	code.add("    const request: HTTPRequest = { method: %q, url: this.client.restURL(%s, query), headers: {} };\n", strings.ToUpper(method.httpMethod()), ts.restPath(method))
+	for _, argName := range sortedKeys(method.varHeaderMap()) {
+		if arg := method.argByName(argName); arg != nil {
+			code.WriteString(tsArgValue(arg, fmt.Sprintf("request.headers[%q] = %%s;", method.varHeaderMap()[argName])))
+		}
+	}
+	if cookies := method.argCookieMap(); len(cookies) != 0 {
+		code.add("    const cookies: string[] = [];\n")
+		for _, argName := range sortedKeys(cookies) {
+			if arg := method.argByName(argName); arg != nil {
+				code.WriteString(tsArgValue(arg, fmt.Sprintf("cookies.push(%q + encodeURIComponent(%%s));", cookies[argName]+"=")))
+			}
+		}
+		code.add("    if (cookies.length) {\n")
+		code.add("      request.headers[\"Cookie\"] = cookies.join(\"; \");\n")
+		code.add("    }\n")
+	}
+	if uploads := method.uploadVarsMap(); len(uploads) != 0 {
+		code.add("    const form = new FormData();\n")
+		for _, argName := range sortedKeys(uploads) {
+			if arg := method.argByName(argName); arg != nil {
+				code.add("    form.append(%[1]q, %[2]s, %[1]q);\n", uploads[argName], utils.ToLowerCamel(arg.Name))
+			}
+		}
+		code.add("    request.body = form;\n")
+	} else if len(method.arguments()) != 0 {
+		var fields []string
+		for _, arg := range method.arguments() {
+			fields = append(fields, fmt.Sprintf("%s: %s", tsPropertyName(fieldJsonName(arg)), utils.ToLowerCamel(arg.Name)))
+		}
+		code.add("    const body: %s = %s;\n", ts.requestName(method), tsLiteral(fields))
+		code.add("    request.headers[\"Content-Type\"] = \"application/json\";\n")
+		code.add("    request.body = JSON.stringify(body);\n")
+	}
+	code.add("    const response = await this.client.send(request);\n")
+	for _, retName := range sortedKeys(method.downloadVarsMap()) {
+		if ret := method.resultByName(retName); ret != nil {
+			code.add("    return { %s: await response.blob() };\n", tsPropertyName(ret.Name))
+			code.add("  }\n")
+			return code.String()
+		}
+	}
+	code.add("    const text = await response.text();\n")
+	code.add("    const results: %s = text ? JSON.parse(text) : {};\n", ts.responseName(method))
+	for _, retName := range sortedKeys(method.varHeaderMap()) {
+		if ret := method.resultByName(retName); ret != nil {
+			code.add("    results.%s = response.headers.get(%q) ?? \"\";\n", ret.Name, method.varHeaderMap()[retName])
+		}
+	}
+	code.add("    return results;\n")
+	code.add("  }\n")
+	return code.String()
+}
+
+// restPath builds expression of URL path with path arguments substituted
+func (ts *clientTS) restPath(method *method) string {
+
+	var parts []string
+	var literal string
+	for _, token := range strings.Split(method.httpPath(), "/") {
+		if token == "" {
+			continue
+		}
+		literal += "/"
+		if !strings.HasPrefix(token, ":") {
+			literal += token
+			continue
+		}
+		arg := method.argByName(strings.TrimPrefix(token, ":"))
+		if arg == nil {
+			literal += token
+			continue
+		}
+		parts = append(parts, strconv.Quote(literal), fmt.Sprintf("encodeURIComponent(String(%s))", utils.ToLowerCamel(arg.Name)))
+		literal = ""
+	}
+	if literal != "" || len(parts) == 0 {
+		parts = append(parts, strconv.Quote(literal))
+	}
+	return strings.Join(parts, " + ")
+}
+
+// tsArgValue renders statement with argument converted to string, nullable arguments are skipped if not set
+func tsArgValue(arg *types.Variable, statement string) string {
+
+	argName := utils.ToLowerCamel(arg.Name)
+	if _, isPointer := arg.Type.(types.TPointer); isPointer {
+		return fmt.Sprintf("    if (%s !== null && %[1]s !== undefined) {\n      %s\n    }\n", argName, fmt.Sprintf(statement, "String("+argName+")"))
+	}
+	return fmt.Sprintf("    %s\n", fmt.Sprintf(statement, "String("+argName+")"))
+}
+
+func (ts *clientTS) methodArgs(method *method) string {
+
+	var args []string
+	for _, arg := range method.argsWithoutContext() {
+		if vType, isEllipsis := arg.Type.(types.TEllipsis); isEllipsis {
+			args = append(args, fmt.Sprintf("...%s: Array<%s>", utils.ToLowerCamel(arg.Name), ts.walkVariable(method.svc.pkgPath, vType.Next)))
+			continue
+		}
+		typeName := ts.walkVariable(method.svc.pkgPath, arg.Type)
+		if _, isUpload := method.uploadVarsMap()[arg.Name]; isUpload && !method.isJsonRPC() {
+			typeName = "Blob"
+		}
+		args = append(args, fmt.Sprintf("%s: %s", utils.ToLowerCamel(arg.Name), typeName))
+	}
+	return strings.Join(args, ", ")
+}
+
+func (ts *clientTS) requestName(method *method) string {
+	return "Request" + method.svc.Name + method.Name
+}
+
+func (ts *clientTS) responseName(method *method) string {
+	return "Response" + method.svc.Name + method.Name
+}
+
+func (ts *clientTS) requestFields(method *method) (fields []tsField) {
+
+	for _, arg := range method.clientRequestFields() {
+		fields = append(fields, ts.field(method.svc.pkgPath, fieldJsonName(arg), arg, method.tags.Sub(arg.Name)))
+	}
+	return
+}
+
+// responseFields returns fields of JSON-RPC result or REST response, REST response also contains header and download results
+func (ts *clientTS) responseFields(method *method) (fields []tsField) {
+
+	for _, ret := range method.clientResponseFields() {
+		fields = append(fields, ts.field(method.svc.pkgPath, fieldJsonName(ret), ret, method.tags.Sub(ret.Name)))
+	}
+	if method.isJsonRPC() {
+		return
+	}
+	for _, retName := range sortedKeys(method.varHeaderMap()) {
+		if ret := method.resultByName(retName); ret != nil {
+			fields = append(fields, tsField{name: ret.Name, typeName: "string"})
+		}
+	}
+	for _, retName := range sortedKeys(method.downloadVarsMap()) {
+		if ret := method.resultByName(retName); ret != nil {
+			fields = append(fields, tsField{name: ret.Name, typeName: "Blob"})
+		}
+	}
+	return
+}
+
+func (ts *clientTS) field(pkgPath, name string, field types.StructField, fieldTags tags.DocTags) tsField {
+	return tsField{name: name, typeName: ts.walkVariable(pkgPath, field.Type), optional: !isRequired(field.Type, field.Tags["json"], fieldTags)}
+}
+
+func (ts *clientTS) walkVariable(pkgPath string, varType types.Type) string {
+
+	if typeName, found := castTypeTs(varType.String()); found {
+		return typeName
+	}
+	switch vType := varType.(type) {
+	case types.TName:
+		if types.IsBuiltin(varType) || vType.TypeName == "any" {
+			return castBuiltinTs(vType.TypeName)
+		}
+		return ts.declare(pkgPath, vType.TypeName)
+	case types.TImport:
+		return ts.declare(vType.Import.Package, vType.Next.String())
+	case types.TMap:
+		return fmt.Sprintf("Record<string, %s> | null", ts.walkVariable(pkgPath, vType.Value))
+	case types.TArray:
+		if !vType.IsSlice {
+			return fmt.Sprintf("Array<%s>", ts.walkVariable(pkgPath, vType.Next))
+		}
+		return fmt.Sprintf("Array<%s> | null", ts.walkVariable(pkgPath, vType.Next))
+	case types.TEllipsis:
+		return fmt.Sprintf("Array<%s> | null", ts.walkVariable(pkgPath, vType.Next))
+	case types.TPointer:
+		typeName := ts.walkVariable(pkgPath, vType.Next)
+		if !strings.HasSuffix(typeName, " | null") {
+			typeName += " | null"
+		}
+		return typeName
+	case types.Struct:
+		extends, fields := ts.structFields(pkgPath, vType)
+		var properties []string
+		for _, field := range fields {
+			properties = append(properties, field.property())
+		}
+		return strings.Join(append(extends, "{ "+strings.Join(properties, " ")+" }"), " & ")
+	}
+	return "unknown"
+}
+
+// declare renders declaration of named type once and returns its name
+func (ts *clientTS) declare(pkgPath, name string) string {
+
+	key := pkgPath + "." + name
+	if tsName, found := ts.names[key]; found {
+		return tsName
+	}
+	nextType := searchType(pkgPath, name)
+	if nextType == nil {
+		ts.log.WithField("type", key).Warn("type not found")
+		ts.names[key] = "unknown"
+		return "unknown"
+	}
+	tsName := name
+	if owner, found := ts.owners[tsName]; found && owner != key {
+		tsName = utils.ToCamel(path.Base(pkgPath)) + name
+	}
+	ts.names[key] = tsName
+	ts.owners[tsName] = key
+
+	switch vType := nextType.(type) {
+	case types.Struct:
+		ts.structs[tsName] = true
+		extends, fields := ts.structFields(pkgPath, vType)
+		ts.decls = append(ts.decls, ts.interfaceDecl(tsName, extends, fields))
+	case types.TInterface:
+		ts.decls = append(ts.decls, fmt.Sprintf("\nexport type %s = unknown;\n", tsName))
+	default:
+		if values := searchEnum(pkgPath, name); len(values) != 0 {
+			ts.decls = append(ts.decls, tsEnum(tsName, values))
+			break
+		}
+		ts.decls = append(ts.decls, fmt.Sprintf("\nexport type %s = %s;\n", tsName, ts.walkVariable(pkgPath, nextType)))
+	}
+	return tsName
+}
+
+// structFields returns fields as encoding/json marshals them, embedded structs are returned as extended interfaces
+func (ts *clientTS) structFields(pkgPath string, vType types.Struct) (extends []string, fields []tsField) {
+
+	for _, field := range vType.Fields {
+
+		jsonTags := field.Tags["json"]
+		name, embedded := field.Name, field.Name == ""
+		if len(jsonTags) > 0 && jsonTags[0] == "-" && len(jsonTags) == 1 {
+			continue
+		}
+		if len(jsonTags) > 0 && jsonTags[0] != "" {
+			name, embedded = jsonTags[0], false
+		}
+		if len(jsonTags) > 1 && jsonTags[1] == "inline" {
+			embedded = true
+		}
+		if embedded {
+			embedType := field.Type
+			if pointer, isPointer := embedType.(types.TPointer); isPointer {
+				embedType = pointer.Next
+			}
+			if typeName := ts.walkVariable(pkgPath, embedType); ts.structs[typeName] {
+				extends = append(extends, typeName)
+				continue
+			}
+			if name == "" {
+				typeName := types.TypeName(embedType)
+				if typeName == nil {
+					continue
+				}
+				name = *typeName
+			}
+		} else if field.Name[:1] != strings.ToUpper(field.Name[:1]) {
+			continue
+		}
+		fields = append(fields, tsField{name: name, typeName: ts.walkVariable(pkgPath, field.Type), optional: !isRequired(field.Type, jsonTags, tags.ParseTags(field.Docs))})
+	}
+	return
+}
+
+func (ts *clientTS) interfaceDecl(name string, extends []string, fields []tsField) string {
+
+	if len(extends) == 0 && len(fields) == 0 {
+		return fmt.Sprintf("\nexport type %s = Record<string, never>;\n", name)
+	}
+	decl := fmt.Sprintf("\nexport interface %s ", name)
+	if len(extends) != 0 {
+		decl += "extends " + strings.Join(extends, ", ") + " "
+	}
+	return decl + tsObject(fields) + "\n"
+}
+
+func (ts *clientTS) typeNames() (names []string) {
+
+	for name := range ts.owners {
+		names = append(names, name)
+	}
+	for _, name := range ts.serviceKeys() {
+		for _, method := range ts.services[name].clientMethods() {
+			names = append(names, ts.requestName(method), ts.responseName(method))
+		}
+	}
+	sort.Strings(names)
+	return
+}
+
+func (ts *clientTS) renderErrors() []byte {
+
+	var tsFile bytesWriter
+	tsFile.add("// %s\n", doNotEdit)
+	tsFile.WriteString(tsErrorsBase)
+
+	errs := ts.clientErrors()
+	for _, err := range errs {
+		tsFile.add("\nexport class %s extends JSONRPCError {\n", err.className)
+		tsFile.add("  constructor(message: string, data?: unknown) {\n")
+		tsFile.add("    super(message, %d, data);\n", err.code)
+		tsFile.add("    this.name = %q;\n", err.className)
+		tsFile.add("  }\n")
+		tsFile.add("}\n")
+	}
+	tsFile.add("\nconst errorClasses: Record<number, new (message: string, data?: unknown) => JSONRPCError> = {\n")
+	for _, err := range errs {
+		tsFile.add("  [%d]: %s,\n", err.code, err.className)
+	}
+	tsFile.add("};\n\n")
+	tsFile.add("/**\n * Converts error object of JSON-RPC response to instance of error class by its code.\n */\n")
+	tsFile.add("export function convertError(error: JSONRPCErrorObject): JSONRPCError {\n")
+	tsFile.add("  const errorClass = errorClasses[error.code];\n")
+	tsFile.add("  return errorClass ? new errorClass(error.message, error.data) : new JSONRPCError(error.message, error.code, error.data);\n")
+	tsFile.add("}\n")
+	return tsFile.Bytes()
+}
+
+func (ts *clientTS) renderPackage(outDir string) []byte {
+
+	name := ts.pkgName
+	if name == "" {
+		name = npmPackageName(outDir)
+	}
+	return []byte(fmt.Sprintf(tsPackage, name, ts.tags.Value("version", "0.0.1"), strconv.Quote(ts.tags.Value("description", ""))))
+}
+
+func tsObject(fields []tsField) string {
+
+	if len(fields) == 0 {
+		return "{}"
+	}
+	object := "{\n"
+	for _, field := range fields {
+		object += "  " + field.property() + "\n"
+	}
+	return object + "}"
+}
+
+func (field tsField) property() string {
+
+	optional := ""
+	if field.optional {
+		optional = "?"
+	}
+	return fmt.Sprintf("%s%s: %s;", tsPropertyName(field.name), optional, field.typeName)
+}
+
+func tsLiteral(properties []string) string {
+	if len(properties) == 0 {
+		return "{}"
+	}
+	return "{ " + strings.Join(properties, ", ") + " }"
+}
+
+func tsEnum(name string, values []enumValue) string {
+
+	var literals []string
+	decl := fmt.Sprintf("\nexport const %s = {\n", name)
+	for _, value := range values {
+		key := strings.TrimPrefix(value.Name, name)
+		if key == "" || !tsIdentifier.MatchString(key) {
+			key = value.Name
+		}
+		if value.Desc != "" {
+			decl += fmt.Sprintf("  /** %s */\n", strings.ReplaceAll(value.Desc, "*/", "* /"))
+		}
+		decl += fmt.Sprintf("  %s: %s,\n", key, value.literal())
+		literals = append(literals, value.literal())
+	}
+	decl += "} as const;\n\n"
+	return decl + fmt.Sprintf("export type %s = %s;\n", name, strings.Join(literals, " | "))
+}
+
+func tsPropertyName(name string) string {
+	if tsIdentifier.MatchString(name) {
+		return name
+	}
+	return strconv.Quote(name)
+}
+
+func tagOmitEmpty(jsonTags []string) bool {
+	for i := 1; i < len(jsonTags); i++ {
+		if jsonTags[i] == "omitempty" {
+			return true
+		}
+	}
+	return false
+}
+
+func castTypeTs(originName string) (typeName string, found bool) {
+
+	switch originName {
+	case "time.Time":
+		return "string", true
+	case "[]byte":
+		return "string | null", true
+	case "time.Duration":
+		return "number", true
+	case "json.RawMessage":
+		return "unknown", true
+	}
+	if strings.HasSuffix(originName, "UUID") {
+		return "string", true
+	}
+	if strings.HasSuffix(originName, "Decimal") {
+		return "number", true
+	}
+	return
+}
+
+func castBuiltinTs(originName string) string {
+
+	switch originName {
+	case "bool":
+		return "boolean"
+	case "string", "error":
+		return "string"
+	case "complex64", "complex128", "any":
+		return "unknown"
+	}
+	return "number"
+}
+
+const tsPackage = `{
+  "name": %q,
+  "version": %q,
+  "description": %s,
+  "type": "module",
+  "main": "./dist/index.js",
+  "types": "./dist/index.d.ts",
+  "exports": {
+    ".": {
+      "types": "./dist/index.d.ts",
+      "import": "./dist/index.js"
+    }
+  },
+  "files": [
+    "dist",
+    "src"
+  ],
+  "scripts": {
+    "build": "tsc -p .",
+    "prepare": "tsc -p ."
+  },
+  "devDependencies": {
+    "typescript": "^5.4.0"
+  }
+}
+`
+
+const tsConfig = `{
+  "compilerOptions": {
+    "target": "ES2020",
+    "module": "NodeNext",
+    "moduleResolution": "NodeNext",
+    "lib": ["ES2020", "DOM"],
+    "strict": true,
+    "declaration": true,
+    "skipLibCheck": true,
+    "rootDir": "src",
+    "outDir": "dist"
+  },
+  "include": ["src"]
+}
+`
@@ -0,0 +1,76 @@
+package generator
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestClientsNullableCollectionsAndServiceErrors(t *testing.T) {
+
+	modDir := newTestModule(t, map[string]string{
+		"service/service.go": `package service
+
+import "context"
+
+// @tg jsonRPC-server log
+// @tg jsonRPC-errors=-32001|userNotFound
+type Users interface {
+	List(ctx context.Context, filter map[string]string) (ids []int, key [4]byte, err error)
+}
+`,
+	})
+	tr := newTestTransport(t, filepath.Join(modDir, "service"))
+	if err := tr.RenderClientTS(filepath.Join(modDir, "ts")); err != nil {
+		t.Fatal(err)
+	}
+	if err := tr.RenderClientPython(filepath.Join(modDir, "python")); err != nil {
+		t.Fatal(err)
+	}
+	assertContains(t, readTestFile(t, filepath.Join(modDir, "ts", "src", "types.ts")),
+		`filter: Record<string, string> | null;`,
+		`ids: Array<number> | null;`,
+		`key: Array<number>;`,
+	)
+	assertContains(t, readTestFile(t, filepath.Join(modDir, "ts", "src", "errors.ts")),
+		`UserNotFoundError`,
+	)
+	assertContains(t, readTestFile(t, filepath.Join(modDir, "python", "python_client", "models.py")),
+		`filter: Optional[Dict[str, str]]`,
+		`ids: Optional[List[int]]`,
+		`key: List[int]`,
+	)
+	assertContains(t, readTestFile(t, filepath.Join(modDir, "python", "python_client", "errors.py")),
+		`UserNotFoundError`,
+	)
+	assertNotContains(t, readTestFile(t, filepath.Join(modDir, "ts", "src", "client.ts")), "%!")
+}
+
+func TestClientsJSAndTSLayout(t *testing.T) {
+
+	modDir := newTestModule(t, map[string]string{"service/service.go": testRESTService})
+	tr := newTestTransport(t, filepath.Join(modDir, "service"))
+	outDir := filepath.Join(modDir, "web")
+	if err := tr.RenderClientsJS(outDir, true, true); err != nil {
+		t.Fatal(err)
+	}
+	assertContains(t, readTestFile(t, filepath.Join(outDir, "js", "package.json")),
+		`"name": "web-client-js"`,
+		`"import": "./jsonrpc-client.js"`,
+	)
+	readTestFile(t, filepath.Join(outDir, "js", "jsonrpc-client.cjs"))
+	assertContains(t, readTestFile(t, filepath.Join(outDir, "ts", "package.json")),
+		`"name": "web-client-ts"`,
+		`"build": "tsc -p ."`,
+	)
+	readTestFile(t, filepath.Join(outDir, "ts", "src", "client.ts"))
+	readTestFile(t, filepath.Join(outDir, "ts", "tsconfig.json"))
+
+	onlyTS := filepath.Join(modDir, "api")
+	if err := tr.RenderClientsJS(onlyTS, false, true); err != nil {
+		t.Fatal(err)
+	}
+	assertContains(t, readTestFile(t, filepath.Join(onlyTS, "package.json")),
+		`"name": "api-client"`,
+		`"build": "tsc -p ."`,
+	)
+}
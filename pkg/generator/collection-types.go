@@ -0,0 +1,104 @@
+package generator
+
+const postmanSchema = "https://schema.getpostman.com/json/collection/v2.1.0/collection.json"
+
+type pmCollection struct {
+	Info     pmInfo       `json:"info"`
+	Item     []pmItem     `json:"item"`
+	Variable []pmVariable `json:"variable,omitempty"`
+}
+
+type pmInfo struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version,omitempty"`
+	Schema      string `json:"schema"`
+}
+
+type pmItem struct {
+	Name    string     `json:"name"`
+	Item    []pmItem   `json:"item,omitempty"`
+	Request *pmRequest `json:"request,omitempty"`
+}
+
+type pmRequest struct {
+	Method      string     `json:"method"`
+	Header      []pmHeader `json:"header"`
+	Body        *pmBody    `json:"body,omitempty"`
+	URL         pmURL      `json:"url"`
+	Description string     `json:"description,omitempty"`
+}
+
+type pmHeader struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type pmBody struct {
+	Mode     string         `json:"mode"`
+	Raw      string         `json:"raw,omitempty"`
+	FormData []pmFormParam  `json:"formdata,omitempty"`
+	Options  *pmBodyOptions `json:"options,omitempty"`
+}
+
+type pmBodyOptions struct {
+	Raw pmRawOptions `json:"raw"`
+}
+
+type pmRawOptions struct {
+	Language string `json:"language"`
+}
+
+type pmFormParam struct {
+	Key  string `json:"key"`
+	Type string `json:"type"`
+	Src  string `json:"src"`
+}
+
+type pmURL struct {
+	Raw      string       `json:"raw"`
+	Host     []string     `json:"host"`
+	Path     []string     `json:"path,omitempty"`
+	Query    []pmVariable `json:"query,omitempty"`
+	Variable []pmVariable `json:"variable,omitempty"`
+}
+
+type pmVariable struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type insExport struct {
+	Type      string        `json:"_type"`
+	Format    int           `json:"__export_format"`
+	Source    string        `json:"__export_source"`
+	Resources []insResource `json:"resources"`
+}
+
+type insResource struct {
+	ID          string            `json:"_id"`
+	Type        string            `json:"_type"`
+	ParentID    *string           `json:"parentId"`
+	Name        string            `json:"name"`
+	Description string            `json:"description,omitempty"`
+	Scope       string            `json:"scope,omitempty"`
+	Data        map[string]string `json:"data,omitempty"`
+	Method      string            `json:"method,omitempty"`
+	URL         string            `json:"url,omitempty"`
+	Body        *insBody          `json:"body,omitempty"`
+	Headers     []insParam        `json:"headers,omitempty"`
+	Parameters  []insParam        `json:"parameters,omitempty"`
+}
+
+type insBody struct {
+	MimeType string     `json:"mimeType"`
+	Text     string     `json:"text,omitempty"`
+	Params   []insParam `json:"params,omitempty"`
+}
+
+type insParam struct {
+	Name     string `json:"name"`
+	Value    string `json:"value,omitempty"`
+	Type     string `json:"type,omitempty"`
+	FileName string `json:"fileName,omitempty"`
+}
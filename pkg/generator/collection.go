@@ -0,0 +1,457 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/vetcher/go-astra/types"
+
+	"github.com/tundrik/tg/v2/pkg/utils"
+)
+
+const (
+	formatPostman  = "postman"
+	formatInsomnia = "insomnia"
+	formatHTTP     = "http"
+)
+
+type collection struct {
+	*Transport
+	doc *swagger
+}
+
+type collectionRequest struct {
+	name    string
+	summary string
+	desc    string
+	method  string
+	path    []string
+	vars    []collectionParam
+	query   []collectionParam
+	headers []collectionParam
+	cookies []collectionParam
+	uploads []string
+	body    interface{}
+}
+
+type collectionParam struct {
+	name     string
+	variable string
+	value    string
+	example  bool
+}
+
+type jsonrpcEnvelope struct {
+	ID      int         `json:"id"`
+	JsonRPC string      `json:"jsonrpc"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params"`
+}
+
+func (tr Transport) RenderCollection(outDir string, formats ...string) (err error) {
+	return newCollection(&tr).render(outDir, formats...)
+}
+
+func newCollection(tr *Transport) (c *collection) {
+	c = &collection{
+		Transport: tr,
+		doc:       newSwagger(tr),
+	}
+	return
+}
+
+func (c *collection) render(outDir string, formats ...string) (err error) {
+
+	if err = os.MkdirAll(outDir, 0777); err != nil {
+		return
+	}
+	if len(formats) == 0 {
+		formats = []string{formatPostman, formatInsomnia, formatHTTP}
+	}
+	requests := make(map[string][]collectionRequest)
+	for _, serviceName := range c.serviceKeys() {
+		svc := c.services[serviceName]
+		for _, method := range svc.methods {
+			if method.isJsonRPC() {
+				requests[svc.Name] = append(requests[svc.Name], c.jsonrpcRequest(svc, method))
+			} else if method.isHTTP() {
+				requests[svc.Name] = append(requests[svc.Name], c.restRequest(svc, method))
+			}
+		}
+	}
+	for _, format := range formats {
+		switch format {
+		case formatPostman:
+			err = c.writeJSON(path.Join(outDir, "postman_collection.json"), c.postman(requests))
+		case formatInsomnia:
+			err = c.writeJSON(path.Join(outDir, "insomnia.json"), c.insomnia(requests))
+		case formatHTTP:
+			for _, serviceName := range c.serviceKeys() {
+				if svcRequests := requests[serviceName]; len(svcRequests) != 0 {
+					if err = ioutil.WriteFile(path.Join(outDir, c.services[serviceName].lcName()+".http"), c.httpFile(svcRequests), 0600); err != nil {
+						return
+					}
+				}
+			}
+		default:
+			err = fmt.Errorf("unknown collection format '%s'", format)
+		}
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (c *collection) writeJSON(filePath string, value interface{}) (err error) {
+
+	var data []byte
+	if data, err = json.MarshalIndent(value, "", "  "); err != nil {
+		return
+	}
+	c.log.Info("write to ", filePath)
+	return ioutil.WriteFile(filePath, data, 0600)
+}
+
+func (c *collection) baseURL() string {
+
+	if servers := c.tags.Value("servers"); servers != "" {
+		return strings.Split(strings.Split(servers, "|")[0], ";")[0]
+	}
+	return "http://localhost:9000"
+}
+
+func (c *collection) newRequest(method *method) collectionRequest {
+
+	request := collectionRequest{
+		name:    method.Name,
+		summary: method.tags.Value(tagSummary),
+		desc:    method.tags.Value(tagDesc),
+	}
+	for _, argName := range sortedKeys(method.varHeaderMap()) {
+		if arg := method.argByName(argName); arg != nil {
+			request.headers = append(request.headers, c.param(method, arg, method.varHeaderMap()[argName]))
+		}
+	}
+	for _, argName := range sortedKeys(method.argCookieMap()) {
+		if arg := method.argByName(argName); arg != nil {
+			request.cookies = append(request.cookies, c.param(method, arg, method.argCookieMap()[argName]))
+		}
+	}
+	return request
+}
+
+func (c *collection) jsonrpcRequest(svc *service, method *method) (request collectionRequest) {
+
+	request = c.newRequest(method)
+	request.method = "POST"
+	request.path = strings.Split(strings.Trim(method.jsonrpcPath(), "/"), "/")
+	request.body = jsonrpcEnvelope{
+		ID:      1,
+		JsonRPC: "2.0",
+		Method:  method.jsonrpcName(),
+		Params:  c.bodyExample(svc, method, method.clientRequestFields()),
+	}
+	return
+}
+
+func (c *collection) restRequest(svc *service, method *method) (request collectionRequest) {
+
+	request = c.newRequest(method)
+	request.method = strings.ToUpper(method.httpMethod())
+	for _, token := range strings.Split(strings.Trim(method.httpPath(), "/"), "/") {
+		request.path = append(request.path, token)
+		if !strings.HasPrefix(token, ":") {
+			continue
+		}
+		if arg := method.argByName(strings.TrimPrefix(token, ":")); arg != nil {
+			request.vars = append(request.vars, c.param(method, arg, strings.TrimPrefix(token, ":")))
+		}
+	}
+	for _, argName := range sortedKeys(method.argParamMap()) {
+		if arg := method.argByName(argName); arg != nil {
+			request.query = append(request.query, c.param(method, arg, method.argParamMap()[argName]))
+		}
+	}
+	if uploads := method.uploadVarsMap(); len(uploads) != 0 {
+		for _, argName := range sortedKeys(uploads) {
+			request.uploads = append(request.uploads, uploads[argName])
+		}
+		return
+	}
+	if args := method.arguments(); len(args) != 0 {
+		request.body = c.bodyExample(svc, method, args)
+	}
+	return
+}
+
+func (c *collection) param(method *method, arg *types.Variable, name string) (param collectionParam) {
+
+	param.name = name
+	param.variable = utils.ToLowerCamel(arg.Name)
+	if example, found := exampleValue(method.tags.Sub(arg.Name)); found {
+		param.value = fmt.Sprint(example)
+		param.example = true
+	}
+	return
+}
+
+func (c *collection) bodyExample(svc *service, method *method, fields []types.StructField) map[string]interface{} {
+
+	body := make(map[string]interface{})
+	for _, field := range fields {
+		fieldTags := method.tags.Sub(field.Name)
+		if example, found := exampleValue(fieldTags); found {
+			body[fieldJsonName(field)] = example
+			continue
+		}
+		body[fieldJsonName(field)] = c.sample(c.doc.walkVariable(field.Name, svc.pkgPath, field.Type, fieldTags), 0)
+	}
+	return body
+}
+
+// sample builds example value by schema, examples of fields are used where present
+func (c *collection) sample(schema swSchema, depth int) interface{} {
+
+	if schema.Example != nil {
+		return schema.Example
+	}
+	if schema.Ref != "" {
+		if depth > 5 {
+			return nil
+		}
+		return c.sample(c.doc.schemas[strings.TrimPrefix(schema.Ref, "#/components/schemas/")], depth+1)
+	}
+	if len(schema.Enum) != 0 {
+		return schema.Enum[0]
+	}
+	switch schema.Type {
+	case "string":
+		switch schema.Format {
+		case "date-time":
+			return "2006-01-02T15:04:05Z"
+		case "uuid":
+			return "00000000-0000-0000-0000-000000000000"
+		}
+		return ""
+	case "integer", "number":
+		return 0
+	case "boolean":
+		return false
+	case "array":
+		if schema.Items == nil || depth > 5 {
+			return []interface{}{}
+		}
+		return []interface{}{c.sample(*schema.Items, depth+1)}
+	case "object":
+		object := make(map[string]interface{})
+		if depth > 5 {
+			return object
+		}
+		for name, property := range schema.Properties {
+			object[name] = c.sample(property, depth+1)
+		}
+		return object
+	}
+	return nil
+}
+
+// valueOr returns example value of parameter or placeholder if there is no example
+func (p collectionParam) valueOr(placeholder func(name string) string) string {
+
+	if p.example {
+		return p.value
+	}
+	return placeholder(p.variable)
+}
+
+func (r collectionRequest) title() string {
+
+	if r.summary != "" {
+		return r.name + " - " + r.summary
+	}
+	return r.name
+}
+
+func (r collectionRequest) bodyText() string {
+
+	data, _ := json.MarshalIndent(r.body, "", "  ")
+	return string(data)
+}
+
+// url renders request URL, placeholder renders value of parameter without example
+func (r collectionRequest) url(baseURL string, placeholder func(name string) string) string {
+
+	var elements []string
+	for _, token := range r.path {
+		for _, param := range r.vars {
+			if token == ":"+param.name {
+				token = param.valueOr(placeholder)
+			}
+		}
+		elements = append(elements, token)
+	}
+	url := baseURL + "/" + strings.Join(elements, "/")
+	var query []string
+	for _, param := range r.query {
+		query = append(query, param.name+"="+param.valueOr(placeholder))
+	}
+	if len(query) != 0 {
+		url += "?" + strings.Join(query, "&")
+	}
+	return url
+}
+
+func (r collectionRequest) cookie(placeholder func(name string) string) string {
+
+	var cookies []string
+	for _, param := range r.cookies {
+		cookies = append(cookies, param.name+"="+param.valueOr(placeholder))
+	}
+	return strings.Join(cookies, "; ")
+}
+
+func (c *collection) postman(requests map[string][]collectionRequest) (doc pmCollection) {
+
+	placeholder := func(name string) string { return "{{" + name + "}}" }
+	doc.Info = pmInfo{
+		Name:        c.tags.Value("title", "API"),
+		Description: c.tags.Value("description"),
+		Version:     c.tags.Value("version"),
+		Schema:      postmanSchema,
+	}
+	doc.Variable = []pmVariable{{Key: "baseUrl", Value: c.baseURL()}}
+	for _, serviceName := range c.serviceKeys() {
+		svcRequests := requests[serviceName]
+		if len(svcRequests) == 0 {
+			continue
+		}
+		folder := pmItem{Name: serviceName}
+		for _, request := range svcRequests {
+			pmReq := &pmRequest{
+				Method:      request.method,
+				Header:      []pmHeader{},
+				Description: strings.TrimSpace(request.summary + "\n\n" + request.desc),
+				URL: pmURL{
+					Raw:  "{{baseUrl}}/" + strings.Join(request.path, "/"),
+					Host: []string{"{{baseUrl}}"},
+					Path: request.path,
+				},
+			}
+			for _, param := range request.vars {
+				pmReq.URL.Variable = append(pmReq.URL.Variable, pmVariable{Key: param.name, Value: param.valueOr(placeholder)})
+			}
+			var query []string
+			for _, param := range request.query {
+				pmReq.URL.Query = append(pmReq.URL.Query, pmVariable{Key: param.name, Value: param.valueOr(placeholder)})
+				query = append(query, param.name+"="+param.valueOr(placeholder))
+			}
+			if len(query) != 0 {
+				pmReq.URL.Raw += "?" + strings.Join(query, "&")
+			}
+			for _, param := range request.headers {
+				pmReq.Header = append(pmReq.Header, pmHeader{Key: param.name, Value: param.valueOr(placeholder)})
+			}
+			if len(request.cookies) != 0 {
+				pmReq.Header = append(pmReq.Header, pmHeader{Key: "Cookie", Value: request.cookie(placeholder)})
+			}
+			if len(request.uploads) != 0 {
+				pmReq.Body = &pmBody{Mode: "formdata"}
+				for _, upload := range request.uploads {
+					pmReq.Body.FormData = append(pmReq.Body.FormData, pmFormParam{Key: upload, Type: "file"})
+				}
+			} else if request.body != nil {
+				pmReq.Header = append(pmReq.Header, pmHeader{Key: "Content-Type", Value: contentJSON})
+				pmReq.Body = &pmBody{Mode: "raw", Raw: request.bodyText(), Options: &pmBodyOptions{Raw: pmRawOptions{Language: "json"}}}
+			}
+			folder.Item = append(folder.Item, pmItem{Name: request.title(), Request: pmReq})
+		}
+		doc.Item = append(doc.Item, folder)
+	}
+	return
+}
+
+func (c *collection) insomnia(requests map[string][]collectionRequest) (doc insExport) {
+
+	placeholder := func(name string) string { return "{{ _." + name + " }}" }
+	workspaceID := "wrk_tg"
+	doc = insExport{Type: "export", Format: 4, Source: "tg"}
+	doc.Resources = append(doc.Resources,
+		insResource{ID: workspaceID, Type: "workspace", Name: c.tags.Value("title", "API"), Description: c.tags.Value("description"), Scope: "collection"},
+		insResource{ID: "env_tg", Type: "environment", ParentID: &workspaceID, Name: "Base Environment", Data: map[string]string{"baseUrl": c.baseURL()}},
+	)
+	for _, serviceName := range c.serviceKeys() {
+		svcRequests := requests[serviceName]
+		if len(svcRequests) == 0 {
+			continue
+		}
+		folderID := "fld_" + strings.ToLower(serviceName)
+		doc.Resources = append(doc.Resources, insResource{ID: folderID, Type: "request_group", ParentID: &workspaceID, Name: serviceName})
+		for _, request := range svcRequests {
+			insReq := insResource{
+				ID:          "req_" + strings.ToLower(serviceName+"_"+request.name),
+				Type:        "request",
+				ParentID:    &folderID,
+				Name:        request.title(),
+				Description: request.desc,
+				Method:      request.method,
+				URL:         request.url("{{ _.baseUrl }}", placeholder),
+			}
+			for _, param := range request.headers {
+				insReq.Headers = append(insReq.Headers, insParam{Name: param.name, Value: param.valueOr(placeholder)})
+			}
+			if len(request.cookies) != 0 {
+				insReq.Headers = append(insReq.Headers, insParam{Name: "Cookie", Value: request.cookie(placeholder)})
+			}
+			if len(request.uploads) != 0 {
+				insReq.Body = &insBody{MimeType: contentMultipart}
+				for _, upload := range request.uploads {
+					insReq.Body.Params = append(insReq.Body.Params, insParam{Name: upload, Type: "file"})
+				}
+			} else if request.body != nil {
+				insReq.Headers = append(insReq.Headers, insParam{Name: "Content-Type", Value: contentJSON})
+				insReq.Body = &insBody{MimeType: contentJSON, Text: request.bodyText()}
+			}
+			doc.Resources = append(doc.Resources, insReq)
+		}
+	}
+	return
+}
+
+func (c *collection) httpFile(requests []collectionRequest) []byte {
+
+	placeholder := func(name string) string { return "{{" + name + "}}" }
+
+	var httpFile bytesWriter
+	httpFile.add("# %s\n\n", doNotEdit)
+	httpFile.add("@baseUrl = %s\n", c.baseURL())
+	for _, request := range requests {
+		httpFile.add("\n### %s\n", request.title())
+		httpFile.add("# @name %s\n", request.name)
+		httpFile.add("%s %s\n", request.method, request.url("{{baseUrl}}", placeholder))
+		for _, param := range request.headers {
+			httpFile.add("%s: %s\n", param.name, param.valueOr(placeholder))
+		}
+		if len(request.cookies) != 0 {
+			httpFile.add("Cookie: %s\n", request.cookie(placeholder))
+		}
+		if len(request.uploads) != 0 {
+			httpFile.add("Content-Type: %s; boundary=boundary\n\n", contentMultipart)
+			for _, upload := range request.uploads {
+				httpFile.add("--boundary\n")
+				httpFile.add("Content-Disposition: form-data; name=%q; filename=%q\n\n", upload, upload)
+				httpFile.add("< ./%s\n", upload)
+			}
+			httpFile.add("--boundary--\n")
+		} else if request.body != nil {
+			httpFile.add("Content-Type: %s\n\n", contentJSON)
+			httpFile.WriteString(request.bodyText())
+			httpFile.Line()
+		}
+	}
+	return httpFile.Bytes()
+}
@@ -0,0 +1,37 @@
+package generator
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestCollectionJsonRPCMethodNames(t *testing.T) {
+
+	modDir := newTestModule(t, map[string]string{
+		"service/service.go": `package service
+
+import "context"
+
+// @tg jsonRPC-server log
+// @tg jsonRPC-namespace=accounts
+type Users interface {
+	// @tg jsonRPC-name=fetch
+	Get(ctx context.Context, id int) (name string, err error)
+}
+`,
+	})
+	tr := newTestTransport(t, filepath.Join(modDir, "service"))
+	if err := tr.RenderCollection(filepath.Join(modDir, "collection"), formatHTTP); err != nil {
+		t.Fatal(err)
+	}
+	if err := tr.RenderSwagger(filepath.Join(modDir, "swagger.json"), ""); err != nil {
+		t.Fatal(err)
+	}
+	assertContains(t, readTestFile(t, filepath.Join(modDir, "collection", "users.http")),
+		`POST {{baseUrl}}/accounts/fetch`,
+		`"method": "accounts.fetch"`,
+	)
+	assertContains(t, readTestFile(t, filepath.Join(modDir, "swagger.json")),
+		`\"method\": \"accounts.fetch\"`,
+	)
+}
@@ -8,12 +8,20 @@ const (
 	packageIO                    = "io"
 	_ctx_                        = "ctx"
 	packageFmt                   = "fmt"
+	packageFlag                  = "flag"
 	packageTime                  = "time"
 	_next_                       = "next"
 	packageSync                  = "sync"
 	packageTesting               = "testing"
 	packageReflect               = "reflect"
 	packageHttp                  = "net/http"
+	packageRand                  = "math/rand"
+	packageErrors                = "errors"
+	packageNet                   = "net"
+	packageBytes                 = "bytes"
+	packageTLS                   = "crypto/tls"
+	packageHttpTest              = "net/http/httptest"
+	packageURL                   = "net/url"
 	packageContext               = "context"
 	packageStrconv               = "strconv"
 	packageStrings               = "strings"
@@ -22,6 +30,7 @@ const (
 	packageCors                  = "github.com/lab259/cors"
 	packageUUID                  = "github.com/google/uuid"
 	packageFiber                 = "github.com/gofiber/fiber/v2"
+	packageFastHttp              = "github.com/valyala/fasthttp"
 	packageZeroLog               = "github.com/rs/zerolog"
 	packageJson                  = "github.com/seniorGolang/json"
 	packageGoKitMetrics          = "github.com/go-kit/kit/metrics"
@@ -43,99 +52,1011 @@ const (
 )
 
 const jsonRPCClientBase = `
-export class JSONRPCError extends Error {
+class JSONRPCError extends Error {
 	constructor(message, name, code, data) {
-	  	super(message);
-	  	this.name = name;
-	  	this.code = code;
+		super(message);
+		this.name = name;
+		this.code = code;
 		this.data = data;
 	}
 }
 
+class HTTPError extends Error {
+	constructor(status, body) {
+		super(httpErrorMessage(status, body));
+		this.name = "HTTPError";
+		this.status = status;
+		this.body = body;
+	}
+}
+
+function httpErrorMessage(status, body) {
+	try {
+		const message = JSON.parse(body);
+		if (typeof message === "string" && message !== "") {
+			return message;
+		}
+	} catch (e) {
+		// body is not JSON string
+	}
+	return "http status " + status + ": " + body;
+}
+
+/**
+ * Converts error object of JSON-RPC response to JSONRPCError, name of error is decoded from its code.
+ *
+ * @param {{code: number, message: string, data: *}} error
+ * @returns {JSONRPCError}
+ */
+function convertError(error) {
+	return new JSONRPCError(error.message, errorNames[error.code] || "UnknownError", error.code, error.data);
+}
+
+function abortError(signal) {
+	if (signal.reason !== undefined) {
+		return signal.reason;
+	}
+	const error = new Error("This operation was aborted");
+	error.name = "AbortError";
+	return error;
+}
+
+function splitOptions(values) {
+	const last = values[values.length - 1];
+	if (last && typeof AbortSignal !== "undefined" && last.signal instanceof AbortSignal) {
+		return [values.slice(0, -1), last];
+	}
+	return [values, {}];
+}
+
+/**
+ * Transport over fetch API, JSON-RPC calls are posted to url, REST methods are sent to the same origin.
+ * Fetch implementation may be replaced (for example in tests).
+ *
+ * @param {string} url
+ * @param {{fetch: Function}} options
+ */
+function fetchTransport(url, { fetch = globalThis.fetch } = {}) {
+	return {
+		url,
+		async doRequest(requests, request, signal) {
+			const response = await fetch(url, {
+				method: "POST",
+				headers: { "Content-Type": "application/json", ...request.headers },
+				body: request.body,
+				signal,
+			});
+			const text = await response.text();
+			if (!response.ok) {
+				throw new HTTPError(response.status, text);
+			}
+			try {
+				return JSON.parse(text);
+			} catch (e) {
+				throw new JSONRPCError("invalid JSON-RPC response: " + e.message, "ParseError", -32700, text);
+			}
+		},
+		doHTTP(request, signal) {
+			return fetch(request.url, { method: request.method, headers: request.headers, body: request.body, signal });
+		},
+	};
+}
+
 class JSONRPCScheduler {
 	/**
+	 * @param {string|{doRequest: Function, doHTTP: Function, url: string}} transport URL or transport
+	 * @param {{batchWindow: number, maxBatchSize: number, fetch: Function}} options
+	 */
+	constructor(transport, { batchWindow = 0, maxBatchSize = defaultMaxBatchSize, fetch } = {}) {
+		this._transport = typeof transport === "string" ? fetchTransport(transport, { fetch }) : transport;
+		this._batchWindow = batchWindow;
+		this._maxBatchSize = maxBatchSize;
+		this._requestID = 0;
+		this._pending = [];
+		this._commitTimerID = null;
+		this._beforeRequest = [];
+	}
+	/**
+	 * Adds hooks called in order before every request is sent. Hook may return promise,
+	 * it receives JSON-RPC requests (empty for REST) and outgoing request with headers and body.
 	 *
-	 * @param {*} transport
+	 * @param {...function(Array<Object>, {headers: Object<string, string>, body: *}): (void|Promise<void>)} hooks
 	 */
-	constructor(transport) {
-	  this._transport = transport;
-	  this._requestID = 0;
-	  this._scheduleRequests = {};
-	  this._commitTimerID = null;
-	  this._beforeRequest = null;
+	beforeRequest(...hooks) {
+		this._beforeRequest.push(...hooks);
 	}
-	beforeRequest(fn) {
-	  this._beforeRequest = fn;
-	} 
-	__scheduleCommit() {
-	  if (this._commitTimerID) {
-		clearTimeout(this._commitTimerID);
-	  }
-	  this._commitTimerID = setTimeout(() => {
-		this._commitTimerID = null;
-		const scheduleRequests = { ...this._scheduleRequests };
-		this._scheduleRequests = {};
-		let requests = [];
-		for (let key in scheduleRequests) {
-		  requests.push(scheduleRequests[key].request);
-		}
-		this.__doRequest(requests)
-		  .then((responses) => {
-			for (let i = 0; i < responses.length; i++) {
-              const schedule = scheduleRequests[responses[i].id];
-			  if (responses[i].error) {
-				schedule.reject(responses[i].error);
-				continue;
-			  }
-			  schedule.resolve(responses[i].result);
+	/**
+	 * Schedules JSON-RPC call. Calls made within batch window (ms) are sent in batches of maxBatchSize (0 means unlimited),
+	 * methods, which are not allowed in batch by server, are sent alone.
+	 *
+	 * @param {string} method
+	 * @param {Object} params
+	 * @param {{signal: AbortSignal}} options
+	 * @param {boolean} batch
+	 * @returns {Promise<*>}
+	 */
+	__scheduleRequest(method, params, { signal } = {}, batch = true) {
+		return new Promise((resolve, reject) => {
+			if (signal && signal.aborted) {
+				reject(abortError(signal));
+				return;
+			}
+			const call = this.__newCall(this.makeJSONRPCRequest(this.__requestIDGenerate(), method, params), resolve, reject, signal);
+			if (!batch) {
+				this.__flush([call], false);
+				return;
+			}
+			this._pending.push(call);
+			if (this._maxBatchSize > 0 && this._pending.length >= this._maxBatchSize) {
+				this.__commit();
+				return;
+			}
+			if (this._commitTimerID === null) {
+				this._commitTimerID = setTimeout(() => this.__commit(), this._batchWindow);
 			}
-		  })
-         .catch((e) => {
-           for (let key in requests) {
-             if (!requests.hasOwnProperty(key)) {
-               continue;
-             }
-             if (scheduleRequests.hasOwnProperty(requests[key].id)) {
-               scheduleRequests[requests[key].id].reject(e)
-             }
-           }
-         });
-	  }, 0);
+		});
 	}
-	makeJSONRPCRequest(id, method, params) {
-	  return {
-		jsonrpc: "2.0",
-		id: id,
-		method: method,
-		params: params,
-	  };
+	/**
+	 * Sends REST request through transport, rejects with HTTPError on unsuccessful status code.
+	 *
+	 * @param {{method: string, url: string, headers: Object<string, string>, body: *}} request
+	 * @param {{signal: AbortSignal}} options
+	 * @returns {Promise<Response>}
+	 */
+	async __send(request, { signal } = {}) {
+		if (!this._transport.doHTTP) {
+			throw new Error("transport does not support REST requests");
+		}
+		for (const hook of this._beforeRequest) {
+			await hook([], request);
+		}
+		const response = await this._transport.doHTTP(request, signal);
+		if (response.status < 200 || response.status >= 300) {
+			throw new HTTPError(response.status, await response.text());
+		}
+		return response;
 	}
 	/**
-    * @param {string} method
-    * @param {Object} params
-    * @returns {Promise<*>}
-    */
-	__scheduleRequest(method, params) {
-	  const p = new Promise((resolve, reject) => {
-		const request = this.makeJSONRPCRequest(
-		  this.__requestIDGenerate(),
-		  method,
-		  params
-		);
-		this._scheduleRequests[request.id] = {
-		  request,
-		  resolve,
-		  reject,
+	 * Builds URL of REST method, path of method is joined with path of transport URL.
+	 *
+	 * @param {string} path
+	 * @param {URLSearchParams} query
+	 * @returns {string}
+	 */
+	restURL(path, query) {
+		const base = new URL(this._transport.url, globalThis.location && globalThis.location.href);
+		const url = base.origin + base.pathname.replace(/\/$/, "") + path;
+		const search = query ? query.toString() : "";
+		return search ? url + "?" + search : url;
+	}
+	makeJSONRPCRequest(id, method, params) {
+		return {
+			jsonrpc: "2.0",
+			id: id,
+			method: method,
+			params: params,
 		};
-	  });
-	  this.__scheduleCommit();
-	  return p;
 	}
-	__doRequest(request) {
-	  return this._transport.doRequest(request);
+	__newCall(request, resolve, reject, signal) {
+		const call = { request, settled: false, flight: null };
+		const settle = (fn) => (value) => {
+			if (call.settled) {
+				return;
+			}
+			call.settled = true;
+			if (signal) {
+				signal.removeEventListener("abort", onAbort);
+			}
+			fn(value);
+		};
+		const onAbort = () => {
+			call.reject(abortError(signal));
+			this._pending = this._pending.filter((pending) => pending !== call);
+			if (call.flight && call.flight.calls.every((sent) => sent.settled)) {
+				call.flight.controller.abort();
+			}
+		};
+		call.resolve = settle(resolve);
+		call.reject = settle(reject);
+		if (signal) {
+			signal.addEventListener("abort", onAbort);
+		}
+		return call;
+	}
+	__commit() {
+		if (this._commitTimerID !== null) {
+			clearTimeout(this._commitTimerID);
+			this._commitTimerID = null;
+		}
+		const calls = this._pending;
+		const size = this._maxBatchSize > 0 ? this._maxBatchSize : calls.length;
+		this._pending = [];
+		for (let i = 0; i < calls.length; i += size) {
+			this.__flush(calls.slice(i, i + size), true);
+		}
+	}
+	async __flush(calls, batch) {
+		const flight = { controller: new AbortController(), calls };
+		for (const call of calls) {
+			call.flight = flight;
+		}
+		const requests = calls.map((call) => call.request);
+		try {
+			const request = { headers: {}, body: JSON.stringify(batch ? requests : requests[0]) };
+			for (const hook of this._beforeRequest) {
+				await hook(requests, request);
+			}
+			const body = await this._transport.doRequest(requests, request, flight.controller.signal);
+			if (typeof body !== "object" || body === null) {
+				throw new JSONRPCError("invalid JSON-RPC response", "ParseError", -32700, body);
+			}
+			const responses = new Map((Array.isArray(body) ? body : [body]).map((item) => [item.id, item]));
+			for (const call of calls) {
+				const response = responses.get(call.request.id) || responses.get(null);
+				if (!response) {
+					call.reject(new JSONRPCError("response for call " + call.request.id + " is missing", "InternalError", -32603));
+				} else if (response.error) {
+					call.reject(convertError(response.error));
+				} else {
+					call.resolve(response.result);
+				}
+			}
+		} catch (e) {
+			for (const call of calls) {
+				call.reject(e);
+			}
+		}
 	}
 	__requestIDGenerate() {
-	  return ++this._requestID;
+		return ++this._requestID;
 	}
- }
+}
+
+/**
+ * @param {string} token
+ * @returns {function(): Promise<{type: string, value: string}>}
+ */
+function staticToken(token) {
+	return () => Promise.resolve({ type: "Bearer", value: token });
+}
+
+/**
+ * OAuth2 client credentials token source, token is cached until it expires.
+ *
+ * @param {{tokenURL: string, clientID: string, clientSecret: string, scopes: Array<string>, fetch: Function}} options
+ * @returns {function(): Promise<{type: string, value: string, expiry: number}>}
+ */
+function clientCredentials({ tokenURL, clientID, clientSecret, scopes = [], fetch = globalThis.fetch }) {
+	let token = null;
+	let pending = null;
+	return () => {
+		if (token && (!token.expiry || token.expiry - Date.now() > 10000)) {
+			return Promise.resolve(token);
+		}
+		if (!pending) {
+			const form = new URLSearchParams({ grant_type: "client_credentials" });
+			if (scopes.length) {
+				form.set("scope", scopes.join(" "));
+			}
+			const credentials = btoa(encodeURIComponent(clientID) + ":" + encodeURIComponent(clientSecret));
+			pending = fetch(tokenURL, {
+				method: "POST",
+				headers: { "Content-Type": "application/x-www-form-urlencoded", Authorization: "Basic " + credentials },
+				body: form.toString(),
+			})
+				.then(async (response) => {
+					if (!response.ok) {
+						throw new Error("token endpoint status " + response.status + ": " + (await response.text()));
+					}
+					const data = await response.json();
+					if (!data.access_token) {
+						throw new Error("token endpoint returned empty access token");
+					}
+					token = {
+						type: !data.token_type || data.token_type.toLowerCase() === "bearer" ? "Bearer" : data.token_type,
+						value: data.access_token,
+						expiry: data.expires_in > 0 ? Date.now() + data.expires_in * 1000 : 0,
+					};
+					return token;
+				})
+				.finally(() => {
+					pending = null;
+				});
+		}
+		return pending;
+	};
+}
+
+/**
+ * Hook, which sets Authorization header from token source.
+ *
+ * @param {function(): Promise<{type: string, value: string}>} tokenSource
+ */
+function bearerAuth(tokenSource) {
+	return async (requests, request) => {
+		const token = await tokenSource();
+		request.headers["Authorization"] = token.type + " " + token.value;
+	};
+}
+
+/**
+ * Hook, which sets header to hex encoded HMAC-SHA256 of request body, multipart body is signed as empty.
+ *
+ * @param {string} secret
+ * @param {string} header
+ */
+function hmacSigner(secret, header = "X-Signature") {
+	const encoder = new TextEncoder();
+	const key = globalThis.crypto.subtle.importKey("raw", encoder.encode(secret), { name: "HMAC", hash: "SHA-256" }, false, ["sign"]);
+	return async (requests, request) => {
+		const body = typeof request.body === "string" ? request.body : "";
+		const signature = await globalThis.crypto.subtle.sign("HMAC", await key, encoder.encode(body));
+		request.headers[header] = Array.from(new Uint8Array(signature), (b) => b.toString(16).padStart(2, "0")).join("");
+	};
+}
+`
+
+const tsClientBase = `
+export interface HTTPRequest {
+  method: string;
+  url: string;
+  headers: Record<string, string>;
+  body?: string | FormData;
+}
+
+export interface HTTPResponse {
+  readonly status: number;
+  readonly headers: { get(name: string): string | null };
+  text(): Promise<string>;
+  blob(): Promise<Blob>;
+}
+
+export interface Transport {
+  doRequest(request: HTTPRequest): Promise<HTTPResponse>;
+}
+
+/**
+ * Transport over fetch API, fetch implementation may be replaced (for example in tests).
+ */
+export function fetchTransport(fetchFn: typeof fetch = globalThis.fetch): Transport {
+  return {
+    doRequest: (request) => fetchFn(request.url, { method: request.method, headers: request.headers, body: request.body }),
+  };
+}
+
+/**
+ * Hook called in order before every request is sent, it may change headers and body.
+ */
+export type RequestHook = (request: HTTPRequest) => void | Promise<void>;
+
+export interface Token {
+  type: string;
+  value: string;
+  expiry?: number;
+}
+
+export type TokenSource = () => Promise<Token>;
+
+export function staticToken(token: string): TokenSource {
+  return () => Promise.resolve({ type: "Bearer", value: token });
+}
+
+export interface ClientCredentialsOptions {
+  tokenURL: string;
+  clientID: string;
+  clientSecret: string;
+  scopes?: string[];
+  fetch?: typeof fetch;
+}
+
+/**
+ * OAuth2 client credentials token source, token is cached until it expires.
+ */
+export function clientCredentials({ tokenURL, clientID, clientSecret, scopes = [], fetch = globalThis.fetch }: ClientCredentialsOptions): TokenSource {
+  let token: Token | null = null;
+  let pending: Promise<Token> | null = null;
+  return () => {
+    if (token && (!token.expiry || token.expiry - Date.now() > 10000)) {
+      return Promise.resolve(token);
+    }
+    if (!pending) {
+      const form = new URLSearchParams({ grant_type: "client_credentials" });
+      if (scopes.length) {
+        form.set("scope", scopes.join(" "));
+      }
+      const credentials = btoa(encodeURIComponent(clientID) + ":" + encodeURIComponent(clientSecret));
+      pending = fetch(tokenURL, {
+        method: "POST",
+        headers: { "Content-Type": "application/x-www-form-urlencoded", Authorization: "Basic " + credentials },
+        body: form.toString(),
+      })
+        .then(async (response) => {
+          if (!response.ok) {
+            throw new Error("token endpoint status " + response.status + ": " + (await response.text()));
+          }
+          const data = await response.json();
+          if (!data.access_token) {
+            throw new Error("token endpoint returned empty access token");
+          }
+          token = {
+            type: !data.token_type || String(data.token_type).toLowerCase() === "bearer" ? "Bearer" : String(data.token_type),
+            value: String(data.access_token),
+            expiry: data.expires_in > 0 ? Date.now() + data.expires_in * 1000 : 0,
+          };
+          return token;
+        })
+        .finally(() => {
+          pending = null;
+        });
+    }
+    return pending;
+  };
+}
+
+/**
+ * Hook, which sets Authorization header from token source.
+ */
+export function bearerAuth(tokenSource: TokenSource): RequestHook {
+  return async (request) => {
+    const token = await tokenSource();
+    request.headers["Authorization"] = token.type + " " + token.value;
+  };
+}
+
+/**
+ * Hook, which sets header to hex encoded HMAC-SHA256 of request body, multipart body is signed as empty.
+ */
+export function hmacSigner(secret: string, header = "X-Signature"): RequestHook {
+  const encoder = new TextEncoder();
+  const key = globalThis.crypto.subtle.importKey("raw", encoder.encode(secret), { name: "HMAC", hash: "SHA-256" }, false, ["sign"]);
+  return async (request) => {
+    const body = typeof request.body === "string" ? request.body : "";
+    const signature = await globalThis.crypto.subtle.sign("HMAC", await key, encoder.encode(body));
+    request.headers[header] = Array.from(new Uint8Array(signature), (b) => b.toString(16).padStart(2, "0")).join("");
+  };
+}
+
+export interface JSONRPCRequest {
+  jsonrpc: "2.0";
+  id: number;
+  method: string;
+  params: unknown;
+}
+
+interface JSONRPCResponse {
+  jsonrpc: "2.0";
+  id: number | null;
+  result?: unknown;
+  error?: JSONRPCErrorObject;
+}
+
+interface PendingCall {
+  request: JSONRPCRequest;
+  resolve: (result: unknown) => void;
+  reject: (error: Error) => void;
+}
+
+export interface ClientOptions {
+  transport?: Transport;
+  maxBatchSize?: number;
+}
+
+export class BaseClient {
+  readonly url: string;
+  private readonly transport: Transport;
+  private readonly maxBatchSize: number;
+  private hooks: RequestHook[] = [];
+  private pending: PendingCall[] = [];
+  private timer: ReturnType<typeof setTimeout> | null = null;
+  private requestID = 0;
+
+  constructor(url: string, options: ClientOptions = {}) {
+    this.url = url;
+    this.transport = options.transport ?? fetchTransport();
+    this.maxBatchSize = options.maxBatchSize ?? defaultMaxBatchSize;
+  }
+
+  /**
+   * Adds hooks called in order before every request, for example bearerAuth or hmacSigner.
+   */
+  beforeRequest(...hooks: RequestHook[]): this {
+    this.hooks.push(...hooks);
+    return this;
+  }
+
+  /**
+   * Sends request through transport, rejects with HTTPError on unsuccessful status code.
+   */
+  async send(request: HTTPRequest): Promise<HTTPResponse> {
+    for (const hook of this.hooks) {
+      await hook(request);
+    }
+    const response = await this.transport.doRequest(request);
+    if (response.status < 200 || response.status >= 300) {
+      throw new HTTPError(response.status, await response.text());
+    }
+    return response;
+  }
+
+  /**
+   * Schedules JSON-RPC call, calls made in the same tick are sent in batches of maxBatchSize (0 means unlimited).
+   * Methods, which are not allowed in batch by server, are sent alone.
+   */
+  call<T>(method: string, params: unknown, batch = true): Promise<T> {
+    return new Promise<T>((resolve, reject) => {
+      const request: JSONRPCRequest = { jsonrpc: "2.0", id: ++this.requestID, method, params };
+      const call: PendingCall = { request, resolve: resolve as (result: unknown) => void, reject };
+      if (!batch) {
+        this.flush([call], false);
+        return;
+      }
+      this.pending.push(call);
+      if (this.timer === null) {
+        this.timer = setTimeout(() => this.commit(), 0);
+      }
+    });
+  }
+
+  /**
+   * Builds URL of REST method, path of method is joined with path of client URL.
+   */
+  restURL(path: string, query?: URLSearchParams): string {
+    const base = new URL(this.url, globalThis.location?.href);
+    const url = base.origin + base.pathname.replace(/\/$/, "") + path;
+    const search = query ? query.toString() : "";
+    return search ? url + "?" + search : url;
+  }
+
+  private commit(): void {
+    const calls = this.pending;
+    const size = this.maxBatchSize > 0 ? this.maxBatchSize : calls.length;
+    this.pending = [];
+    this.timer = null;
+    for (let i = 0; i < calls.length; i += size) {
+      this.flush(calls.slice(i, i + size), true);
+    }
+  }
+
+  private async flush(calls: PendingCall[], batch: boolean): Promise<void> {
+    const requests = calls.map((call) => call.request);
+    try {
+      const response = await this.send({
+        method: "POST",
+        url: this.url,
+        headers: { "Content-Type": "application/json" },
+        body: JSON.stringify(batch ? requests : requests[0]),
+      });
+      const body: JSONRPCResponse | JSONRPCResponse[] = JSON.parse(await response.text());
+      const responses = new Map((Array.isArray(body) ? body : [body]).map((item): [number | null, JSONRPCResponse] => [item.id, item]));
+      for (const call of calls) {
+        const result = responses.get(call.request.id) ?? responses.get(null);
+        if (!result) {
+          call.reject(convertError({ code: -32603, message: "response for call " + call.request.id + " is missing" }));
+        } else if (result.error) {
+          call.reject(convertError(result.error));
+        } else {
+          call.resolve(result.result);
+        }
+      }
+    } catch (e) {
+      for (const call of calls) {
+        call.reject(e instanceof Error ? e : new Error(String(e)));
+      }
+    }
+  }
+}
+`
+
+const tsErrorsBase = `
+export interface JSONRPCErrorObject {
+  code: number;
+  message: string;
+  data?: unknown;
+}
+
+export class JSONRPCError extends Error {
+  readonly code: number;
+  readonly data?: unknown;
+
+  constructor(message: string, code: number, data?: unknown) {
+    super(message);
+    this.name = "JSONRPCError";
+    this.code = code;
+    this.data = data;
+  }
+}
+
+export class HTTPError extends Error {
+  readonly status: number;
+  readonly body: string;
+
+  constructor(status: number, body: string) {
+    super(httpErrorMessage(status, body));
+    this.name = "HTTPError";
+    this.status = status;
+    this.body = body;
+  }
+}
+
+function httpErrorMessage(status: number, body: string): string {
+  try {
+    const message = JSON.parse(body);
+    if (typeof message === "string" && message !== "") {
+      return message;
+    }
+  } catch {
+    // body is not JSON string
+  }
+  return "http status " + status + ": " + body;
+}
+`
+
+const pyClientBase = `
+
+T = TypeVar("T")
+
+
+@dataclass
+class HTTPRequest:
+    method: str
+    url: str
+    headers: Dict[str, str] = field(default_factory=dict)
+    body: Optional[bytes] = None
+
+
+@dataclass
+class HTTPResponse:
+    status: int
+    headers: Mapping[str, str]
+    body: bytes
+
+    def header(self, name: str) -> str:
+        """Returns value of response header, name is case insensitive."""
+        for key, value in self.headers.items():
+            if key.lower() == name.lower():
+                return value
+        return ""
+
+
+class Transport(Protocol):
+    def do_request(self, request: HTTPRequest) -> HTTPResponse: ...
+
+
+class AsyncTransport(Protocol):
+    async def do_request(self, request: HTTPRequest) -> HTTPResponse: ...
+
+
+class UrllibTransport:
+    """Transport over urllib, timeout in seconds is applied to every request."""
+
+    def __init__(self, timeout: Optional[float] = None, context: Optional[ssl.SSLContext] = None) -> None:
+        self.timeout = timeout
+        self.context = context
+
+    def do_request(self, request: HTTPRequest) -> HTTPResponse:
+        http_request = urllib.request.Request(request.url, data=request.body, headers=request.headers, method=request.method)
+        options: Dict[str, Any] = {"context": self.context}
+        if self.timeout is not None:
+            options["timeout"] = self.timeout
+        try:
+            with urllib.request.urlopen(http_request, **options) as response:
+                return HTTPResponse(response.status, dict(response.headers.items()), response.read())
+        except urllib.error.HTTPError as e:
+            return HTTPResponse(e.code, dict(e.headers.items()), e.read())
+
+
+class ThreadTransport:
+    """Asyncio transport, which runs blocking transport in default executor of event loop."""
+
+    def __init__(self, transport: Optional[Transport] = None) -> None:
+        self.transport = transport or UrllibTransport()
+
+    async def do_request(self, request: HTTPRequest) -> HTTPResponse:
+        return await asyncio.get_running_loop().run_in_executor(None, self.transport.do_request, request)
+
+
+RequestHook = Callable[[HTTPRequest], None]
+
+
+@dataclass
+class Token:
+    type: str
+    value: str
+    expiry: float = 0.0
+
+
+TokenSource = Callable[[], Token]
+
+
+def static_token(token: str) -> TokenSource:
+    return lambda: Token("Bearer", token)
+
+
+def client_credentials(token_url: str, client_id: str, client_secret: str, scopes: Sequence[str] = (), transport: Optional[Transport] = None) -> TokenSource:
+    """OAuth2 client credentials token source, token is cached until it expires."""
+    lock = threading.Lock()
+    cached: List[Token] = []
+    token_transport = transport or UrllibTransport()
+
+    def source() -> Token:
+        with lock:
+            if cached and (not cached[0].expiry or cached[0].expiry - time.time() > 10):
+                return cached[0]
+            form = {"grant_type": "client_credentials"}
+            if scopes:
+                form["scope"] = " ".join(scopes)
+            credentials = urllib.parse.quote_plus(client_id) + ":" + urllib.parse.quote_plus(client_secret)
+            response = token_transport.do_request(HTTPRequest("POST", token_url, {
+                "Content-Type": "application/x-www-form-urlencoded",
+                "Authorization": "Basic " + base64.b64encode(credentials.encode()).decode(),
+            }, urllib.parse.urlencode(form).encode()))
+            if response.status < 200 or response.status >= 300:
+                raise HTTPError(response.status, response.body)
+            data = json.loads(response.body)
+            if not data.get("access_token"):
+                raise ValueError("token endpoint returned empty access token")
+            token_type = data.get("token_type") or "Bearer"
+            expires_in = data.get("expires_in") or 0
+            token = Token("Bearer" if token_type.lower() == "bearer" else token_type, data["access_token"], time.time() + expires_in if expires_in > 0 else 0.0)
+            cached[:] = [token]
+            return token
+
+    return source
+
+
+def bearer_auth(token_source: TokenSource) -> RequestHook:
+    """Hook, which sets Authorization header from token source."""
+
+    def hook(request: HTTPRequest) -> None:
+        token = token_source()
+        request.headers["Authorization"] = token.type + " " + token.value
+
+    return hook
+
+
+def hmac_signer(secret: Union[str, bytes], header: str = "X-Signature") -> RequestHook:
+    """Hook, which sets header to hex encoded HMAC-SHA256 of request body."""
+    key = secret.encode() if isinstance(secret, str) else secret
+
+    def hook(request: HTTPRequest) -> None:
+        request.headers[header] = hmac.new(key, request.body or b"", hashlib.sha256).hexdigest()
+
+    return hook
+
+
+def _json_default(value: Any) -> Any:
+    if isinstance(value, (bytes, bytearray)):
+        return base64.b64encode(value).decode()
+    if isinstance(value, enum.Enum):
+        return value.value
+    raise TypeError("object of type %s is not JSON serializable" % type(value).__name__)
+
+
+def _dumps(value: Any) -> bytes:
+    return json.dumps(value, default=_json_default).encode()
+
+
+def _param(value: Any) -> str:
+    if isinstance(value, bool):
+        return "true" if value else "false"
+    if isinstance(value, enum.Enum):
+        return str(value.value)
+    return str(value)
+
+
+def _multipart(files: Dict[str, bytes]) -> Tuple[str, bytes]:
+    boundary = uuid.uuid4().hex
+    body = b""
+    for name, content in files.items():
+        body += ("--%s\r\nContent-Disposition: form-data; name=\"%s\"; filename=\"%s\"\r\n" % (boundary, name, name)).encode()
+        body += b"Content-Type: application/octet-stream\r\n\r\n" + content + b"\r\n"
+    body += ("--%s--\r\n" % boundary).encode()
+    return "multipart/form-data; boundary=" + boundary, body
+
+
+class Call(Generic[T]):
+    """Call of batch, result is available after batch is executed."""
+
+    def __init__(self, request: Dict[str, Any]) -> None:
+        self.request = request
+        self._done = False
+        self._result: Any = None
+        self._error: Optional[BaseException] = None
+
+    def set_result(self, result: Any) -> None:
+        self._done, self._result = True, result
+
+    def set_exception(self, error: BaseException) -> None:
+        self._done, self._error = True, error
+
+    def done(self) -> bool:
+        return self._done
+
+    def result(self) -> T:
+        if not self._done:
+            raise RuntimeError("batch is not executed")
+        if self._error is not None:
+            raise self._error
+        return self._result
+
+
+class _Base:
+    def __init__(self, url: str, max_batch_size: int) -> None:
+        self.url = url
+        self.max_batch_size = max_batch_size
+        self._hooks: List[RequestHook] = []
+        self._request_id = itertools.count(1)
+
+    def before_request(self: "B", *hooks: RequestHook) -> "B":
+        """Adds hooks called in order before every request, for example bearer_auth or hmac_signer."""
+        self._hooks.extend(hooks)
+        return self
+
+    def rest_url(self, path: str, query: Sequence[Tuple[str, str]] = ()) -> str:
+        """Builds URL of REST method, path of method is joined with path of client URL."""
+        parts = urllib.parse.urlsplit(self.url)
+        url = parts.scheme + "://" + parts.netloc + parts.path.rstrip("/") + path
+        return url + "?" + urllib.parse.urlencode(query) if query else url
+
+    def _request(self, method: str, params: Any) -> Dict[str, Any]:
+        return {"jsonrpc": "2.0", "id": next(self._request_id), "method": method, "params": params}
+
+    def _prepare(self, request: HTTPRequest) -> HTTPRequest:
+        for hook in self._hooks:
+            hook(request)
+        return request
+
+    def _jsonrpc_request(self, requests: List[Dict[str, Any]], batch: bool) -> HTTPRequest:
+        return HTTPRequest("POST", self.url, {"Content-Type": "application/json"}, _dumps(requests if batch else requests[0]))
+
+    def _chunks(self, calls: List[Any]) -> List[List[Any]]:
+        size = self.max_batch_size if self.max_batch_size > 0 else max(len(calls), 1)
+        return [calls[i:i + size] for i in range(0, len(calls), size)]
+
+    @staticmethod
+    def _check(response: HTTPResponse) -> HTTPResponse:
+        if response.status < 200 or response.status >= 300:
+            raise HTTPError(response.status, response.body)
+        return response
+
+    @staticmethod
+    def _resolve(calls: Sequence[Tuple[Dict[str, Any], Any]], body: bytes) -> None:
+        data = json.loads(body)
+        responses = {item.get("id"): item for item in (data if isinstance(data, list) else [data])}
+        for request, sink in calls:
+            item = responses.get(request["id"], responses.get(None))
+            if sink.done():
+                continue
+            if item is None:
+                sink.set_exception(convert_error({"code": -32603, "message": "response for call %d is missing" % request["id"]}))
+            elif item.get("error"):
+                sink.set_exception(convert_error(item["error"]))
+            else:
+                sink.set_result(item.get("result"))
+
+
+B = TypeVar("B", bound=_Base)
+
+
+class BaseClient(_Base):
+    def __init__(self, url: str, transport: Optional[Transport] = None, max_batch_size: int = DEFAULT_MAX_BATCH_SIZE) -> None:
+        super().__init__(url, max_batch_size)
+        self.transport = transport or UrllibTransport()
+
+    def send(self, request: HTTPRequest) -> HTTPResponse:
+        """Sends request through transport, raises HTTPError on unsuccessful status code."""
+        return self._check(self.transport.do_request(self._prepare(request)))
+
+    def call(self, method: str, params: Any) -> Any:
+        """Sends single JSON-RPC call and returns its result."""
+        call: Call[Any] = Call(self._request(method, params))
+        self._send([call], False)
+        return call.result()
+
+    def _send(self, calls: List[Call[Any]], batch: bool) -> None:
+        try:
+            response = self.send(self._jsonrpc_request([call.request for call in calls], batch))
+            self._resolve([(call.request, call) for call in calls], response.body)
+        except Exception as e:
+            for call in calls:
+                if not call.done():
+                    call.set_exception(e)
+
+
+class BaseBatch:
+    """Collects calls and sends them in batches of client max_batch_size, on exit of with block if there was no error."""
+
+    def __init__(self, client: BaseClient) -> None:
+        self._client = client
+        self._calls: List[Call[Any]] = []
+
+    def add(self, method: str, params: Any) -> Call[Any]:
+        call: Call[Any] = Call(self._client._request(method, params))
+        self._calls.append(call)
+        return call
+
+    def execute(self) -> None:
+        calls, self._calls = self._calls, []
+        for chunk in self._client._chunks(calls):
+            self._client._send(chunk, True)
+
+    def __enter__(self: "BB") -> "BB":
+        return self
+
+    def __exit__(self, exc_type: Any, exc: Any, tb: Any) -> None:
+        if exc_type is None:
+            self.execute()
+
+
+BB = TypeVar("BB", bound=BaseBatch)
+
+
+class BaseAsyncClient(_Base):
+    """Asyncio client, JSON-RPC calls made in the same iteration of event loop are sent in batches."""
+
+    def __init__(self, url: str, transport: Optional[AsyncTransport] = None, max_batch_size: int = DEFAULT_MAX_BATCH_SIZE) -> None:
+        super().__init__(url, max_batch_size)
+        self.transport = transport or ThreadTransport()
+        self._pending: List[Tuple[Dict[str, Any], "asyncio.Future[Any]"]] = []
+        self._tasks: Set["asyncio.Task[None]"] = set()
+
+    async def send(self, request: HTTPRequest) -> HTTPResponse:
+        """Sends request through transport, raises HTTPError on unsuccessful status code."""
+        return self._check(await self.transport.do_request(self._prepare(request)))
+
+    async def call(self, method: str, params: Any, batch: bool = True) -> Any:
+        """Schedules JSON-RPC call, methods which are not allowed in batch by server are sent alone."""
+        loop = asyncio.get_running_loop()
+        future: "asyncio.Future[Any]" = loop.create_future()
+        request = self._request(method, params)
+        if not batch:
+            await self._flush([(request, future)], False)
+            return await future
+        if not self._pending:
+            loop.call_soon(self._commit)
+        self._pending.append((request, future))
+        return await future
+
+    def _commit(self) -> None:
+        calls, self._pending = self._pending, []
+        for chunk in self._chunks(calls):
+            task = asyncio.ensure_future(self._flush(chunk, True))
+            self._tasks.add(task)
+            task.add_done_callback(self._tasks.discard)
+
+    async def _flush(self, calls: List[Tuple[Dict[str, Any], "asyncio.Future[Any]"]], batch: bool) -> None:
+        try:
+            response = await self.send(self._jsonrpc_request([request for request, _ in calls], batch))
+            self._resolve(calls, response.body)
+        except Exception as e:
+            for _, future in calls:
+                if not future.done():
+                    future.set_exception(e)
+`
+
+const pyErrorsBase = `
+
+class HTTPError(Exception):
+    def __init__(self, status: int, body: bytes) -> None:
+        super().__init__(_http_error_message(status, body))
+        self.status = status
+        self.body = body
+
+
+def _http_error_message(status: int, body: bytes) -> str:
+    try:
+        message = json.loads(body)
+        if isinstance(message, str) and message:
+            return message
+    except ValueError:
+        pass
+    return "http status %d: %s" % (status, body.decode(errors="replace"))
+
+
+class JSONRPCError(Exception):
+    def __init__(self, message: str, code: int, data: Any = None) -> None:
+        super().__init__(message)
+        self.message = message
+        self.code = code
+        self.data = data
 `
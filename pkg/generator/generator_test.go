@@ -0,0 +1,166 @@
+package generator
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+const testModule = "example.com/tgtest"
+
+// testErrors are type checker errors, which do not depend on packages outside of test module
+var testErrors = regexp.MustCompile(`declared and not used|imported and not used|redeclared in this block|missing return|undefined: [A-Za-z_][A-Za-z0-9_]*$`)
+
+// newTestModule writes go.mod and files of test module to temporary directory and returns its path
+func newTestModule(t *testing.T, files map[string]string) (modDir string) {
+
+	t.Helper()
+	modDir = t.TempDir()
+	files["go.mod"] = "module " + testModule + "\n\ngo 1.22\n"
+	for fileName, content := range files {
+		filePath := filepath.Join(modDir, fileName)
+		if err := os.MkdirAll(filepath.Dir(filePath), 0777); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filePath, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return
+}
+
+// chdirTestModule makes test module current directory, packages of module are resolved relative to it
+func chdirTestModule(t *testing.T, modDir string) {
+
+	t.Helper()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = os.Chdir(modDir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(wd) })
+}
+
+func newTestTransport(t *testing.T, svcDir string) (tr Transport) {
+
+	t.Helper()
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+	var err error
+	if tr, err = NewTransport(log, svcDir); err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func readTestFile(t *testing.T, filePath string) string {
+
+	t.Helper()
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func assertContains(t *testing.T, text string, fragments ...string) {
+
+	t.Helper()
+	for _, fragment := range fragments {
+		if !strings.Contains(text, fragment) {
+			t.Errorf("%q not found in:\n%s", fragment, text)
+		}
+	}
+}
+
+func assertNotContains(t *testing.T, text string, fragments ...string) {
+
+	t.Helper()
+	for _, fragment := range fragments {
+		if strings.Contains(text, fragment) {
+			t.Errorf("%q found in:\n%s", fragment, text)
+		}
+	}
+}
+
+// typeCheck checks generated package, packages of test module are checked from sources,
+// other packages are replaced by empty ones and errors caused by them are ignored
+func typeCheck(t *testing.T, modDir, pkgDir string) {
+
+	t.Helper()
+	checker := &testImporter{modDir: modDir, fset: token.NewFileSet(), std: importer.Default(), packages: make(map[string]*types.Package)}
+	for _, err := range checker.check(pkgDir) {
+		t.Error(err)
+	}
+}
+
+type testImporter struct {
+	modDir   string
+	fset     *token.FileSet
+	std      types.Importer
+	packages map[string]*types.Package
+}
+
+func (imp *testImporter) Import(pkgPath string) (pkg *types.Package, err error) {
+
+	if pkg = imp.packages[pkgPath]; pkg != nil {
+		return
+	}
+	if strings.HasPrefix(pkgPath, testModule+"/") {
+		imp.check(filepath.Join(imp.modDir, strings.TrimPrefix(pkgPath, testModule+"/")))
+		return imp.packages[pkgPath], nil
+	}
+	if pkg, err = imp.std.Import(pkgPath); err != nil {
+		pkgName := path.Base(pkgPath)
+		if regexp.MustCompile(`^v[0-9]+$`).MatchString(pkgName) {
+			pkgName = path.Base(path.Dir(pkgPath))
+		}
+		pkg = types.NewPackage(pkgPath, strings.ReplaceAll(pkgName, "-", ""))
+		pkg.MarkComplete()
+	}
+	imp.packages[pkgPath] = pkg
+	return pkg, nil
+}
+
+func (imp *testImporter) check(pkgDir string) (errs []error) {
+
+	pkgPath := testModule + "/" + filepath.ToSlash(strings.TrimPrefix(strings.TrimPrefix(pkgDir, imp.modDir), string(filepath.Separator)))
+	entries, err := os.ReadDir(pkgDir)
+	if err != nil {
+		return []error{err}
+	}
+	var files []*ast.File
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".go") || strings.HasSuffix(entry.Name(), "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(imp.fset, filepath.Join(pkgDir, entry.Name()), nil, 0)
+		if err != nil {
+			return []error{err}
+		}
+		files = append(files, file)
+	}
+	conf := types.Config{
+		Importer: imp,
+		Error: func(err error) {
+			if testErrors.MatchString(err.Error()) {
+				errs = append(errs, err)
+			}
+		},
+	}
+	imp.packages[pkgPath], _ = conf.Check(pkgPath, imp.fset, files, nil)
+	return
+}
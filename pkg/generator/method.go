@@ -12,8 +12,8 @@ import (
 
 	. "github.com/dave/jennifer/jen"
 
-	"github.com/tundrik/tg/pkg/tags"
-	"github.com/tundrik/tg/pkg/utils"
+	"github.com/tundrik/tg/v2/pkg/tags"
+	"github.com/tundrik/tg/v2/pkg/utils"
 )
 
 type method struct {
@@ -63,6 +63,51 @@ func (m method) lccName() string {
 	return utils.ToLowerCamel(m.Name)
 }
 
+func (m method) jsonrpcName() string {
+	return m.svc.jsonrpcNamespace() + "." + m.jsonrpcLocalName()
+}
+
+func (m method) jsonrpcLocalName() string {
+	return strings.ToLower(m.tags.Value(tagRPCName, m.Name))
+}
+
+func (m method) jsonrpcAliases() (aliases []string) {
+
+	for _, alias := range strings.Split(m.tags.Value(tagRPCAliases), ",") {
+		if alias = strings.ToLower(strings.TrimSpace(alias)); alias == "" {
+			continue
+		}
+		if !strings.Contains(alias, ".") {
+			alias = m.svc.jsonrpcNamespace() + "." + alias
+		}
+		aliases = append(aliases, alias)
+	}
+	return
+}
+
+// jsonrpcLegacyName returns method name before renaming by jsonRPC-name or jsonRPC-namespace,
+// empty if method is not renamed or old name is already served as alias or by another method
+func (m method) jsonrpcLegacyName() string {
+
+	name := m.svc.lcName() + "." + m.lcName()
+	if name == m.jsonrpcName() {
+		return ""
+	}
+	for _, alias := range m.jsonrpcAliases() {
+		if alias == name {
+			return ""
+		}
+	}
+	for _, svc := range m.svc.tr.services {
+		for _, method := range svc.methods {
+			if method.isJsonRPC() && method.jsonrpcName() == name {
+				return ""
+			}
+		}
+	}
+	return name
+}
+
 func (m method) requestStructName() string {
 	return "request" + m.svc.Name + m.Name
 }
@@ -139,13 +184,44 @@ func (m method) httpPath(withoutPrefix ...bool) string {
 }
 
 func (m method) jsonrpcPath(withoutPrefix ...bool) string {
+
+	namespace, name := m.svc.lccName(), m.lccName()
+	if m.svc.tags.Contains(tagRPCNamespace) {
+		namespace = m.svc.jsonrpcNamespace()
+	}
+	if m.tags.Contains(tagRPCName) {
+		name = m.jsonrpcLocalName()
+	}
+	return m.jsonrpcRoute(formatPathURL(m.tags.Value(tagHttpPath, path.Join("/", namespace, name))), withoutPrefix...)
+}
+
+// jsonrpcPaths returns path of jsonRPC method, its path before renaming by jsonRPC-name or jsonRPC-namespace and paths of aliases
+func (m method) jsonrpcPaths(withoutPrefix ...bool) (paths []string) {
+
+	routes := []string{
+		m.jsonrpcPath(withoutPrefix...),
+		m.jsonrpcRoute(formatPathURL(m.tags.Value(tagHttpPath, path.Join("/", m.svc.lccName(), m.lccName()))), withoutPrefix...),
+	}
+	for _, alias := range m.jsonrpcAliases() {
+		routes = append(routes, m.jsonrpcRoute(path.Join("/", strings.ReplaceAll(alias, ".", "/")), withoutPrefix...))
+	}
+	known := make(map[string]bool)
+	for _, route := range routes {
+		if !known[route] {
+			known[route] = true
+			paths = append(paths, route)
+		}
+	}
+	return
+}
+
+func (m method) jsonrpcRoute(urlPath string, withoutPrefix ...bool) string {
 	var elements []string
 	if len(withoutPrefix) == 0 {
 		elements = append(elements, "/")
 	}
 	prefix := m.svc.tags.Value(tagHttpPrefix)
 	globalPrefix := m.svc.tr.tags.Value(tagHttpPrefix)
-	urlPath := formatPathURL(m.tags.Value(tagHttpPath, path.Join("/", m.svc.lccName(), m.lccName())))
 	return path.Join(append(elements, globalPrefix, prefix, urlPath)...)
 }
 
@@ -256,7 +332,9 @@ func (m method) results() (vars []types.StructField) {
 		_, inDownload := m.downloadVarsMap()[arg.Name]
 
 		if !inHeader && !inCookie && !inDownload {
-			arg.Tags = map[string][]string{"json": {arg.Name}}
+			if jsonTags, _ := arg.Tags["json"]; len(jsonTags) == 0 {
+				arg.Tags = map[string][]string{"json": {arg.Name}}
+			}
 			arg.Name = utils.ToCamel(arg.Name)
 			vars = append(vars, arg)
 		}
@@ -268,7 +346,7 @@ func (m method) urlArgs(errStatement func(arg, header string) *Statement) (g *St
 
 	return m.argFromString("urlParam", m.argPathMap(),
 		func(srcName string) Code {
-			return Id(_ctx_).Dot("Params").Call(Lit(srcName))
+			return Id("pathParam").Call(Id(_ctx_), Lit(srcName))
 		},
 		errStatement,
 	)
@@ -558,6 +636,10 @@ func (m method) argToTypeConverter(from *Statement, vType types.Type, id *Statem
 		return List(id, Err()).Op(op).Qual(packageStrconv, "ParseUint").Call(from, Lit(10), Lit(64)).Add(errStatement)
 	case "uint32":
 		return List(id, Err()).Op(op).Qual(packageStrconv, "ParseUint").Call(from, Lit(10), Lit(32)).Add(errStatement)
+	case "float64":
+		return List(id, Err()).Op(op).Qual(packageStrconv, "ParseFloat").Call(from, Lit(64)).Add(errStatement)
+	case "bool":
+		return List(id, Err()).Op(op).Qual(packageStrconv, "ParseBool").Call(from).Add(errStatement)
 	case "UUID":
 		return List(id, Id("_")).Op(op).Qual(uuidPackage, "Parse").Call(from)
 
@@ -0,0 +1,276 @@
+package generator
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+
+	. "github.com/dave/jennifer/jen"
+
+	"github.com/tundrik/tg/v2/pkg/utils"
+)
+
+func (tr Transport) RenderMocks(outDir, transportDir string) (err error) {
+
+	tr.cleanup(outDir)
+	if err = os.MkdirAll(outDir, 0777); err != nil {
+		return
+	}
+	var transportPkg string
+	transportDir, _ = filepath.Abs(transportDir)
+	if transportPkg, err = utils.GetPkgPath(transportDir, true); err != nil {
+		return
+	}
+	showError(tr.log, tr.renderMock(outDir), "renderMock")
+	showError(tr.log, tr.renderMockServer(outDir, transportPkg), "renderMockServer")
+	for _, serviceName := range tr.serviceKeys() {
+		svc := tr.services[serviceName]
+		showError(tr.log, svc.renderMock(outDir), "renderMock")
+	}
+	return
+}
+
+func (tr Transport) renderMock(outDir string) (err error) {
+
+	srcFile := newSrc(filepath.Base(outDir))
+	srcFile.PackageComment(doNotEdit)
+
+	srcFile.ImportName(packageFmt, "fmt")
+	srcFile.ImportName(packageSync, "sync")
+	srcFile.ImportName(packageReflect, "reflect")
+	srcFile.ImportName(packageStrings, "strings")
+
+	srcFile.Line().Comment("TestingT is the part of testing.TB used by mocks")
+	srcFile.Type().Id("TestingT").Interface(
+		Id("Helper").Params(),
+		Id("Errorf").Params(Id("format").String(), Id("args").Op("...").Interface()),
+		Id("Cleanup").Params(Func().Params()),
+	)
+
+	srcFile.Line().Comment("Matcher checks argument of the call")
+	srcFile.Type().Id("Matcher").Interface(
+		Id("Match").Params(Id("value").Interface()).Bool(),
+		Id("String").Params().String(),
+	)
+
+	srcFile.Line().Type().Id("matcher").Struct(
+		Id("desc").String(),
+		Id("match").Func().Params(Id("value").Interface()).Bool(),
+	)
+
+	srcFile.Line().Func().Params(Id("m").Id("matcher")).Id("Match").Params(Id("value").Interface()).Bool().Block(
+		Return(Id("m").Dot("match").Call(Id("value"))),
+	)
+
+	srcFile.Line().Func().Params(Id("m").Id("matcher")).Id("String").Params().String().Block(
+		Return(Id("m").Dot("desc")),
+	)
+
+	srcFile.Line().Comment("Any matches any value")
+	srcFile.Func().Id("Any").Params().Id("Matcher").Block(
+		Return(Id("matcher").Values(Dict{
+			Id("desc"):  Lit("any"),
+			Id("match"): Func().Params(Interface()).Bool().Block(Return(True())),
+		})),
+	)
+
+	srcFile.Line().Comment("Eq matches value deeply equal to expected")
+	srcFile.Func().Id("Eq").Params(Id("expected").Interface()).Id("Matcher").Block(
+		Return(Id("matcher").Values(Dict{
+			Id("desc"): Qual(packageFmt, "Sprintf").Call(Lit("%#v"), Id("expected")),
+			Id("match"): Func().Params(Id("value").Interface()).Bool().Block(
+				Return(Qual(packageReflect, "DeepEqual").Call(Id("expected"), Id("value"))),
+			),
+		})),
+	)
+
+	srcFile.Line().Comment("Nil matches nil value of any nillable type")
+	srcFile.Func().Id("Nil").Params().Id("Matcher").Block(
+		Return(Id("matcher").Values(Dict{
+			Id("desc"): Lit("nil"),
+			Id("match"): Func().Params(Id("value").Interface()).Bool().Block(
+				If(Id("value").Op("==").Nil()).Block(Return(True())),
+				Id("v").Op(":=").Qual(packageReflect, "ValueOf").Call(Id("value")),
+				Switch(Id("v").Dot("Kind").Call()).Block(
+					Case(
+						Qual(packageReflect, "Chan"), Qual(packageReflect, "Func"), Qual(packageReflect, "Interface"),
+						Qual(packageReflect, "Map"), Qual(packageReflect, "Ptr"), Qual(packageReflect, "Slice"),
+					).Block(Return(Id("v").Dot("IsNil").Call())),
+				),
+				Return(False()),
+			),
+		})),
+	)
+
+	srcFile.Line().Comment("Not matches value which is not matched by argument")
+	srcFile.Func().Id("Not").Params(Id("value").Interface()).Id("Matcher").Block(
+		Id("m").Op(":=").Id("toMatcher").Call(Id("value")),
+		Return(Id("matcher").Values(Dict{
+			Id("desc"): Lit("not(").Op("+").Id("m").Dot("String").Call().Op("+").Lit(")"),
+			Id("match"): Func().Params(Id("value").Interface()).Bool().Block(
+				Return(Op("!").Id("m").Dot("Match").Call(Id("value"))),
+			),
+		})),
+	)
+
+	srcFile.Line().Comment("Cond matches value by predicate")
+	srcFile.Func().Id("Cond").Params(Id("desc").String(), Id("predicate").Func().Params(Id("value").Interface()).Bool()).Id("Matcher").Block(
+		Return(Id("matcher").Values(Dict{
+			Id("desc"):  Id("desc"),
+			Id("match"): Id("predicate"),
+		})),
+	)
+
+	srcFile.Line().Func().Id("toMatcher").Params(Id("value").Interface()).Id("Matcher").Block(
+		If(List(Id("m"), Id("ok")).Op(":=").Id("value").Assert(Id("Matcher")).Op(";").Id("ok")).Block(
+			Return(Id("m")),
+		),
+		Return(Id("Eq").Call(Id("value"))),
+	)
+
+	srcFile.Line().Comment("Call is an expectation registered by Expect methods")
+	srcFile.Type().Id("Call").Struct(
+		Id("method").String(),
+		Id("args").Index().Id("Matcher"),
+		Id("minTimes").Int(),
+		Id("maxTimes").Int(),
+		Id("count").Int(),
+		Id("action").Interface(),
+	)
+
+	srcFile.Line().Func().Params(Id("c").Op("*").Id("Call")).Id("times").Params(List(Id("min"), Id("max")).Int()).Block(
+		List(Id("c").Dot("minTimes"), Id("c").Dot("maxTimes")).Op("=").List(Id("min"), Id("max")),
+	)
+
+	srcFile.Line().Func().Params(Id("c").Op("*").Id("Call")).Id("match").Params(Id("method").String(), Id("args").Index().Interface()).Bool().Block(
+		If(Id("c").Dot("method").Op("!=").Id("method").Op("||").Len(Id("c").Dot("args")).Op("!=").Len(Id("args"))).Block(
+			Return(False()),
+		),
+		For(List(Id("i"), Id("arg")).Op(":=").Range().Id("args")).Block(
+			If(Op("!").Id("c").Dot("args").Index(Id("i")).Dot("Match").Call(Id("arg"))).Block(
+				Return(False()),
+			),
+		),
+		Return(True()),
+	)
+
+	srcFile.Line().Func().Params(Id("c").Op("*").Id("Call")).Id("String").Params().String().Block(
+		Id("args").Op(":=").Make(Index().String(), Len(Id("c").Dot("args"))),
+		For(List(Id("i"), Id("arg")).Op(":=").Range().Id("c").Dot("args")).Block(
+			Id("args").Index(Id("i")).Op("=").Id("arg").Dot("String").Call(),
+		),
+		Return(Qual(packageFmt, "Sprintf").Call(Lit("%s(%s)"), Id("c").Dot("method"), Qual(packageStrings, "Join").Call(Id("args"), Lit(", ")))),
+	)
+
+	srcFile.Line().Comment("Mock records expectations and checks calls against them")
+	srcFile.Type().Id("Mock").Struct(
+		Id("t").Id("TestingT"),
+		Id("mutex").Qual(packageSync, "Mutex"),
+		Id("calls").Index().Op("*").Id("Call"),
+	)
+
+	srcFile.Line().Comment("NewMock creates mock which checks expectations on test cleanup")
+	srcFile.Func().Id("NewMock").Params(Id("t").Id("TestingT")).Params(Id("m").Op("*").Id("Mock")).Block(
+		Id("m").Op("=").Op("&").Id("Mock").Values(Dict{Id("t"): Id("t")}),
+		Id("t").Dot("Cleanup").Call(Id("m").Dot("AssertExpectations")),
+		Return(),
+	)
+
+	srcFile.Line().Comment("Expect registers call which is expected exactly once")
+	srcFile.Func().Params(Id("m").Op("*").Id("Mock")).Id("Expect").Params(Id("method").String(), Id("args").Op("...").Interface()).Params(Id("call").Op("*").Id("Call")).Block(
+		Id("call").Op("=").Op("&").Id("Call").Values(Dict{
+			Id("method"):   Id("method"),
+			Id("minTimes"): Lit(1),
+			Id("maxTimes"): Lit(1),
+		}),
+		For(List(Id("_"), Id("arg")).Op(":=").Range().Id("args")).Block(
+			Id("call").Dot("args").Op("=").Append(Id("call").Dot("args"), Id("toMatcher").Call(Id("arg"))),
+		),
+		Id("m").Dot("mutex").Dot("Lock").Call(),
+		Defer().Id("m").Dot("mutex").Dot("Unlock").Call(),
+		Id("m").Dot("calls").Op("=").Append(Id("m").Dot("calls"), Id("call")),
+		Return(),
+	)
+
+	srcFile.Line().Comment("Called returns first matching expectation which is not exhausted, nil if the call is unexpected")
+	srcFile.Func().Params(Id("m").Op("*").Id("Mock")).Id("Called").Params(Id("method").String(), Id("args").Op("...").Interface()).Params(Op("*").Id("Call")).Block(
+		Id("m").Dot("t").Dot("Helper").Call(),
+		Id("m").Dot("mutex").Dot("Lock").Call(),
+		Defer().Id("m").Dot("mutex").Dot("Unlock").Call(),
+		For(List(Id("_"), Id("call")).Op(":=").Range().Id("m").Dot("calls")).Block(
+			If(Id("call").Dot("match").Call(Id("method"), Id("args")).Op("&&").Parens(Id("call").Dot("maxTimes").Op("<").Lit(0).Op("||").Id("call").Dot("count").Op("<").Id("call").Dot("maxTimes"))).Block(
+				Id("call").Dot("count").Op("++"),
+				Return(Id("call")),
+			),
+		),
+		Id("m").Dot("t").Dot("Errorf").Call(Lit("unexpected call %s with arguments %v"), Id("method"), Id("args")),
+		Return(Nil()),
+	)
+
+	srcFile.Line().Comment("AssertExpectations fails the test if some expected calls were not made")
+	srcFile.Func().Params(Id("m").Op("*").Id("Mock")).Id("AssertExpectations").Params().Block(
+		Id("m").Dot("t").Dot("Helper").Call(),
+		Id("m").Dot("mutex").Dot("Lock").Call(),
+		Defer().Id("m").Dot("mutex").Dot("Unlock").Call(),
+		For(List(Id("_"), Id("call")).Op(":=").Range().Id("m").Dot("calls")).Block(
+			If(Id("call").Dot("count").Op("<").Id("call").Dot("minTimes")).Block(
+				Id("m").Dot("t").Dot("Errorf").Call(Lit("missing call %s: expected %d times, called %d"), Id("call"), Id("call").Dot("minTimes"), Id("call").Dot("count")),
+			),
+		),
+	)
+	return srcFile.Save(path.Join(outDir, "mock.go"))
+}
+
+func (tr Transport) renderMockServer(outDir, transportPkg string) (err error) {
+
+	srcFile := newSrc(filepath.Base(outDir))
+	srcFile.PackageComment(doNotEdit)
+
+	srcFile.ImportName(packageHttp, "http")
+	srcFile.ImportName(packageZeroLog, "zerolog")
+	srcFile.ImportName(transportPkg, filepath.Base(transportPkg))
+
+	srcFile.Line().Comment("URL is the base address of Server, requests to it are served in-process")
+	srcFile.Const().Id("URL").Op("=").Lit("http://mock")
+
+	srcFile.Line().Comment("Server mounts generated transport on top of mocks")
+	srcFile.Type().Id("Server").StructFunc(func(sg *Group) {
+		sg.Id("Transport").Op("*").Qual(transportPkg, "Server")
+		for _, serviceName := range tr.serviceKeys() {
+			sg.Id(serviceName).Op("*").Id(serviceName)
+		}
+	})
+
+	srcFile.Line().Comment("NewServer creates mocks and transport server, options are applied after services")
+	srcFile.Func().Id("NewServer").Params(Id("t").Id("TestingT"), Id("options").Op("...").Qual(transportPkg, "Option")).Params(Id("srv").Op("*").Id("Server")).Block(
+		Id("srv").Op("=").Op("&").Id("Server").Values(DictFunc(func(d Dict) {
+			for _, serviceName := range tr.serviceKeys() {
+				d[Id(serviceName)] = Id("New" + serviceName).Call(Id("t"))
+			}
+		})),
+		Id("log").Op(":=").Qual(packageZeroLog, "Nop").Call(),
+		Id("services").Op(":=").Index().Qual(transportPkg, "Option").ValuesFunc(func(vg *Group) {
+			for _, serviceName := range tr.serviceKeys() {
+				vg.Line().Qual(transportPkg, serviceName).Call(Qual(transportPkg, "New"+serviceName).Call(Id("log"), Id("srv").Dot(serviceName)))
+			}
+			vg.Line()
+		}),
+		Id("srv").Dot("Transport").Op("=").Qual(transportPkg, "New").Call(Id("log"), Append(Id("services"), Id("options").Op("...")).Op("...")),
+		Return(),
+	)
+
+	srcFile.Line().Comment("Do serves request by transport without network listener")
+	srcFile.Func().Params(Id("srv").Op("*").Id("Server")).Id("Do").Params(Id("request").Op("*").Qual(packageHttp, "Request")).Params(Op("*").Qual(packageHttp, "Response"), Error()).Block(
+		Return(Id("srv").Dot("Transport").Dot("Fiber").Call().Dot("Test").Call(Id("request"), Lit(-1))),
+	)
+
+	srcFile.Line().Func().Params(Id("srv").Op("*").Id("Server")).Id("RoundTrip").Params(Id("request").Op("*").Qual(packageHttp, "Request")).Params(Op("*").Qual(packageHttp, "Response"), Error()).Block(
+		Return(Id("srv").Dot("Do").Call(Id("request"))),
+	)
+
+	srcFile.Line().Comment("HTTPClient returns client which sends requests to Server in-process")
+	srcFile.Func().Params(Id("srv").Op("*").Id("Server")).Id("HTTPClient").Params().Params(Op("*").Qual(packageHttp, "Client")).Block(
+		Return(Op("&").Qual(packageHttp, "Client").Values(Dict{Id("Transport"): Id("srv")})),
+	)
+	return srcFile.Save(path.Join(outDir, "server.go"))
+}
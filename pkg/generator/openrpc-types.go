@@ -0,0 +1,68 @@
+package generator
+
+type orObject struct {
+	OpenRPC    string       `json:"openrpc" yaml:"openrpc"`
+	Info       swInfo       `json:"info" yaml:"info"`
+	Servers    []orServer   `json:"servers,omitempty" yaml:"servers,omitempty"`
+	Methods    []orMethod   `json:"methods" yaml:"methods"`
+	Components orComponents `json:"components,omitempty" yaml:"components,omitempty"`
+}
+
+type orServer struct {
+	Name        string `json:"name,omitempty" yaml:"name,omitempty"`
+	URL         string `json:"url" yaml:"url"`
+	Summary     string `json:"summary,omitempty" yaml:"summary,omitempty"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+type orMethod struct {
+	Name           string                `json:"name" yaml:"name"`
+	Tags           []orTag               `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Summary        string                `json:"summary,omitempty" yaml:"summary,omitempty"`
+	Description    string                `json:"description,omitempty" yaml:"description,omitempty"`
+	Params         []orContentDescriptor `json:"params" yaml:"params"`
+	Result         *orContentDescriptor  `json:"result,omitempty" yaml:"result,omitempty"`
+	Deprecated     bool                  `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
+	Errors         []orError             `json:"errors,omitempty" yaml:"errors,omitempty"`
+	ParamStructure string                `json:"paramStructure,omitempty" yaml:"paramStructure,omitempty"`
+	Examples       []orExamplePairing    `json:"examples,omitempty" yaml:"examples,omitempty"`
+}
+
+type orTag struct {
+	Name        string `json:"name" yaml:"name"`
+	Summary     string `json:"summary,omitempty" yaml:"summary,omitempty"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+type orContentDescriptor struct {
+	Name        string   `json:"name" yaml:"name"`
+	Summary     string   `json:"summary,omitempty" yaml:"summary,omitempty"`
+	Description string   `json:"description,omitempty" yaml:"description,omitempty"`
+	Required    bool     `json:"required,omitempty" yaml:"required,omitempty"`
+	Schema      swSchema `json:"schema" yaml:"schema"`
+	Deprecated  bool     `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
+}
+
+type orError struct {
+	Ref     string      `json:"$ref,omitempty" yaml:"$ref,omitempty"`
+	Code    int         `json:"code,omitempty" yaml:"code,omitempty"`
+	Message string      `json:"message,omitempty" yaml:"message,omitempty"`
+	Data    interface{} `json:"data,omitempty" yaml:"data,omitempty"`
+}
+
+type orExamplePairing struct {
+	Name        string      `json:"name" yaml:"name"`
+	Description string      `json:"description,omitempty" yaml:"description,omitempty"`
+	Params      []orExample `json:"params" yaml:"params"`
+	Result      *orExample  `json:"result,omitempty" yaml:"result,omitempty"`
+}
+
+type orExample struct {
+	Name  string      `json:"name" yaml:"name"`
+	Value interface{} `json:"value" yaml:"value"`
+}
+
+type orComponents struct {
+	Schemas swSchemas          `json:"schemas,omitempty" yaml:"schemas,omitempty"`
+	Errors  map[string]orError `json:"errors,omitempty" yaml:"errors,omitempty"`
+}
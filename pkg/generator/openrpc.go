@@ -0,0 +1,289 @@
+package generator
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/vetcher/go-astra/types"
+	"gopkg.in/yaml.v3"
+
+	"github.com/tundrik/tg/v2/pkg/tags"
+	"github.com/tundrik/tg/v2/pkg/utils"
+)
+
+const (
+	openrpcVersion    = "1.2.6"
+	openrpcMetaSchema = "https://raw.githubusercontent.com/open-rpc/meta-schema/master/schema.json"
+)
+
+var jsonrpcErrors = map[string]orError{
+	"parseError":          {Code: -32700, Message: "Parse error"},
+	"invalidRequestError": {Code: -32600, Message: "Invalid Request"},
+	"methodNotFoundError": {Code: -32601, Message: "Method not found"},
+	"invalidParamsError":  {Code: -32602, Message: "Invalid params"},
+	"internalError":       {Code: -32603, Message: "Internal error"},
+}
+
+type openrpc struct {
+	*swagger
+}
+
+func newOpenRPC(tr *Transport) (doc *openrpc) {
+	return &openrpc{swagger: newSwagger(tr)}
+}
+
+func (doc *openrpc) render(outFilePath string) (err error) {
+
+	if err = os.MkdirAll(filepath.Dir(outFilePath), 0777); err != nil {
+		return
+	}
+	var docData []byte
+	rpcDoc := doc.document()
+	switch strings.ToLower(filepath.Ext(outFilePath)) {
+	case ".yaml", ".yml":
+		if docData, err = yaml.Marshal(rpcDoc); err != nil {
+			return
+		}
+	default:
+		if docData, err = json.MarshalIndent(rpcDoc, " ", "    "); err != nil {
+			return
+		}
+	}
+	doc.log.Info("write to ", outFilePath)
+	return ioutil.WriteFile(outFilePath, docData, 0600)
+}
+
+func (doc *openrpc) document() (rpcDoc orObject) {
+
+	rpcDoc.OpenRPC = openrpcVersion
+	rpcDoc.Info.Title = doc.tags.Value("title")
+	rpcDoc.Info.Version = doc.tags.Value("version")
+	rpcDoc.Info.Description = doc.tags.Value("description")
+	rpcDoc.Methods = make([]orMethod, 0)
+
+	if servers := doc.tags.Value("servers"); servers != "" {
+		for _, tagServer := range strings.Split(servers, "|") {
+			var serverDesc string
+			serverValues := strings.Split(tagServer, ";")
+			if len(serverValues) > 1 {
+				serverDesc = serverValues[1]
+			}
+			serverURL := serverValues[0]
+			if prefix := doc.tags.Value(tagHttpPrefix); prefix != "" {
+				serverURL = strings.TrimSuffix(serverURL, "/") + path.Join("/", prefix)
+			}
+			rpcDoc.Servers = append(rpcDoc.Servers, orServer{Name: serverDesc, URL: serverURL})
+		}
+	}
+	for _, serviceName := range doc.serviceKeys() {
+		service := doc.services[serviceName]
+		if !service.isJsonRPC() {
+			continue
+		}
+		doc.log.WithField("module", "openrpc").Infof("service %s append jsonRPC methods", service.Name)
+		for _, method := range service.methods {
+			if !method.isJsonRPC() {
+				continue
+			}
+			rpcMethod := doc.method(method)
+			rpcDoc.Methods = append(rpcDoc.Methods, rpcMethod)
+			for _, alias := range method.jsonrpcAliases() {
+				aliasMethod := rpcMethod
+				aliasMethod.Name = alias
+				aliasMethod.Deprecated = true
+				aliasMethod.Description = "Deprecated alias of " + rpcMethod.Name
+				aliasMethod.Examples = nil
+				rpcDoc.Methods = append(rpcDoc.Methods, aliasMethod)
+			}
+		}
+	}
+	if doc.hasDiscover() {
+		rpcDoc.Methods = append(rpcDoc.Methods, orMethod{
+			Name:        "rpc.discover",
+			Description: "Returns an OpenRPC schema as a description of this service",
+			Params:      make([]orContentDescriptor, 0),
+			Result: &orContentDescriptor{
+				Name:   "OpenRPC Schema",
+				Schema: swSchema{Ref: openrpcMetaSchema},
+			},
+		})
+	}
+	rpcDoc.Components.Schemas = doc.schemas
+	rpcDoc.Components.Errors = jsonrpcErrors
+	return
+}
+
+func (doc *openrpc) method(method *method) (rpcMethod orMethod) {
+
+	svc := method.svc
+
+	rpcMethod = orMethod{
+		Name:           method.jsonrpcName(),
+		Summary:        method.tags.Value(tagSummary),
+		Description:    method.tags.Value(tagDesc),
+		Deprecated:     method.tags.Contains(tagDeprecated),
+		ParamStructure: "by-name",
+		Params:         make([]orContentDescriptor, 0),
+	}
+	serviceTags := strings.Split(svc.tags.Value(tagSwaggerTags, svc.Name), ",")
+	if method.tags.Contains(tagSwaggerTags) {
+		serviceTags = strings.Split(method.tags.Value(tagSwaggerTags), ",")
+	}
+	for _, tag := range serviceTags {
+		rpcMethod.Tags = append(rpcMethod.Tags, orTag{Name: strings.TrimSpace(tag)})
+	}
+
+	var examples []orExample
+	for _, arg := range method.arguments() {
+		argName := fieldJsonName(arg)
+		argTags := method.tags.Sub(arg.Name)
+		rpcMethod.Params = append(rpcMethod.Params, orContentDescriptor{
+			Name:        argName,
+			Description: argTags.Value(tagDesc),
+			Required:    isRequired(arg.Type, arg.Tags["json"], argTags),
+			Schema:      doc.walkVariable(arg.Name, svc.pkgPath, arg.Type, argTags),
+		})
+		if example, found := exampleValue(argTags); found {
+			examples = append(examples, orExample{Name: argName, Value: example})
+		}
+	}
+
+	doc.registerStruct(method.responseStructName(), svc.pkgPath, method.tags, method.results())
+	rpcMethod.Result = &orContentDescriptor{
+		Name:   "result",
+		Schema: swSchema{Ref: "#/components/schemas/" + method.responseStructName()},
+	}
+
+	if len(examples) != 0 {
+		pairing := orExamplePairing{Name: method.jsonrpcName(), Params: examples}
+		result := make(map[string]interface{})
+		for _, ret := range method.results() {
+			if example, found := exampleValue(method.tags.Sub(fieldJsonName(ret))); found {
+				result[fieldJsonName(ret)] = example
+			}
+		}
+		if len(result) != 0 {
+			pairing.Result = &orExample{Name: "result", Value: result}
+		}
+		rpcMethod.Examples = append(rpcMethod.Examples, pairing)
+	}
+
+	rpcMethod.Errors = []orError{
+		{Ref: "#/components/errors/parseError"},
+		{Ref: "#/components/errors/internalError"},
+	}
+	rpcMethod.Errors = append(rpcMethod.Errors, jsonrpcMethodErrors(method.errorTags())...)
+	return
+}
+
+// errorTags merges global, service and method tags, the most specific jsonRPC-errors wins
+func (m *method) errorTags() (errTags tags.DocTags) {
+	return errTags.Merge(m.svc.tr.tags).Merge(m.svc.tags).Merge(m.tags)
+}
+
+// jsonrpcMethodErrors collects application errors declared as `code|message` pairs.
+func jsonrpcMethodErrors(errTags tags.DocTags) (errs []orError) {
+
+	codes := make(map[int]string)
+	for _, pair := range strings.Split(errTags.Value(tagJsonRPCErrors), ",") {
+		if pairTokens := strings.Split(pair, "|"); len(pairTokens) == 2 {
+			code, err := strconv.Atoi(strings.TrimSpace(pairTokens[0]))
+			if err != nil {
+				continue
+			}
+			codes[code] = strings.TrimSpace(pairTokens[1])
+		}
+	}
+	for code, message := range codes {
+		errs = append(errs, orError{Code: code, Message: message})
+	}
+	sort.Slice(errs, func(i, j int) bool { return errs[i].Code > errs[j].Code })
+	return
+}
+
+type clientError struct {
+	code      int
+	className string
+}
+
+// clientErrors returns standard jsonRPC errors and errors declared by jsonRPC-errors with class names for clients
+func (tr Transport) clientErrors() (errs []clientError) {
+
+	errs = []clientError{
+		{code: -32700, className: "ParseError"},
+		{code: -32600, className: "InvalidRequestError"},
+		{code: -32601, className: "MethodNotFoundError"},
+		{code: -32602, className: "InvalidParamsError"},
+		{code: -32603, className: "InternalError"},
+	}
+	codes := make(map[int]bool)
+	classes := make(map[string]bool)
+	for _, err := range errs {
+		codes[err.code], classes[err.className] = true, true
+	}
+	for _, name := range tr.serviceKeys() {
+		for _, method := range tr.services[name].methods {
+			if !method.isJsonRPC() {
+				continue
+			}
+			for _, methodErr := range jsonrpcMethodErrors(method.errorTags()) {
+				if codes[methodErr.Code] {
+					continue
+				}
+				className := clientErrorName(methodErr.Code, methodErr.Message)
+				if classes[className] {
+					className += strconv.Itoa(abs(methodErr.Code))
+				}
+				codes[methodErr.Code], classes[className] = true, true
+				errs = append(errs, clientError{code: methodErr.Code, className: className})
+			}
+		}
+	}
+	return
+}
+
+// clientErrorName makes error class name from message, for example 'user not found' becomes UserNotFoundError
+func clientErrorName(code int, message string) (className string) {
+
+	className = utils.ToCamel(regexp.MustCompile(`[^A-Za-z0-9]+`).ReplaceAllString(message, " "))
+	if className == "" || className[0] >= '0' && className[0] <= '9' {
+		className = "Error" + strconv.Itoa(abs(code))
+	}
+	if !strings.HasSuffix(className, "Error") {
+		className += "Error"
+	}
+	return
+}
+
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
+
+func fieldJsonName(field types.StructField) string {
+
+	if tagValues := field.Tags["json"]; len(tagValues) > 0 && tagValues[0] != "" {
+		return tagValues[0]
+	}
+	return field.Name
+}
+
+func exampleValue(varTags tags.DocTags) (value interface{}, found bool) {
+
+	var example string
+	if example, found = varTags[tagExample]; !found {
+		return
+	}
+	value = example
+	_ = json.Unmarshal([]byte(example), &value)
+	return
+}
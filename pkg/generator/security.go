@@ -0,0 +1,173 @@
+package generator
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/tundrik/tg/v2/pkg/tags"
+)
+
+const (
+	securityBearer        = "bearer"
+	securityBasic         = "basic"
+	securityAPIKey        = "apiKey"
+	securityOAuth2        = "oauth2"
+	securityOpenIDConnect = "openIdConnect"
+	securityNone          = "none"
+
+	flowImplicit          = "implicit"
+	flowPassword          = "password"
+	flowClientCredentials = "clientCredentials"
+	flowAuthorizationCode = "authorizationCode"
+)
+
+type securityScheme struct {
+	name   string
+	kind   string
+	desc   string
+	format string
+	in     string
+	param  string
+	flow   string
+	url    string
+	token  string
+	scopes map[string]string
+}
+
+// securitySchemes returns schemes declared in package by `security.<name>=kind|args` tags
+func (tr *Transport) securitySchemes() (schemes []securityScheme) {
+
+	securityTags := tr.tags.Sub(tagSecurity)
+	var names []string
+	for key := range securityTags {
+		if !strings.Contains(key, ".") {
+			names = append(names, key)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		values := strings.Split(securityTags.Value(name), "|")
+		scheme := securityScheme{
+			name:   name,
+			kind:   values[0],
+			desc:   securityTags.Sub(name).Value(tagDesc),
+			scopes: make(map[string]string),
+		}
+		switch scheme.kind {
+		case securityBearer:
+			scheme.format = valueAt(values, 1)
+		case securityAPIKey:
+			scheme.in = valueAt(values, 1)
+			scheme.param = valueAt(values, 2)
+		case securityOAuth2:
+			scheme.flow = valueAt(values, 1)
+			if scheme.flow == flowPassword || scheme.flow == flowClientCredentials {
+				scheme.token = valueAt(values, 2)
+			} else {
+				scheme.url = valueAt(values, 2)
+				scheme.token = valueAt(values, 3)
+			}
+		case securityOpenIDConnect:
+			scheme.url = valueAt(values, 1)
+		case securityBasic:
+		default:
+			tr.log.WithField("scheme", name).Warnf("unknown security scheme type '%s'", scheme.kind)
+			continue
+		}
+		if scopes := securityTags.Sub(name).Value("scopes"); scopes != "" {
+			for _, scope := range strings.Split(scopes, "|") {
+				values := strings.SplitN(scope, ";", 2)
+				scheme.scopes[strings.TrimSpace(values[0])] = strings.TrimSpace(valueAt(values, 1))
+			}
+		}
+		schemes = append(schemes, scheme)
+	}
+	return
+}
+
+// securityRequirements parses `security` tag: alternatives are separated by '|', schemes required together by '+',
+// scopes follow name of scheme after ':' and are separated by space, 'none' clears requirements
+func securityRequirements(tagValues tags.DocTags) (requirements []swSecurityRequirement, found bool) {
+
+	if !tagValues.IsSet(tagSecurity) {
+		return nil, false
+	}
+	requirements = make([]swSecurityRequirement, 0)
+	value := strings.TrimSpace(tagValues.Value(tagSecurity))
+	if value == "" || value == securityNone {
+		return requirements, true
+	}
+	for _, alternative := range strings.Split(value, "|") {
+		requirement := make(swSecurityRequirement)
+		for _, scheme := range strings.Split(alternative, "+") {
+			values := strings.SplitN(strings.TrimSpace(scheme), ":", 2)
+			requirement[values[0]] = strings.Fields(valueAt(values, 1))
+		}
+		requirements = append(requirements, requirement)
+	}
+	return requirements, true
+}
+
+// security returns requirements of method, requirements of method override requirements of service
+func (m *method) security() (requirements []swSecurityRequirement, found bool) {
+
+	if requirements, found = securityRequirements(m.tags); found {
+		return
+	}
+	return securityRequirements(m.svc.tags)
+}
+
+func valueAt(values []string, i int) string {
+	if i < len(values) {
+		return strings.TrimSpace(values[i])
+	}
+	return ""
+}
+
+func (doc *swagger) securitySchemes() (schemes map[string]swSecurityScheme) {
+
+	for _, scheme := range doc.Transport.securitySchemes() {
+		if schemes == nil {
+			schemes = make(map[string]swSecurityScheme)
+		}
+		swScheme := swSecurityScheme{Type: scheme.kind, Description: scheme.desc}
+		switch scheme.kind {
+		case securityBearer:
+			swScheme.Type = "http"
+			swScheme.Scheme = securityBearer
+			swScheme.BearerFormat = scheme.format
+		case securityBasic:
+			swScheme.Type = "http"
+			swScheme.Scheme = securityBasic
+		case securityAPIKey:
+			swScheme.In = scheme.in
+			swScheme.Name = scheme.param
+		case securityOAuth2:
+			flow := &swOAuthFlow{AuthorizationURL: scheme.url, TokenURL: scheme.token, Scopes: scheme.scopes}
+			swScheme.Flows = &swOAuthFlows{}
+			switch scheme.flow {
+			case flowImplicit:
+				swScheme.Flows.Implicit = flow
+			case flowPassword:
+				swScheme.Flows.Password = flow
+			case flowAuthorizationCode:
+				swScheme.Flows.AuthorizationCode = flow
+			default:
+				swScheme.Flows.ClientCredentials = flow
+			}
+		case securityOpenIDConnect:
+			swScheme.OpenIDConnectURL = scheme.url
+		}
+		schemes[scheme.name] = swScheme
+	}
+	return
+}
+
+// operationSecurity returns requirements of method, nil if method uses requirements of document
+func (doc *swagger) operationSecurity(method *method) *[]swSecurityRequirement {
+
+	if requirements, found := method.security(); found {
+		return &requirements
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package generator
+
+import (
+	"context"
+	"path"
+	"path/filepath"
+
+	. "github.com/dave/jennifer/jen"
+)
+
+func (svc *service) renderClientLogger(outDir string) (err error) {
+
+	srcFile := newSrc(filepath.Base(outDir))
+	srcFile.PackageComment(doNotEdit)
+
+	ctx := context.WithValue(context.Background(), "code", srcFile)
+
+	srcFile.ImportName(packageViewer, "viewer")
+	srcFile.ImportName(packageZeroLog, "zerolog")
+
+	srcFile.Type().Id("logger"+svc.Name).Struct(
+		Id(_next_).Id(svc.Name),
+		Id("log").Qual(packageZeroLog, "Logger"),
+	)
+
+	srcFile.Line().Func().Id("loggerMiddleware" + svc.Name).Params(Id("log").Qual(packageZeroLog, "Logger")).Params(Id("Middleware" + svc.Name)).Block(
+		Return(Func().Params(Id(_next_).Id(svc.Name)).Params(Id(svc.Name)).Block(
+			Return(Op("&").Id("logger" + svc.Name).Values(Dict{
+				Id("log"):  Id("log"),
+				Id(_next_): Id(_next_),
+			})),
+		)),
+	)
+
+	for _, method := range svc.methods {
+		srcFile.Line().Func().Params(Id("m").Id("logger" + svc.Name)).Id(method.Name).Params(funcDefinitionParams(ctx, method.Args)).Params(funcDefinitionParams(ctx, method.Results)).BlockFunc(svc.loggerFuncBody(method))
+	}
+	return srcFile.Save(path.Join(outDir, svc.lcName()+"-logger.go"))
+}
+
+func (svc *service) renderClientMetrics(outDir string) (err error) {
+
+	srcFile := newSrc(filepath.Base(outDir))
+	srcFile.PackageComment(doNotEdit)
+
+	ctx := context.WithValue(context.Background(), "code", srcFile)
+
+	srcFile.ImportName(packageGoKitMetrics, "metrics")
+
+	srcFile.Type().Id("metrics"+svc.Name).Struct(
+		Id(_next_).Id(svc.Name),
+		Id("requestCount").Qual(packageGoKitMetrics, "Counter"),
+		Id("errorCount").Qual(packageGoKitMetrics, "Counter"),
+		Id("requestLatency").Qual(packageGoKitMetrics, "Histogram"),
+	)
+
+	srcFile.Line().Func().Id("metricsMiddleware" + svc.Name).Params(Id("client").String()).Params(Id("Middleware" + svc.Name)).Block(
+		Return(Func().Params(Id(_next_).Id(svc.Name)).Params(Id(svc.Name)).Block(
+			Return(Op("&").Id("metrics" + svc.Name).Values(Dict{
+				Id(_next_):           Id(_next_),
+				Id("requestCount"):   Id("RequestCount").Dot("With").Call(Lit("client"), Id("client"), Lit("service"), Lit(svc.Name)),
+				Id("errorCount"):     Id("ErrorCount").Dot("With").Call(Lit("client"), Id("client"), Lit("service"), Lit(svc.Name)),
+				Id("requestLatency"): Id("RequestLatency").Dot("With").Call(Lit("client"), Id("client"), Lit("service"), Lit(svc.Name)),
+			})),
+		)),
+	)
+
+	for _, method := range svc.methods {
+		srcFile.Line().Func().Params(Id("m").Id("metrics"+svc.Name)).Id(method.Name).Params(funcDefinitionParams(ctx, method.Args)).Params(funcDefinitionParams(ctx, method.Results)).Block(
+			Defer().Func().Params(Id("begin").Qual(packageTime, "Time")).Block(
+				Id("code").Op(":=").Id("callCode").Call(Err()),
+				Id("m").Dot("requestCount").Dot("With").Call(Lit("method"), Lit(method.lccName()), Lit("code"), Id("code")).Dot("Add").Call(Lit(1)),
+				If(Err().Op("!=").Nil()).Block(
+					Id("m").Dot("errorCount").Dot("With").Call(Lit("method"), Lit(method.lccName()), Lit("code"), Id("code")).Dot("Add").Call(Lit(1)),
+				),
+				Id("m").Dot("requestLatency").Dot("With").Call(Lit("method"), Lit(method.lccName()), Lit("code"), Id("code")).Dot("Observe").Call(Qual(packageTime, "Since").Call(Id("begin")).Dot("Seconds").Call()),
+			).Call(Qual(packageTime, "Now").Call()),
+			Return().Id("m").Dot(_next_).Dot(method.Name).Call(paramNames(method.Args)),
+		)
+	}
+	return srcFile.Save(path.Join(outDir, svc.lcName()+"-metrics.go"))
+}
+
+func (svc *service) renderClientTrace(outDir string) (err error) {
+
+	srcFile := newSrc(filepath.Base(outDir))
+	srcFile.PackageComment(doNotEdit)
+
+	ctx := context.WithValue(context.Background(), "code", srcFile)
+
+	srcFile.ImportName(packageZeroLog, "zerolog")
+	srcFile.ImportAlias(packageOpentracing, "otg")
+
+	srcFile.Type().Id("trace"+svc.Name).Struct(
+		Id(_next_).Id(svc.Name),
+		Id("log").Qual(packageZeroLog, "Logger"),
+		Id("client").String(),
+	)
+
+	srcFile.Line().Func().Id("traceMiddleware"+svc.Name).Params(Id("log").Qual(packageZeroLog, "Logger"), Id("client").String()).Params(Id("Middleware" + svc.Name)).Block(
+		Return(Func().Params(Id(_next_).Id(svc.Name)).Params(Id(svc.Name)).Block(
+			Return(Op("&").Id("trace" + svc.Name).Values(Dict{
+				Id(_next_):   Id(_next_),
+				Id("log"):    Id("log"),
+				Id("client"): Id("client"),
+			})),
+		)),
+	)
+
+	for _, method := range svc.methods {
+		srcFile.Line().Func().Params(Id("m").Id("trace"+svc.Name)).Id(method.Name).Params(funcDefinitionParams(ctx, method.Args)).Params(funcDefinitionParams(ctx, method.Results)).Block(
+			Id("span").Op(":=").Id("extractSpan").Call(Id("m").Dot("log"), Id(_ctx_), Lit(method.jsonrpcName())),
+			Id("span").Dot("SetTag").Call(Lit("client"), Id("m").Dot("client")),
+			Defer().Func().Params().Block(
+				If(Err().Op("!=").Nil()).Block(
+					Id("span").Dot("SetTag").Call(Lit("error"), True()),
+					Id("span").Dot("LogKV").Call(Lit("error"), Err().Dot("Error").Call()),
+				),
+				Id("span").Dot("Finish").Call(),
+			).Call(),
+			Id(_ctx_).Op("=").Qual(packageOpentracing, "ContextWithSpan").Call(Id(_ctx_), Id("span")),
+			Return().Id("m").Dot(_next_).Dot(method.Name).Call(paramNames(method.Args)),
+		)
+	}
+	return srcFile.Save(path.Join(outDir, svc.lcName()+"-trace.go"))
+}
@@ -0,0 +1,172 @@
+package generator
+
+import (
+	"context"
+	"path"
+	"path/filepath"
+
+	. "github.com/dave/jennifer/jen"
+	"github.com/vetcher/go-astra/types"
+)
+
+func (svc *service) renderClientService(outDir string) (err error) {
+
+	srcFile := newSrc(filepath.Base(outDir))
+	srcFile.PackageComment(doNotEdit)
+
+	ctx := context.WithValue(context.Background(), "code", srcFile)
+
+	srcFile.Line().Comment(svc.Name + " mirrors service interface, Client" + svc.Name + " implements it")
+	srcFile.Type().Id(svc.Name).InterfaceFunc(func(ig *Group) {
+		for _, method := range svc.methods {
+			ig.Id(method.Name).Params(funcDefinitionParams(ctx, method.Args)).Params(funcDefinitionParams(ctx, method.Results))
+		}
+	})
+
+	srcFile.Line()
+	for _, method := range svc.methods {
+		srcFile.Type().Id(svc.Name + method.Name).Func().Params(funcDefinitionParams(ctx, method.Args)).Params(funcDefinitionParams(ctx, method.Results))
+	}
+
+	srcFile.Line().Type().Id("Middleware" + svc.Name).Func().Params(Id("next").Id(svc.Name)).Params(Id(svc.Name)).Line()
+
+	for _, method := range svc.methods {
+		srcFile.Type().Id("Middleware" + svc.Name + method.Name).Func().Params(Id("next").Id(svc.Name + method.Name)).Params(Id(svc.Name + method.Name))
+	}
+
+	srcFile.Line().Type().Id("Client" + svc.Name).StructFunc(func(sg *Group) {
+		sg.Op("*").Id("ClientJsonRPC")
+		sg.Line().Id("svc").Id(svc.Name)
+		for _, method := range svc.methods {
+			sg.Id("call" + method.Name).Id(svc.Name + method.Name)
+		}
+	})
+
+	srcFile.Line().Var().Id("_").Id(svc.Name).Op("=").Op("&").Id("Client" + svc.Name).Values()
+
+	srcFile.Line().Comment("remote" + svc.Name + " sends calls to server, middlewares of Client" + svc.Name + " wrap it")
+	srcFile.Type().Id("remote" + svc.Name).Struct(
+		Op("*").Id("Client" + svc.Name),
+	)
+
+	srcFile.Line().Func().Id("newClient" + svc.Name).Params(Id("cli").Op("*").Id("ClientJsonRPC")).Params(Id("client").Op("*").Id("Client" + svc.Name)).BlockFunc(func(bg *Group) {
+		bg.Id("client").Op("=").Op("&").Id("Client" + svc.Name).Values(Dict{Id("ClientJsonRPC"): Id("cli")})
+		bg.Id("client").Dot("svc").Op("=").Op("&").Id("remote" + svc.Name).Values(Dict{Id("Client" + svc.Name): Id("client")})
+		for _, method := range svc.methods {
+			bg.Id("client").Dot("call" + method.Name).Op("=").Id("client").Dot("svc").Dot(method.Name)
+		}
+		bg.If(Id("cli").Dot("logging")).Block(
+			Id("client").Dot("Wrap").Call(Id("loggerMiddleware" + svc.Name).Call(Id("cli").Dot("log"))),
+		)
+		bg.If(Id("cli").Dot("metrics")).Block(
+			Id("client").Dot("Wrap").Call(Id("metricsMiddleware" + svc.Name).Call(Id("cli").Dot("name"))),
+		)
+		bg.If(Id("cli").Dot("tracing")).Block(
+			Id("client").Dot("Wrap").Call(Id("traceMiddleware"+svc.Name).Call(Id("cli").Dot("log"), Id("cli").Dot("name"))),
+		)
+		bg.Return()
+	})
+
+	srcFile.Line().Add(svc.clientWrapFunc())
+
+	for _, method := range svc.methods {
+		srcFile.Line().Func().Params(Id("cli").Op("*").Id("Client" + svc.Name)).Id("Wrap" + method.Name).Params(Id("m").Id("Middleware" + svc.Name + method.Name)).Block(
+			Id("cli").Dot("call" + method.Name).Op("=").Id("m").Call(Id("cli").Dot("call" + method.Name)),
+		)
+	}
+
+	for _, method := range svc.methods {
+		srcFile.Line().Func().Params(Id("cli").Op("*").Id("Client" + svc.Name)).Id(method.Name).Params(funcDefinitionParams(ctx, method.Args)).Params(funcDefinitionParams(ctx, method.Results)).Block(
+			Return(Id("cli").Dot("call" + method.Name).CallFunc(func(cg *Group) {
+				for _, arg := range method.Args {
+
+					argCode := Id(arg.Name)
+
+					if types.IsEllipsis(arg.Type) {
+						argCode.Op("...")
+					}
+					cg.Add(argCode)
+				}
+			})),
+		)
+	}
+
+	for _, method := range svc.clientUnsupportedMethods() {
+		srcFile.Line().Func().Params(Id("cli").Op("*").Id("remote"+svc.Name)).Id(method.Name).Params(funcDefinitionParams(ctx, method.Args)).Params(funcDefinitionParams(ctx, method.Results)).Block(
+			Id(method.Results[len(method.Results)-1].Name).Op("=").Id("ErrMethodUnsupported"),
+			Return(),
+		)
+	}
+	return srcFile.Save(path.Join(outDir, svc.lcName()+"-client.go"))
+}
+
+func (svc *service) clientWrapFunc() Code {
+
+	return Func().Params(Id("cli").Op("*").Id("Client" + svc.Name)).Id("Wrap").Params(Id("m").Id("Middleware" + svc.Name)).BlockFunc(func(bg *Group) {
+
+		bg.Id("cli").Dot("svc").Op("=").Id("m").Call(Id("cli").Dot("svc"))
+
+		for _, method := range svc.methods {
+			bg.Id("cli").Dot("call" + method.Name).Op("=").Id("cli").Dot("svc").Dot(method.Name)
+		}
+	})
+}
+
+// clientUnsupportedMethods returns methods, which client can not call, for example methods with own handler
+func (svc *service) clientUnsupportedMethods() (methods []*method) {
+
+	rest := make(map[*method]bool)
+	for _, method := range svc.restClientMethods() {
+		rest[method] = true
+	}
+	for _, method := range svc.methods {
+		if !method.isJsonRPC() && !rest[method] {
+			methods = append(methods, method)
+		}
+	}
+	return
+}
+
+// clientMethods returns methods, which client can call by jsonRPC or REST
+func (svc *service) clientMethods() (methods []*method) {
+
+	rest := make(map[*method]bool)
+	for _, method := range svc.restClientMethods() {
+		rest[method] = true
+	}
+	for _, method := range svc.methods {
+		if method.isJsonRPC() || rest[method] {
+			methods = append(methods, method)
+		}
+	}
+	return
+}
+
+// clientRequestFields returns fields of jsonRPC params or REST request body
+func (m *method) clientRequestFields() (fields []types.StructField) {
+
+	args := m.fieldsArgument()
+	if !m.isJsonRPC() {
+		args = m.arguments()
+	}
+	for _, arg := range args {
+		if fieldJsonName(arg) != "-" {
+			fields = append(fields, arg)
+		}
+	}
+	return
+}
+
+// clientResponseFields returns fields of jsonRPC result or REST response body
+func (m *method) clientResponseFields() (fields []types.StructField) {
+
+	for _, ret := range m.fieldsResult() {
+		if _, isDownload := m.downloadVarsMap()[ret.Name]; isDownload && !m.isJsonRPC() {
+			continue
+		}
+		if fieldJsonName(ret) != "-" {
+			fields = append(fields, ret)
+		}
+	}
+	return
+}
@@ -21,6 +21,9 @@ func (svc *service) renderExchange(outDir string) (err error) {
 
 	for _, method := range svc.methods {
 		srcFile.Add(svc.exchange(ctx, method.requestStructName(), method.fieldsArgument())).Line()
+		if len(method.enumFields()) != 0 {
+			srcFile.Add(svc.validateFunc(ctx, method)).Line()
+		}
 		srcFile.Add(svc.exchange(ctx, method.responseStructName(), method.fieldsResult())).Line()
 	}
 	return srcFile.Save(path.Join(outDir, svc.lcName()+"-exchange.go"))
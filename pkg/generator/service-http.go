@@ -9,7 +9,7 @@ import (
 
 	. "github.com/dave/jennifer/jen"
 
-	"github.com/tundrik/tg/pkg/utils"
+	"github.com/tundrik/tg/v2/pkg/utils"
 )
 
 func (svc *service) renderHTTP(outDir string) (err error) {
@@ -22,19 +22,25 @@ func (svc *service) renderHTTP(outDir string) (err error) {
 	srcFile.ImportName(packageZeroLog, "zerolog")
 	srcFile.ImportName(svc.pkgPath, filepath.Base(svc.pkgPath))
 
-	srcFile.Type().Id("http"+svc.Name).Struct(
-		Id("log").Qual(packageZeroLog, "Logger"),
-		Id("errorHandler").Id("ErrorHandler"),
-		Id("svc").Op("*").Id("server"+svc.Name),
-		Id("base").Qual(svc.pkgPath, svc.Name),
-	)
+	srcFile.Type().Id("http" + svc.Name).StructFunc(func(g *Group) {
+		g.Id("log").Qual(packageZeroLog, "Logger")
+		g.Id("errorHandler").Id("ErrorHandler")
+		g.Id("svc").Op("*").Id("server" + svc.Name)
+		g.Id("base").Qual(svc.pkgPath, svc.Name)
+		if svc.isJsonRPC() {
+			g.Id("limits").Op("*").Id("jsonrpcLimits")
+		}
+	})
 
 	srcFile.Line().Func().Id("New"+svc.Name).Params(Id("log").Qual(packageZeroLog, "Logger"), Id("svc"+svc.Name).Qual(svc.pkgPath, svc.Name)).Params(Id("srv").Op("*").Id("http"+svc.Name)).Block(
-		Line().Id("srv").Op("=").Op("&").Id("http"+svc.Name).Values(Dict{
-			Id("log"):  Id("log"),
-			Id("base"): Id("svc" + svc.Name),
-			Id("svc"):  Id("newServer" + svc.Name).Call(Id("svc" + svc.Name)),
-		}),
+		Line().Id("srv").Op("=").Op("&").Id("http"+svc.Name).Values(DictFunc(func(d Dict) {
+			d[Id("log")] = Id("log")
+			d[Id("base")] = Id("svc" + svc.Name)
+			d[Id("svc")] = Id("newServer" + svc.Name).Call(Id("svc" + svc.Name))
+			if svc.isJsonRPC() {
+				d[Id("limits")] = Id("newJsonRPCLimits").Call()
+			}
+		})),
 		Return(),
 	)
 	srcFile.Line().Func().Params(Id("http").Id("http" + svc.Name)).Id("Service").Params().Params(Id("MiddlewareSet" + svc.Name)).Block(
@@ -51,12 +57,16 @@ func (svc *service) renderHTTP(outDir string) (err error) {
 
 	srcFile.Line().Func().Params(Id("http").Op("*").Id("http" + svc.Name)).Id("SetRoutes").Params(Id("route").Op("*").Qual(packageFiber, "App")).BlockFunc(func(bg *Group) {
 		if svc.tags.Contains(tagServerJsonRPC) {
-			bg.Id("route").Dot("Post").Call(Lit(svc.batchPath()), Id("http").Dot("serveBatch"))
+			for _, batchPath := range svc.batchPaths() {
+				bg.Id("route").Dot("Post").Call(Lit(batchPath), Id("http").Dot("serveBatch"))
+			}
 			for _, method := range svc.methods {
 				if !method.isJsonRPC() {
 					continue
 				}
-				bg.Id("route").Dot("Post").Call(Lit(method.jsonrpcPath()), Id("http").Dot("serve"+method.Name))
+				for _, methodPath := range method.jsonrpcPaths() {
+					bg.Id("route").Dot("Post").Call(Lit(methodPath), Id("http").Dot("serve"+method.Name))
+				}
 			}
 		}
 		if svc.tags.Contains(tagServerHTTP) {
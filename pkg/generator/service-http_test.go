@@ -0,0 +1,49 @@
+package generator
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestJsonRPCRoutesOfRenamedMethods(t *testing.T) {
+
+	modDir := newTestModule(t, map[string]string{
+		"service/service.go": `package service
+
+import "context"
+
+// @tg jsonRPC-server log
+// @tg jsonRPC-namespace=Accounts
+type Users interface {
+	// @tg jsonRPC-name=listAll jsonRPC-aliases=users.all,all
+	List(ctx context.Context, limit int) (ids []int, err error)
+	Get(ctx context.Context, id int) (name string, err error)
+}
+`,
+	})
+	tr := newTestTransport(t, filepath.Join(modDir, "service"))
+	if err := tr.RenderServer(filepath.Join(modDir, "transport")); err != nil {
+		t.Fatal(err)
+	}
+	assertContains(t, readTestFile(t, filepath.Join(modDir, "transport", "users-http.go")),
+		`route.Post("/accounts", http.serveBatch)`,
+		`route.Post("/users", http.serveBatch)`,
+		`route.Post("/accounts/listall", http.serveList)`,
+		`route.Post("/users/list", http.serveList)`,
+		`route.Post("/users/all", http.serveList)`,
+		`route.Post("/accounts/all", http.serveList)`,
+		`route.Post("/accounts/get", http.serveGet)`,
+		`route.Post("/users/get", http.serveGet)`,
+	)
+	assertContains(t, readTestFile(t, filepath.Join(modDir, "transport", "users-jsonrpc.go")),
+		`http.serveMethod(ctx, "accounts.listall", http.list)`,
+		`http.serveMethod(ctx, "accounts.get", http.get)`,
+	)
+	assertContains(t, readTestFile(t, filepath.Join(modDir, "transport", "jsonrpc.go")),
+		`"accounts.all": "accounts.listall"`,
+		`"users.all":    "accounts.listall"`,
+		`"users.get":    "accounts.get"`,
+		`"users.list":   "accounts.listall"`,
+	)
+	typeCheck(t, modDir, filepath.Join(modDir, "transport"))
+}
@@ -11,7 +11,7 @@ import (
 	. "github.com/dave/jennifer/jen"
 	"github.com/vetcher/go-astra/types"
 
-	"github.com/tundrik/tg/pkg/utils"
+	"github.com/tundrik/tg/v2/pkg/utils"
 )
 
 func (svc *service) renderClientJsonRPC(outDir string) (err error) {
@@ -25,9 +25,7 @@ func (svc *service) renderClientJsonRPC(outDir string) (err error) {
 	srcFile.ImportName(packageFiber, "fiber")
 	srcFile.ImportName(packageZeroLog, "zerolog")
 
-	srcFile.Line().Type().Id("Client" + svc.Name).Struct(
-		Op("*").Id("ClientJsonRPC"),
-	).Line()
+	srcFile.Line()
 
 	for _, method := range svc.methods {
 
@@ -53,15 +51,18 @@ func (svc *service) jsonrpcClientRequestFunc(ctx context.Context, method *method
 
 	return Func().Params(Id("cli").Op("*").Id("Client"+svc.Name)).Id("Req"+method.Name).Params(Id("ret").Id("ret"+svc.Name+method.Name), funcDefinitionParams(ctx, method.argsWithoutContext())).Params(Id("request").Id("baseJsonRPC")).Block(
 
-		Line().Id("request").Op("=").Id("baseJsonRPC").Values(Dict{
-			Id("Version"): Id("Version"),
-			Id("Method"):  Lit(svc.lcName() + "." + method.lcName()),
-			Id("Params"): Id("request" + svc.Name + method.Name).Values(DictFunc(func(d Dict) {
+		Line().Id("request").Op("=").Id("baseJsonRPC").Values(DictFunc(func(d Dict) {
+			d[Id("Version")] = Id("Version")
+			d[Id("Method")] = Lit(method.jsonrpcName())
+			d[Id("Params")] = Id("request" + svc.Name + method.Name).Values(DictFunc(func(d Dict) {
 				for _, arg := range method.argsWithoutContext() {
 					d[Id(utils.ToCamel(arg.Name))] = Id(arg.Name)
 				}
-			})),
-		}),
+			}))
+			if method.tags.IsSet(tagIdempotent) {
+				d[Id("idempotent")] = True()
+			}
+		})),
 
 		Var().Err().Error(),
 		Var().Id("response").Id(method.responseStructName()),
@@ -94,7 +95,7 @@ func (svc *service) jsonrpcClientRequestFunc(ctx context.Context, method *method
 
 func (svc *service) jsonrpcClientMethodFunc(ctx context.Context, method *method) Code {
 
-	return Func().Params(Id("cli").Op("*").Id("Client"+svc.Name)).Id(method.Name).Params(funcDefinitionParams(ctx, method.Args)).Params(funcDefinitionParams(ctx, method.Results)).Block(
+	return Func().Params(Id("cli").Op("*").Id("remote"+svc.Name)).Id(method.Name).Params(funcDefinitionParams(ctx, method.Args)).Params(funcDefinitionParams(ctx, method.Results)).Block(
 
 		Line().Id("retHandler").Op(":=").Func().ParamsFunc(func(pg *Group) {
 			for _, ret := range method.Results {
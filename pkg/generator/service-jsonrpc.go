@@ -11,7 +11,7 @@ import (
 	. "github.com/dave/jennifer/jen"
 	"github.com/vetcher/go-astra/types"
 
-	"github.com/tundrik/tg/pkg/utils"
+	"github.com/tundrik/tg/v2/pkg/utils"
 )
 
 func (svc *service) renderJsonRPC(outDir string) (err error) {
@@ -31,7 +31,7 @@ func (svc *service) renderJsonRPC(outDir string) (err error) {
 			continue
 		}
 		srcFile.Func().Params(Id("http").Op("*").Id("http" + svc.Name)).Id("serve" + method.Name).Params(Id(_ctx_).Op("*").Qual(packageFiber, "Ctx")).Params(Err().Error()).Block(
-			Return().Id("http").Dot("serveMethod").Call(Id(_ctx_), Lit(method.lcName()), Id("http").Dot(method.lccName())),
+			Return().Id("http").Dot("serveMethod").Call(Id(_ctx_), Lit(method.jsonrpcName()), Id("http").Dot(method.lccName())),
 		)
 		srcFile.Add(svc.rpcMethodFunc(method))
 	}
@@ -78,22 +78,40 @@ func (svc *service) serveServiceBatchFunc() Code {
 			ig.Id("single").Op("=").True()
 			ig.Id("requests").Op("=").Append(Id("requests"), Id("request"))
 		})
-
+		bg.If(Err().Op("=").Id("http").Dot("limits").Dot("checkBatch").Call(Len(Id("requests"))).Op(";").Err().Op("!=").Nil()).BlockFunc(func(ig *Group) {
+			if svc.tags.IsSet(tagTrace) {
+				ig.Qual(packageOpentracingExt, "Error").Dot("Set").Call(Id("batchSpan"), True())
+				ig.Id("batchSpan").Dot("SetTag").Call(Lit("msg"), Err().Dot("Error").Call())
+			}
+			ig.Return().Id("sendResponse").Call(Id("http").Dot("log"), Id(_ctx_), Id("makeErrorResponseJsonRPC").Call(Op("[]").Byte().Call(Lit("null")), Id("invalidRequestError"), Err().Dot("Error").Call(), Nil()))
+		})
 		bg.Id("responses").Op(":=").Make(Id("jsonrpcResponses"), Lit(0), Len(Id("requests")))
 		bg.Var().Id("wg").Qual(packageSync, "WaitGroup")
 		bg.For(List(Id("_"), Id("request")).Op(":=").Range().Id("requests")).BlockFunc(func(fg *Group) {
 			fg.Id("methodNameOrigin").Op(":=").Id("request").Dot("Method")
 			fg.Id("method").Op(":=").Qual(packageStrings, "ToLower").Call(Id("request").Dot("Method"))
+			if svc.hasAliases() {
+				fg.Add(svc.resolveAlias())
+			}
 			if svc.tags.IsSet(tagTrace) {
 				fg.Id("span").Op(":=").Qual(packageOpentracing, "StartSpan").Call(Id("request").Dot("Method"), Qual(packageOpentracing, "ChildOf").Call(Id("batchSpan").Dot("Context").Call()))
 				fg.Id("span").Dot("SetTag").Call(Lit("batch"), True())
 			}
+			fg.If(Id("limitErr").Op(":=").Id("http").Dot("limits").Dot("checkRequest").Call(Lit(svc.jsonrpcNamespace()+".").Op("+").Id("method"), Id("request"), Op("!").Id("single")).Op(";").Id("limitErr").Op("!=").Nil()).BlockFunc(func(ig *Group) {
+				if svc.tags.IsSet(tagTrace) {
+					ig.Qual(packageOpentracingExt, "Error").Dot("Set").Call(Id("span"), True())
+					ig.Id("span").Dot("SetTag").Call(Lit("msg"), Id("limitErr").Dot("Error").Call())
+					ig.Id("span").Dot("Finish").Call()
+				}
+				ig.Id("responses").Dot("append").Call(Id("makeErrorResponseJsonRPC").Call(Id("request").Dot("ID"), Id("invalidRequestError"), Id("limitErr").Dot("Error").Call(), Nil()))
+				ig.Continue()
+			})
 			fg.Switch(Id("method")).BlockFunc(func(bg *Group) {
 				for _, method := range svc.methods {
 					if !method.isJsonRPC() {
 						continue
 					}
-					bg.Case(Lit(method.lcName())).Block(
+					bg.Case(Lit(method.jsonrpcLocalName())).Block(
 						Id("wg").Dot("Add").Call(Lit(1)),
 						Func().Params(Id("request").Id("baseJsonRPC")).BlockFunc(func(gr *Group) {
 							if svc.tags.IsSet(tagTrace) {
@@ -191,6 +209,15 @@ func (svc *service) rpcMethodFunc(method *method) Code {
 				Line().Return(Id("makeErrorResponseJsonRPC").Call(Id("requestBase").Dot("ID"), Id("parseError"), Lit(fmt.Sprintf("http header '%s' could not be decoded: ", header)).Op("+").Err().Dot("Error").Call(), Nil())),
 			)
 		}))
+		if len(method.enumFields()) != 0 {
+			bf.If(Err().Op("=").Id("request").Dot("validate").Call().Op(";").Err().Op("!=").Nil()).BlockFunc(func(ig *Group) {
+				if svc.tags.IsSet(tagTrace) {
+					ig.Qual(packageOpentracingExt, "Error").Dot("Set").Call(Id("span"), True())
+					ig.Id("span").Dot("SetTag").Call(Lit("msg"), Lit("invalid params: ").Op("+").Err().Dot("Error").Call())
+				}
+				ig.Return(Id("makeErrorResponseJsonRPC").Call(Id("requestBase").Dot("ID"), Id("invalidParamsError"), Lit("invalid params: ").Op("+").Err().Dot("Error").Call(), Nil()))
+			})
+		}
 		bf.Var().Id("response").Id(method.responseStructName())
 		bf.ListFunc(func(lg *Group) {
 			for _, ret := range method.resultsWithoutError() {
@@ -288,7 +315,15 @@ func (svc *service) serveMethodFunc() Code {
 			})
 			bg.Id("methodNameOrigin").Op(":=").Id("request").Dot("Method")
 			bg.Id("method").Op(":=").Qual(packageStrings, "ToLower").Call(Id("request").Dot("Method"))
-
+			bg.If(Id("method").Op("!=").Lit("").Op("&&").Op("!").Qual(packageStrings, "Contains").Call(Id("method"), Lit("."))).Block(
+				Id("method").Op("=").Lit(svc.jsonrpcNamespace() + ".").Op("+").Id("method"),
+			)
+			if svc.hasAliases() {
+				bg.If(List(Id("name"), Id("found")).Op(":=").Id("jsonrpcAliases").Index(Id("method")).Op(";").Id("found")).Block(
+					Id("http").Dot("log").Dot("Warn").Call().Dot("Str").Call(Lit("alias"), Id("methodNameOrigin")).Dot("Str").Call(Lit("method"), Id("name")).Dot("Msg").Call(Lit("deprecated jsonRPC method alias called")),
+					Id("method").Op("=").Id("name"),
+				)
+			}
 			bg.If(Id("method").Op("!=").Lit("").Op("&&").Id("method").Op("!=").Id("methodName")).BlockFunc(func(ig *Group) {
 				if svc.tags.IsSet(tagTrace) {
 					ig.Qual(packageOpentracingExt, "Error").Dot("Set").Call(Id("span"), True())
@@ -296,6 +331,13 @@ func (svc *service) serveMethodFunc() Code {
 				}
 				ig.Return().Id("sendResponse").Call(Id("http").Dot("log"), Id(_ctx_), Id("makeErrorResponseJsonRPC").Call(Id("request").Dot("ID"), Id("methodNotFoundError"), Lit("invalid method ").Op("+").Id("methodNameOrigin"), Nil()))
 			})
+			bg.If(Err().Op("=").Id("http").Dot("limits").Dot("checkRequest").Call(Id("methodName"), Id("request"), False()).Op(";").Err().Op("!=").Nil()).BlockFunc(func(ig *Group) {
+				if svc.tags.IsSet(tagTrace) {
+					ig.Qual(packageOpentracingExt, "Error").Dot("Set").Call(Id("span"), True())
+					ig.Id("span").Dot("SetTag").Call(Lit("msg"), Err().Dot("Error").Call())
+				}
+				ig.Return().Id("sendResponse").Call(Id("http").Dot("log"), Id(_ctx_), Id("makeErrorResponseJsonRPC").Call(Id("request").Dot("ID"), Id("invalidRequestError"), Err().Dot("Error").Call(), Nil()))
+			})
 			if svc.tags.IsSet(tagTrace) {
 				bg.Id("response").Op("=").Id("methodHandler").Call(Id("span"), Id(_ctx_), Id("request"))
 			} else {
@@ -307,3 +349,12 @@ func (svc *service) serveMethodFunc() Code {
 			bg.Return()
 		})
 }
+
+func (svc *service) resolveAlias() Code {
+
+	namespace := svc.jsonrpcNamespace() + "."
+	return If(List(Id("name"), Id("found")).Op(":=").Id("jsonrpcAliases").Index(Lit(namespace).Op("+").Id("method")).Op(";").Id("found")).Block(
+		Id("http").Dot("log").Dot("Warn").Call().Dot("Str").Call(Lit("alias"), Id("methodNameOrigin")).Dot("Str").Call(Lit("method"), Id("name")).Dot("Msg").Call(Lit("deprecated jsonRPC method alias called")),
+		Id("method").Op("=").Qual(packageStrings, "TrimPrefix").Call(Id("name"), Lit(namespace)),
+	)
+}
@@ -12,8 +12,8 @@ import (
 
 	. "github.com/dave/jennifer/jen"
 
-	"github.com/tundrik/tg/pkg/tags"
-	"github.com/tundrik/tg/pkg/utils"
+	"github.com/tundrik/tg/v2/pkg/tags"
+	"github.com/tundrik/tg/v2/pkg/utils"
 )
 
 func (svc *service) renderLogger(outDir string) (err error) {
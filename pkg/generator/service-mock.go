@@ -0,0 +1,148 @@
+package generator
+
+import (
+	"context"
+	"path"
+	"path/filepath"
+
+	. "github.com/dave/jennifer/jen"
+	"github.com/vetcher/go-astra/types"
+
+	"github.com/tundrik/tg/v2/pkg/utils"
+)
+
+func (svc *service) renderMock(outDir string) (err error) {
+
+	srcFile := newSrc(filepath.Base(outDir))
+	srcFile.PackageComment(doNotEdit)
+
+	ctx := context.WithValue(context.Background(), "code", srcFile)
+
+	srcFile.ImportName(packageErrors, "errors")
+	srcFile.ImportName(svc.pkgPath, filepath.Base(svc.pkgPath))
+
+	srcFile.Line().Var().Id("_").Qual(svc.pkgPath, svc.Name).Op("=").Parens(Op("*").Id(svc.Name)).Parens(Nil())
+
+	srcFile.Line().Comment(svc.Name + " is a mock of " + svc.Name + " interface, unexpected calls and unmet expectations fail the test")
+	srcFile.Type().Id(svc.Name).Struct(
+		Id("mock").Op("*").Id("Mock"),
+	)
+
+	srcFile.Line().Func().Id("New" + svc.Name).Params(Id("t").Id("TestingT")).Params(Op("*").Id(svc.Name)).Block(
+		Return(Op("&").Id(svc.Name).Values(Dict{Id("mock"): Id("NewMock").Call(Id("t"))})),
+	)
+
+	for _, method := range svc.methods {
+		srcFile.Add(svc.mockCall(ctx, method))
+		srcFile.Line().Add(svc.mockExpect(method))
+		srcFile.Line().Add(svc.mockMethod(ctx, method))
+	}
+	return srcFile.Save(path.Join(outDir, svc.lcName()+"-mock.go"))
+}
+
+func (svc *service) mockCallName(method *method) string {
+	return svc.Name + method.Name + "Call"
+}
+
+func (svc *service) mockAction(ctx context.Context, method *method) *Statement {
+	return Func().ParamsFunc(func(pg *Group) {
+		for _, arg := range method.Args {
+			pg.Add(fieldType(ctx, arg.Type, true))
+		}
+	}).ParamsFunc(func(pg *Group) {
+		for _, ret := range method.Results {
+			pg.Add(fieldType(ctx, ret.Type, false))
+		}
+	})
+}
+
+func (svc *service) mockCall(ctx context.Context, method *method) Code {
+
+	callName := svc.mockCallName(method)
+	code := Line().Comment(callName + " is an expectation of " + svc.Name + "." + method.Name + " call").
+		Line().Type().Id(callName).Struct(
+		Id("call").Op("*").Id("Call"),
+	)
+	code.Line().Line().Comment("Return sets values returned by the call").
+		Line().Func().Params(Id("c").Op("*").Id(callName)).Id("Return").Params(funcDefinitionParams(ctx, method.Results)).Params(Op("*").Id(callName)).Block(
+		Id("c").Dot("call").Dot("action").Op("=").Add(svc.mockAction(ctx, method)).Block(
+			ReturnFunc(func(rg *Group) {
+				for _, ret := range method.Results {
+					rg.Id(utils.ToLowerCamel(ret.Name))
+				}
+			}),
+		),
+		Return(Id("c")),
+	)
+	code.Line().Line().Comment("Do sets function which is called instead of the method").
+		Line().Func().Params(Id("c").Op("*").Id(callName)).Id("Do").Params(Id("action").Add(svc.mockAction(ctx, method))).Params(Op("*").Id(callName)).Block(
+		Id("c").Dot("call").Dot("action").Op("=").Id("action"),
+		Return(Id("c")),
+	)
+	code.Line().Line().Comment("Times sets exact number of expected calls").
+		Line().Func().Params(Id("c").Op("*").Id(callName)).Id("Times").Params(Id("n").Int()).Params(Op("*").Id(callName)).Block(
+		Id("c").Dot("call").Dot("times").Call(Id("n"), Id("n")),
+		Return(Id("c")),
+	)
+	code.Line().Line().Comment("AnyTimes allows any number of calls, including none").
+		Line().Func().Params(Id("c").Op("*").Id(callName)).Id("AnyTimes").Params().Params(Op("*").Id(callName)).Block(
+		Id("c").Dot("call").Dot("times").Call(Lit(0), Lit(-1)),
+		Return(Id("c")),
+	)
+	return code
+}
+
+func (svc *service) mockExpect(method *method) Code {
+
+	args := method.argsWithoutContext()
+	return Comment("Expect" + method.Name + " registers expected call, arguments are values or matchers").
+		Line().Func().Params(Id("m").Op("*").Id(svc.Name)).Id("Expect" + method.Name).ParamsFunc(func(pg *Group) {
+		if len(args) == 0 {
+			return
+		}
+		pg.ListFunc(func(lg *Group) {
+			for _, arg := range args {
+				lg.Id(utils.ToLowerCamel(arg.Name))
+			}
+		}).Interface()
+	}).Params(Op("*").Id(svc.mockCallName(method))).Block(
+		Return(Op("&").Id(svc.mockCallName(method)).Values(Dict{
+			Id("call"): Id("m").Dot("mock").Dot("Expect").CallFunc(func(cg *Group) {
+				cg.Lit(method.Name)
+				for _, arg := range args {
+					cg.Id(utils.ToLowerCamel(arg.Name))
+				}
+			}),
+		})),
+	)
+}
+
+func (svc *service) mockMethod(ctx context.Context, method *method) Code {
+
+	return Func().Params(Id("m").Op("*").Id(svc.Name)).Id(method.Name).Params(funcDefinitionParams(ctx, method.Args)).Params(funcDefinitionParams(ctx, method.Results)).BlockFunc(func(bg *Group) {
+		bg.Id("call").Op(":=").Id("m").Dot("mock").Dot("Called").CallFunc(func(cg *Group) {
+			cg.Lit(method.Name)
+			for _, arg := range method.argsWithoutContext() {
+				cg.Id(utils.ToLowerCamel(arg.Name))
+			}
+		})
+		bg.If(Id("call").Op("==").Nil()).BlockFunc(func(ig *Group) {
+			if isErrorLast(method.Results) {
+				ig.Id(utils.ToLowerCamel(method.Results[len(method.Results)-1].Name)).Op("=").Qual(packageErrors, "New").Call(Lit("unexpected call " + svc.Name + "." + method.Name))
+			}
+			ig.Return()
+		})
+		bg.If(List(Id("action"), Id("ok")).Op(":=").Id("call").Dot("action").Assert(svc.mockAction(ctx, method)).Op(";").Id("ok")).Block(
+			Return(Id("action").CallFunc(func(cg *Group) {
+				for _, arg := range method.Args {
+					argCode := Id(utils.ToLowerCamel(arg.Name))
+					if types.IsEllipsis(arg.Type) {
+						argCode.Op("...")
+					}
+					cg.Add(argCode)
+				}
+			})),
+		)
+		bg.Return()
+	})
+}
@@ -0,0 +1,233 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	. "github.com/dave/jennifer/jen"
+	"github.com/vetcher/go-astra/types"
+
+	"github.com/tundrik/tg/v2/pkg/utils"
+)
+
+func (svc *service) renderClientREST(outDir string) (err error) {
+
+	srcFile := newSrc(filepath.Base(outDir))
+	srcFile.PackageComment(doNotEdit)
+
+	ctx := context.WithValue(context.Background(), "code", srcFile)
+
+	srcFile.ImportName(packageJson, "json")
+
+	for _, method := range svc.restClientMethods() {
+		if err = method.restHeaderResultsErr(); err != nil {
+			return
+		}
+		srcFile.Line().Add(svc.restClientMethodFunc(ctx, method))
+	}
+	return srcFile.Save(path.Join(outDir, svc.lcName()+"-rest.go"))
+}
+
+func (svc *service) restClientMethodFunc(ctx context.Context, method *method) Code {
+
+	return Func().Params(Id("cli").Op("*").Id("remote" + svc.Name)).Id(method.Name).Params(funcDefinitionParams(ctx, method.Args)).Params(funcDefinitionParams(ctx, method.Results)).BlockFunc(func(bg *Group) {
+
+		bg.Line().Id("query").Op(":=").Make(Qual(packageURL, "Values"))
+		for _, argName := range sortedKeys(method.argParamMap()) {
+			if arg := method.argByName(argName); arg != nil {
+				bg.Add(restArgValue(arg, func(value Code) Code {
+					return Id("query").Dot("Set").Call(Lit(method.argParamMap()[argName]), value)
+				}))
+			}
+		}
+		bg.Var().Id("restURL").String()
+		bg.If(List(Id("restURL"), Err()).Op("=").Id("cli").Dot("restURL").Call(method.restPath(), Id("query")).Op(";").Err().Op("!=").Nil()).Block(
+			Return(),
+		)
+		bg.Id("request").Op(":=").Id("cli").Dot("newRequest").Call(Id(_ctx_), Lit(strings.ToUpper(method.httpMethod())), Id("restURL"))
+
+		for _, argName := range sortedKeys(method.varHeaderMap()) {
+			if arg := method.argByName(argName); arg != nil {
+				bg.Add(restArgValue(arg, func(value Code) Code {
+					return Id("request").Dot("Header").Dot("Set").Call(Lit(method.varHeaderMap()[argName]), value)
+				}))
+			}
+		}
+		for _, argName := range sortedKeys(method.argCookieMap()) {
+			if arg := method.argByName(argName); arg != nil {
+				bg.Add(restArgValue(arg, func(value Code) Code {
+					return Id("request").Dot("Header").Dot("Add").Call(Lit("Cookie"), Parens(Op("&").Qual(packageHttp, "Cookie").Values(Dict{
+						Id("Name"):  Lit(method.argCookieMap()[argName]),
+						Id("Value"): value,
+					})).Dot("String").Call())
+				}))
+			}
+		}
+		if uploads := method.uploadVarsMap(); len(uploads) != 0 {
+			bg.Var().Id("body").Qual(packageBytes, "Buffer")
+			bg.Id("form").Op(":=").Qual(packageMultipart, "NewWriter").Call(Op("&").Id("body"))
+			for _, argName := range sortedKeys(uploads) {
+				if arg := method.argByName(argName); arg != nil {
+					bg.If(Err().Op("=").Id("writeFormFile").Call(Id("form"), Lit(uploads[argName]), Id(utils.ToLowerCamel(arg.Name))).Op(";").Err().Op("!=").Nil()).Block(
+						Return(),
+					)
+				}
+			}
+			bg.If(Err().Op("=").Id("form").Dot("Close").Call().Op(";").Err().Op("!=").Nil()).Block(
+				Return(),
+			)
+			bg.Id("request").Dot("Header").Dot("Set").Call(Lit("Content-Type"), Id("form").Dot("FormDataContentType").Call())
+			bg.Id("request").Dot("Body").Op("=").Id("body").Dot("Bytes").Call()
+		} else if len(method.arguments()) != 0 {
+			bg.Id("request").Dot("Header").Dot("Set").Call(Lit("Content-Type"), Id("contentTypeJson"))
+			bg.If(List(Id("request").Dot("Body"), Err()).Op("=").Qual(packageJson, "Marshal").Call(
+				Id(method.requestStructName()).Values(DictFunc(func(d Dict) {
+					for _, arg := range method.arguments() {
+						d[Id(utils.ToCamel(arg.Name))] = Id(utils.ToLowerCamel(arg.Name))
+					}
+				})),
+			).Op(";").Err().Op("!=").Nil()).Block(
+				Return(),
+			)
+		}
+		response := Id("_")
+		if method.restClientUsesResponse() {
+			bg.Var().Id("response").Op("*").Id("HTTPResponse")
+			response = Id("response")
+		}
+		bg.If(List(response, Err()).Op("=").Id("cli").Dot("restCall").Call(Id(_ctx_), Lit(method.jsonrpcName()), Id("request"), Lit(method.tags.IsSet(tagIdempotent))).Op(";").Err().Op("!=").Nil()).Block(
+			Return(),
+		)
+		for _, retName := range sortedKeys(method.varHeaderMap()) {
+			if ret := method.resultByName(retName); ret != nil {
+				bg.Id(utils.ToLowerCamel(ret.Name)).Op("=").Id("response").Dot("Header").Dot("Get").Call(Lit(method.varHeaderMap()[retName]))
+			}
+		}
+		for _, retName := range sortedKeys(method.downloadVarsMap()) {
+			if ret := method.resultByName(retName); ret != nil {
+				bg.Id(utils.ToLowerCamel(ret.Name)).Op("=").Id("response").Dot("Body")
+				bg.Return()
+				return
+			}
+		}
+		if len(method.results()) != 0 {
+			bg.Var().Id("results").Id(method.responseStructName())
+			bg.If(Err().Op("=").Qual(packageJson, "Unmarshal").Call(Id("response").Dot("Body"), Op("&").Id("results")).Op(";").Err().Op("!=").Nil()).Block(
+				Return(),
+			)
+			for _, ret := range method.results() {
+				bg.Id(utils.ToLowerCamel(ret.Name)).Op("=").Id("results").Dot(ret.Name)
+			}
+		}
+		bg.Return()
+	})
+}
+
+// restClientUsesResponse reports whether client method reads headers or body of response
+func (m *method) restClientUsesResponse() bool {
+
+	for retName := range m.varHeaderMap() {
+		if m.resultByName(retName) != nil {
+			return true
+		}
+	}
+	for retName := range m.downloadVarsMap() {
+		if m.resultByName(retName) != nil {
+			return true
+		}
+	}
+	return len(m.results()) != 0
+}
+
+// restHeaderResultsErr rejects results passed by response headers, which are not strings, server sets headers of strings only
+func (m *method) restHeaderResultsErr() error {
+
+	for _, retName := range sortedKeys(m.varHeaderMap()) {
+		if ret := m.resultByName(retName); ret != nil && (types.TypeName(ret.Type) == nil || *types.TypeName(ret.Type) != "string") {
+			return fmt.Errorf("%s.%s: result '%s' passed by header '%s' must be string", m.svc.Name, m.Name, ret.Name, m.varHeaderMap()[retName])
+		}
+	}
+	return nil
+}
+
+// restPath builds URL path of method with path arguments substituted.
+func (m *method) restPath() *Statement {
+
+	var parts []Code
+	var literal string
+	for _, token := range strings.Split(m.httpPath(), "/") {
+		if token == "" {
+			continue
+		}
+		literal += "/"
+		if !strings.HasPrefix(token, ":") {
+			literal += token
+			continue
+		}
+		arg := m.argByName(strings.TrimPrefix(token, ":"))
+		if arg == nil {
+			literal += token
+			continue
+		}
+		parts = append(parts, Lit(literal), Qual(packageURL, "PathEscape").Call(restValue(arg.Type, Id(utils.ToLowerCamel(arg.Name)))))
+		literal = ""
+	}
+	if literal != "" || len(parts) == 0 {
+		parts = append(parts, Lit(literal))
+	}
+	return Add(parts[0]).Do(func(s *Statement) {
+		for _, part := range parts[1:] {
+			s.Op("+").Add(part)
+		}
+	})
+}
+
+// restArgValue converts argument to string and passes it to setter, pointers are set only if not nil.
+func restArgValue(arg *types.Variable, setter func(value Code) Code) Code {
+
+	argID := Id(utils.ToLowerCamel(arg.Name))
+	if pointer, ok := arg.Type.(types.TPointer); ok {
+		return If(argID.Clone().Op("!=").Nil()).Block(
+			setter(restValue(pointer.NextType(), Op("*").Add(argID))),
+		)
+	}
+	return setter(restValue(arg.Type, argID))
+}
+
+func restValue(vType types.Type, id *Statement) Code {
+
+	if typeName := types.TypeName(vType); typeName != nil {
+		switch *typeName {
+		case "string":
+			return id
+		case "Time":
+			return Parens(id).Dot("Format").Call(Qual(packageTime, "RFC3339Nano"))
+		}
+	}
+	return Qual(packageFmt, "Sprint").Call(id)
+}
+
+func (svc service) hasREST() bool {
+	return len(svc.restClientMethods()) != 0
+}
+
+func (svc service) restClientMethods() (methods []*method) {
+	for _, method := range svc.methods {
+		if method.isHTTP() && !method.tags.Contains(tagHandler) && !method.tags.IsSet(tagHttpResponse) {
+			methods = append(methods, method)
+		}
+	}
+	return
+}
+
+func sortedKeys(values map[string]string) (keys []string) {
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return
+}
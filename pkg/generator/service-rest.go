@@ -11,7 +11,7 @@ import (
 	. "github.com/dave/jennifer/jen"
 	"github.com/vetcher/go-astra/types"
 
-	"github.com/tundrik/tg/pkg/utils"
+	"github.com/tundrik/tg/v2/pkg/utils"
 )
 
 func (svc *service) renderREST(outDir string) (err error) {
@@ -165,6 +165,16 @@ func (svc *service) httpServeMethodFunc(method *method) Code {
 				ig.Return().Id("sendResponse").Call(Id("http").Dot("log"), Id(_ctx_), Lit("upload file '"+uploadVar+"' error: ").Op("+").Err().Dot("Error").Call())
 			})
 		}
+		if len(method.enumFields()) != 0 {
+			bg.If(Err().Op("=").Id("request").Dot("validate").Call().Op(";").Err().Op("!=").Nil()).BlockFunc(func(ig *Group) {
+				ig.Id(_ctx_).Dot("Status").Call(Qual(packageFiber, "StatusBadRequest"))
+				if svc.tags.IsSet(tagTrace) {
+					ig.Qual(packageOpentracingExt, "Error").Dot("Set").Call(Id("span"), True())
+					ig.Id("span").Dot("SetTag").Call(Lit("msg"), Lit("request validation failed: ").Op("+").Err().Dot("Error").Call())
+				}
+				ig.Return().Id("sendResponse").Call(Id("http").Dot("log"), Id(_ctx_), Lit("request validation failed: ").Op("+").Err().Dot("Error").Call())
+			})
+		}
 		if responseMethod := method.tags.Value(tagHttpResponse, ""); responseMethod != "" {
 			bg.Return().Add(toID(responseMethod).Call(Id(_ctx_), Id("http").Dot("base"), callParamNames("request", method.argsWithoutContext())))
 		} else {
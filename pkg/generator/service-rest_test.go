@@ -0,0 +1,102 @@
+package generator
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+const testRESTService = `package service
+
+import "context"
+
+// @tg http-server log
+type Files interface {
+	// @tg http-method=GET http-path=/files/:fileID
+	// @tg http-args=limit|limit
+	Info(ctx context.Context, fileID string, limit int) (name string, err error)
+	// @tg http-method=DELETE http-path=/files/:fileID
+	Remove(ctx context.Context, fileID string) (err error)
+}
+`
+
+func TestRESTPathArgumentsEscaping(t *testing.T) {
+
+	modDir := newTestModule(t, map[string]string{"service/service.go": testRESTService})
+	tr := newTestTransport(t, filepath.Join(modDir, "service"))
+	if err := tr.RenderServer(filepath.Join(modDir, "transport")); err != nil {
+		t.Fatal(err)
+	}
+	clientDir := filepath.Join(modDir, "client")
+	if err := tr.RenderClient(clientDir); err != nil {
+		t.Fatal(err)
+	}
+	if err := tr.RenderClientJS(filepath.Join(modDir, "js")); err != nil {
+		t.Fatal(err)
+	}
+	if err := tr.RenderClientTS(filepath.Join(modDir, "ts")); err != nil {
+		t.Fatal(err)
+	}
+	if err := tr.RenderClientPython(filepath.Join(modDir, "python")); err != nil {
+		t.Fatal(err)
+	}
+	assertContains(t, readTestFile(t, filepath.Join(modDir, "transport", "http.go")),
+		`value = ctx.Params(key)`,
+		`url.PathUnescape(value)`,
+	)
+	assertContains(t, readTestFile(t, filepath.Join(modDir, "transport", "files-rest.go")),
+		`pathParam(ctx, "fileID")`,
+	)
+	assertContains(t, readTestFile(t, filepath.Join(clientDir, "files-rest.go")),
+		`"/files/"+url.PathEscape(fileID)`,
+	)
+	assertContains(t, readTestFile(t, filepath.Join(modDir, "js", "jsonrpc-client.js")),
+		`"/files/" + encodeURIComponent(String(fileID))`,
+	)
+	assertContains(t, readTestFile(t, filepath.Join(modDir, "ts", "src", "client.ts")),
+		`"/files/" + encodeURIComponent(String(fileID))`,
+	)
+	assertContains(t, readTestFile(t, filepath.Join(modDir, "python", "python_client", "client.py")),
+		`"/files/" + urllib.parse.quote(_param(file_id), safe="")`,
+	)
+	typeCheck(t, modDir, filepath.Join(modDir, "transport"))
+}
+
+func TestRESTVoidMethodClient(t *testing.T) {
+
+	modDir := newTestModule(t, map[string]string{"service/service.go": testRESTService})
+	tr := newTestTransport(t, filepath.Join(modDir, "service"))
+	clientDir := filepath.Join(modDir, "client")
+	if err := tr.RenderClient(clientDir); err != nil {
+		t.Fatal(err)
+	}
+	assertContains(t, readTestFile(t, filepath.Join(clientDir, "files-rest.go")),
+		`if _, err = cli.restCall(ctx, "files.remove"`,
+	)
+	typeCheck(t, modDir, clientDir)
+}
+
+func TestRESTClientBasePathAndHeaderResults(t *testing.T) {
+
+	modDir := newTestModule(t, map[string]string{"service/service.go": `package service
+
+import "context"
+
+// @tg http-server log
+type Files interface {
+	// @tg http-method=GET http-path=/files/:fileID
+	// @tg http-headers=total|X-Total
+	Count(ctx context.Context, fileID string) (total int, err error)
+}
+`})
+	tr := newTestTransport(t, filepath.Join(modDir, "service"))
+	clientDir := filepath.Join(modDir, "client")
+	if err := tr.RenderClient(clientDir); err != nil {
+		t.Fatal(err)
+	}
+	assertContains(t, readTestFile(t, filepath.Join(clientDir, "rest.go")),
+		`baseURL.Host + strings.TrimSuffix(baseURL.EscapedPath(), "/") + path`,
+	)
+	if err := tr.services["Files"].renderClientREST(clientDir); err == nil {
+		t.Fatal("header result of int type is not rejected")
+	}
+}
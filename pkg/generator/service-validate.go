@@ -0,0 +1,109 @@
+package generator
+
+import (
+	"context"
+	"go/ast"
+	"go/constant"
+
+	. "github.com/dave/jennifer/jen"
+	"github.com/vetcher/go-astra/types"
+
+	"github.com/tundrik/tg/v2/pkg/utils"
+)
+
+type enumField struct {
+	field   types.StructField
+	pointer bool
+	pkgPath string
+	named   types.Type
+	values  []enumValue
+}
+
+// enumFields returns arguments of method with constants declared for their imported named types
+func (m *method) enumFields() (fields []enumField) {
+
+	for _, field := range m.fieldsArgument() {
+		enum := enumField{field: field, named: field.Type}
+		if pointer, ok := enum.named.(types.TPointer); ok {
+			enum.pointer = true
+			enum.named = pointer.Next
+		}
+		named, ok := enum.named.(types.TImport)
+		if !ok || named.Import == nil {
+			continue
+		}
+		enum.pkgPath = named.Import.Package
+		if enum.values = searchEnum(enum.pkgPath, named.Next.String()); len(enum.values) != 0 {
+			fields = append(fields, enum)
+		}
+	}
+	return
+}
+
+// validateFunc renders check of enum arguments of request, zero value is accepted as not set optional argument.
+// Enum fields of structures passed as arguments are not checked
+func (svc *service) validateFunc(ctx context.Context, method *method) Code {
+
+	fields := method.enumFields()
+	return Func().Params(Id("request").Id(method.requestStructName())).Id("validate").Params().Params(Err().Error()).BlockFunc(func(bg *Group) {
+		for _, enum := range fields {
+			fieldName := utils.ToCamel(enum.field.Name)
+			value := Id("request").Dot(fieldName)
+			if enum.pointer {
+				value = Op("*").Id("request").Dot(fieldName)
+			}
+			optional := !enum.pointer && !isRequired(enum.field.Type, enum.field.Tags["json"], method.tags.Sub(enum.field.Name))
+			check := Switch(value).Block(
+				CaseFunc(func(cg *Group) {
+					zero := optional
+					for _, enumValue := range enum.values {
+						cg.Add(enumLiteral(ctx, enum, enumValue))
+						zero = zero && !isZeroConstant(enumValue.Value)
+					}
+					if zero {
+						cg.Add(fieldType(ctx, enum.named, false).Call(Op(zeroLiteral(enum.values[0].Value))))
+					}
+				}),
+				Default().Return(Qual(packageFmt, "Errorf").Call(Lit(enum.field.Name+": unexpected value %v"), value)),
+			)
+			if enum.pointer {
+				bg.If(Id("request").Dot(fieldName).Op("!=").Nil()).Block(check)
+				continue
+			}
+			bg.Add(check)
+		}
+		bg.Return()
+	})
+}
+
+func enumLiteral(ctx context.Context, enum enumField, value enumValue) Code {
+
+	if ast.IsExported(value.Name) {
+		return Qual(enum.pkgPath, value.Name)
+	}
+	return fieldType(ctx, enum.named, false).Call(Op(value.literal()))
+}
+
+func isZeroConstant(value constant.Value) bool {
+
+	switch value.Kind() {
+	case constant.String:
+		return constant.StringVal(value) == ""
+	case constant.Bool:
+		return !constant.BoolVal(value)
+	default:
+		return constant.Sign(value) == 0
+	}
+}
+
+func zeroLiteral(value constant.Value) string {
+
+	switch value.Kind() {
+	case constant.String:
+		return `""`
+	case constant.Bool:
+		return "false"
+	default:
+		return "0"
+	}
+}
@@ -0,0 +1,46 @@
+package generator
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestValidateRequiredEnums(t *testing.T) {
+
+	modDir := newTestModule(t, map[string]string{
+		"service/service.go": `package service
+
+import (
+	"context"
+
+	"example.com/tgtest/types"
+)
+
+// @tg jsonRPC-server log
+type Users interface {
+	// @tg kind.optional
+	List(ctx context.Context, status types.Status, kind types.Status, level *types.Status) (ids []int, err error)
+}
+`,
+		"types/types.go": `package types
+
+type Status string
+
+const (
+	StatusActive  Status = "active"
+	StatusBlocked Status = "blocked"
+)
+`,
+	})
+	chdirTestModule(t, modDir)
+	tr := newTestTransport(t, filepath.Join(modDir, "service"))
+	if err := tr.RenderServer(filepath.Join(modDir, "transport")); err != nil {
+		t.Fatal(err)
+	}
+	exchange := readTestFile(t, filepath.Join(modDir, "transport", "users-exchange.go"))
+	assertContains(t, exchange,
+		"switch request.Status {\n\tcase types.StatusActive, types.StatusBlocked:\n",
+		"switch request.Kind {\n\tcase types.StatusActive, types.StatusBlocked, types.Status(\"\"):\n",
+		"switch *request.Level {\n\t\tcase types.StatusActive, types.StatusBlocked:\n",
+	)
+}
@@ -11,8 +11,8 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/vetcher/go-astra/types"
 
-	"github.com/tundrik/tg/pkg/tags"
-	"github.com/tundrik/tg/pkg/utils"
+	"github.com/tundrik/tg/v2/pkg/tags"
+	"github.com/tundrik/tg/v2/pkg/utils"
 )
 
 type service struct {
@@ -61,14 +61,36 @@ func (svc service) lcName() string {
 	return strings.ToLower(svc.Name)
 }
 
+func (svc service) hasAliases() bool {
+	for _, method := range svc.methods {
+		if method.isJsonRPC() && (len(method.jsonrpcAliases()) != 0 || method.jsonrpcLegacyName() != "") {
+			return true
+		}
+	}
+	return false
+}
+
+func (svc service) jsonrpcNamespace() string {
+	return strings.ToLower(svc.tags.Value(tagRPCNamespace, svc.Name))
+}
+
 func (svc service) lccName() string {
 	return utils.ToLowerCamel(svc.Name)
 }
 
 func (svc *service) renderClient(outDir string) (err error) {
+	if svc.tags.Contains(tagServerJsonRPC) || svc.hasREST() {
+		showError(svc.log, svc.renderExchange(outDir), "renderExchange")
+		showError(svc.log, svc.renderClientService(outDir), "renderClientService")
+		showError(svc.log, svc.renderClientLogger(outDir), "renderClientLogger")
+		showError(svc.log, svc.renderClientMetrics(outDir), "renderClientMetrics")
+		showError(svc.log, svc.renderClientTrace(outDir), "renderClientTrace")
+	}
 	if svc.tags.Contains(tagServerJsonRPC) {
-		err = svc.renderExchange(outDir)
-		err = svc.renderClientJsonRPC(outDir)
+		showError(svc.log, svc.renderClientJsonRPC(outDir), "renderClientJsonRPC")
+	}
+	if svc.hasREST() {
+		showError(svc.log, svc.renderClientREST(outDir), "renderClientREST")
 	}
 	return
 }
@@ -104,5 +126,15 @@ func (svc *service) render(outDir string) (err error) {
 }
 
 func (svc service) batchPath() string {
-	return path.Join("/", svc.tr.tags.Value(tagHttpPrefix), svc.tags.Value(tagHttpPrefix), svc.tags.Value(tagHttpPath, path.Join("/", svc.lcName())))
+	return path.Join("/", svc.tr.tags.Value(tagHttpPrefix), svc.tags.Value(tagHttpPrefix), svc.tags.Value(tagHttpPath, path.Join("/", svc.jsonrpcNamespace())))
+}
+
+// batchPaths returns path of service batch and its path before renaming by jsonRPC-namespace
+func (svc service) batchPaths() (paths []string) {
+
+	paths = append(paths, svc.batchPath())
+	if namePath := path.Join("/", svc.tr.tags.Value(tagHttpPrefix), svc.tags.Value(tagHttpPrefix), svc.tags.Value(tagHttpPath, path.Join("/", svc.lcName()))); namePath != paths[0] {
+		paths = append(paths, namePath)
+	}
+	return
 }
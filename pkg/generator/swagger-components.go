@@ -16,9 +16,9 @@ import (
 	"github.com/vetcher/go-astra"
 	"github.com/vetcher/go-astra/types"
 
-	"github.com/tundrik/tg/pkg/mod"
-	"github.com/tundrik/tg/pkg/tags"
-	"github.com/tundrik/tg/pkg/utils"
+	"github.com/tundrik/tg/v2/pkg/mod"
+	"github.com/tundrik/tg/v2/pkg/tags"
+	"github.com/tundrik/tg/v2/pkg/utils"
 )
 
 func (doc *swagger) registerStruct(name, pkgPath string, mTags tags.DocTags, fields []types.StructField) {
@@ -83,7 +83,7 @@ func (doc *swagger) walkVariable(typeName, pkgPath string, varType types.Type, v
 		if nextType := doc.searchType(pkgPath, vType.TypeName); nextType != nil {
 			if doc.knownCount(vType.TypeName) == 0 {
 				doc.knownInc(vType.TypeName)
-				doc.schemas[vType.TypeName] = doc.walkVariable(typeName, pkgPath, nextType, varTags)
+				doc.schemas[vType.TypeName] = doc.withEnum(doc.walkVariable(typeName, pkgPath, nextType, varTags), pkgPath, vType.TypeName)
 			}
 		}
 	case types.TMap:
@@ -98,15 +98,40 @@ func (doc *swagger) walkVariable(typeName, pkgPath string, varType types.Type, v
 	case types.Struct:
 		schema.Type = "object"
 		schema.Properties = make(swProperties)
+		// fields declared in struct hide promoted fields of embedded structs
+		declared := make(map[string]bool)
+		for _, field := range vType.Fields {
+			if fieldName, inline := jsonName(field); !inline {
+				declared[fieldName] = true
+			}
+		}
 		for _, field := range vType.Fields {
 			if fieldName, inline := jsonName(field); fieldName != "-" {
-				embed := doc.walkVariable(field.Name, pkgPath, field.Type, tags.ParseTags(field.Docs))
-				if !inline {
+				fieldTags := tags.ParseTags(field.Docs)
+				embed := doc.walkVariable(field.Name, pkgPath, field.Type, fieldTags)
+				inlined := embed
+				if inlined.Ref != "" {
+					inlined = doc.schemas[strings.TrimPrefix(inlined.Ref, "#/components/schemas/")]
+				}
+				if !inline || inlined.Type != "object" || inlined.AdditionalProperties != nil {
 					schema.Properties[fieldName] = embed
+					if isRequired(field.Type, field.Tags["json"], fieldTags) {
+						schema.Required = append(schema.Required, fieldName)
+					}
 					continue
 				}
-				for eField, def := range doc.schemas[field.Type.String()].Properties {
-					schema.Properties[eField] = def
+				for eField, def := range inlined.Properties {
+					if !declared[eField] {
+						schema.Properties[eField] = def
+					}
+				}
+				// fields of nil embedded pointer are omitted
+				if _, isPointer := field.Type.(types.TPointer); !isPointer {
+					for _, eField := range inlined.Required {
+						if !declared[eField] {
+							schema.Required = append(schema.Required, eField)
+						}
+					}
 				}
 			}
 		}
@@ -127,7 +152,7 @@ func (doc *swagger) walkVariable(typeName, pkgPath string, varType types.Type, v
 			}
 			if doc.knownCount(vType.Next.String()) == 0 {
 				doc.knownInc(vType.Next.String())
-				doc.schemas[vType.Next.String()] = def
+				doc.schemas[vType.Next.String()] = doc.withEnum(def, vType.Import.Package, vType.Next.String())
 			}
 		}
 	case types.TEllipsis:
@@ -146,6 +171,33 @@ func (doc *swagger) walkVariable(typeName, pkgPath string, varType types.Type, v
 	return
 }
 
+// withEnum adds constants declared for named type as enum values
+func (doc *swagger) withEnum(schema swSchema, pkgPath, typeName string) swSchema {
+
+	if schema.Ref != "" || schema.Type == "object" || schema.Type == "array" {
+		return schema
+	}
+	values := searchEnum(pkgPath, typeName)
+	if len(values) == 0 {
+		return schema
+	}
+	var descriptions []string
+	for _, value := range values {
+		schema.Enum = append(schema.Enum, value.value())
+		schema.EnumNames = append(schema.EnumNames, value.Name)
+		schema.EnumDescriptions = append(schema.EnumDescriptions, value.Desc)
+		if value.Desc != "" {
+			descriptions = append(descriptions, fmt.Sprintf("* `%v` - %s", value.value(), value.Desc))
+		}
+	}
+	if len(descriptions) == 0 {
+		schema.EnumDescriptions = nil
+		return schema
+	}
+	schema.Description = strings.TrimSpace(schema.Description + "\n\n" + strings.Join(descriptions, "\n"))
+	return schema
+}
+
 func (doc *swagger) searchType(pkg, name string) (retType types.Type) {
 
 	if retType = doc.parseType(pkg, name); retType == nil {
@@ -270,20 +322,33 @@ func castType(originName string) (typeName, format string) {
 	return
 }
 
+// jsonName returns name of field in JSON, fields of embedded struct without JSON name are inlined as encoding/json does
 func jsonName(fieldInfo types.StructField) (value string, inline bool) {
 
-	if fieldInfo.Variable.Name == "" {
-		fieldInfo.Variable.Name = fieldInfo.Type.String()
-	}
-	if fieldInfo.Variable.Name[:1] != strings.ToUpper(fieldInfo.Variable.Name[:1]) {
-		return "-", false
+	embedded := fieldInfo.Variable.Name == ""
+	if embedded {
+		embedType := fieldInfo.Type
+		if pointer, isPointer := embedType.(types.TPointer); isPointer {
+			embedType = pointer.Next
+		}
+		if typeName := types.TypeName(embedType); typeName != nil {
+			fieldInfo.Variable.Name = *typeName
+		} else {
+			fieldInfo.Variable.Name = embedType.String()
+		}
 	}
 	value = fieldInfo.Name
-	if tagValues, _ := fieldInfo.Tags["json"]; len(tagValues) > 0 {
+	tagValues := fieldInfo.Tags["json"]
+	if len(tagValues) > 0 && tagValues[0] != "" {
 		value = tagValues[0]
-		if len(tagValues) == 2 {
-			inline = tagValues[1] == "inline"
-		}
+	} else if embedded {
+		inline = true
+	}
+	if len(tagValues) == 2 && tagValues[1] == "inline" {
+		inline = true
+	}
+	if !inline && fieldInfo.Variable.Name[:1] != strings.ToUpper(fieldInfo.Variable.Name[:1]) {
+		return "-", false
 	}
 	return
 }
@@ -0,0 +1,91 @@
+package generator
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+const testEmbeddedService = `package service
+
+import "context"
+
+type Base struct {
+	ID      int    ` + "`json:\"id\"`" + `
+	Created string ` + "`json:\"created\"`" + `
+}
+
+type Extra struct {
+	Note string ` + "`json:\"note\"`" + `
+}
+
+type User struct {
+	Base
+	*Extra
+	Created int    ` + "`json:\"created,omitempty\"`" + `
+	Name    string ` + "`json:\"name\"`" + `
+}
+
+// @tg jsonRPC-server log
+type Users interface {
+	Get(ctx context.Context, user User) (found User, err error)
+}
+`
+
+func TestEmbeddedStructsAreFlattened(t *testing.T) {
+
+	modDir := newTestModule(t, map[string]string{"service/service.go": testEmbeddedService})
+	chdirTestModule(t, modDir)
+	tr := newTestTransport(t, filepath.Join(modDir, "service"))
+	if err := tr.RenderSwagger(filepath.Join(modDir, "swagger.json"), ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := tr.RenderOpenRPC(filepath.Join(modDir, "openrpc.json")); err != nil {
+		t.Fatal(err)
+	}
+	if err := tr.RenderCollection(filepath.Join(modDir, "collection"), formatHTTP); err != nil {
+		t.Fatal(err)
+	}
+	if err := tr.RenderClientJS(filepath.Join(modDir, "js")); err != nil {
+		t.Fatal(err)
+	}
+	for _, fileName := range []string{"swagger.json", "openrpc.json"} {
+		var doc struct {
+			Components struct {
+				Schemas map[string]struct {
+					Properties map[string]struct {
+						Type string `json:"type"`
+					} `json:"properties"`
+					Required []string `json:"required"`
+				} `json:"schemas"`
+			} `json:"components"`
+		}
+		if err := json.Unmarshal([]byte(readTestFile(t, filepath.Join(modDir, fileName))), &doc); err != nil {
+			t.Fatal(err)
+		}
+		user := doc.Components.Schemas["User"]
+		var properties []string
+		for name := range user.Properties {
+			properties = append(properties, name)
+		}
+		sort.Strings(properties)
+		sort.Strings(user.Required)
+		if got, want := strings.Join(properties, ","), "created,id,name,note"; got != want {
+			t.Errorf("%s: properties of User %s, want %s", fileName, got, want)
+		}
+		if got, want := user.Properties["created"].Type, "number"; got != want {
+			t.Errorf("%s: type of User.created %s, want %s", fileName, got, want)
+		}
+		if got, want := strings.Join(user.Required, ","), "id,name"; got != want {
+			t.Errorf("%s: required of User %s, want %s", fileName, got, want)
+		}
+	}
+	httpFile := readTestFile(t, filepath.Join(modDir, "collection", "users.http"))
+	assertContains(t, httpFile, `"id": 0`, `"note": ""`)
+	assertNotContains(t, httpFile, `"Base"`, `"Extra"`)
+	assertContains(t, readTestFile(t, filepath.Join(modDir, "js", "jsonrpc-client.js")),
+		"@typedef {Object} User\n", "* @property {number} id\n", "* @property {string} note\n", "* @property {number} [created]\n",
+	)
+}
@@ -15,11 +15,13 @@ func jsonrpcSchema(propName string, property swSchema) (schema swSchema) {
 			"jsonrpc": swSchema{
 				Type:    "string",
 				Example: "2.0",
+				Enum:    []interface{}{"2.0"},
 			},
 		},
 	}
 
 	schema.Properties[propName] = property
+	schema.Required = []string{"id", "jsonrpc", propName}
 	return
 }
 
@@ -35,10 +37,12 @@ func jsonrpcErrorSchema() (schema swSchema) {
 			"jsonrpc": swSchema{
 				Type:    "string",
 				Example: "2.0",
+				Enum:    []interface{}{"2.0"},
 			},
 			"error": swSchema{
 				Type:     "object",
 				Nullable: true,
+				Required: []string{"code", "message"},
 				Properties: swProperties{
 					"code": swSchema{
 						Example: -32603,
@@ -56,6 +60,20 @@ func jsonrpcErrorSchema() (schema swSchema) {
 				},
 			},
 		},
+		Required: []string{"id", "jsonrpc", "error"},
+	}
+	return
+}
+
+func jsonrpcMethodSchema(method *method) (schema swSchema) {
+
+	schema = swSchema{
+		Type:    "string",
+		Example: method.jsonrpcName(),
+		Enum:    []interface{}{method.jsonrpcName()},
+	}
+	for _, alias := range method.jsonrpcAliases() {
+		schema.Enum = append(schema.Enum, alias)
 	}
 	return
 }
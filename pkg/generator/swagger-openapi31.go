@@ -0,0 +1,177 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	openapi30 = "3.0.0"
+	openapi31 = "3.1.0"
+)
+
+type swSchemaFields swSchema
+
+// MarshalJSON renders list of types for OpenAPI 3.1 schemas
+func (schema swSchema) MarshalJSON() ([]byte, error) {
+
+	if len(schema.Types) == 0 {
+		return json.Marshal(swSchemaFields(schema))
+	}
+	return json.Marshal(struct {
+		swSchemaFields
+		Type []string `json:"type"`
+	}{swSchemaFields: swSchemaFields(schema), Type: schema.Types})
+}
+
+// MarshalYAML renders list of types for OpenAPI 3.1 schemas
+func (schema swSchema) MarshalYAML() (interface{}, error) {
+
+	if len(schema.Types) == 0 {
+		return swSchemaFields(schema), nil
+	}
+	var node, typesNode yaml.Node
+	if err := node.Encode(swSchemaFields(schema)); err != nil {
+		return nil, err
+	}
+	if err := typesNode.Encode(schema.Types); err != nil {
+		return nil, err
+	}
+	// schema without other fields is encoded as empty flow mapping
+	node.Style = 0
+	node.Content = append([]*yaml.Node{{Kind: yaml.ScalarNode, Value: "type"}, &typesNode}, node.Content...)
+	return &node, nil
+}
+
+var openapiVersions = regexp.MustCompile(`^3\.[01](\.[0-9]+)?$`)
+
+func (doc *swagger) openapiVersion() string {
+
+	version, err := doc.checkOpenAPIVersion()
+	if err != nil {
+		return openapi30
+	}
+	return version
+}
+
+// checkOpenAPIVersion returns version of document given by option or openapi tag, only 3.0.x and 3.1.x are supported
+func (doc *swagger) checkOpenAPIVersion() (version string, err error) {
+
+	version = doc.openapi
+	if version == "" {
+		version = doc.tags.Value(tagOpenAPI, openapi30)
+	}
+	if !openapiVersions.MatchString(version) {
+		return "", fmt.Errorf("unsupported OpenAPI version '%s', expected 3.0, 3.0.x, 3.1 or 3.1.x", version)
+	}
+	if strings.Count(version, ".") == 1 {
+		version += ".0"
+	}
+	return
+}
+
+func (doc *swagger) isOpenAPI31() bool {
+	return strings.HasPrefix(doc.openapiVersion(), "3.1.")
+}
+
+// webhook returns name of webhook described by method, webhooks are supported by OpenAPI 3.1 only
+func (doc *swagger) webhook(method *method) string {
+
+	if !doc.isOpenAPI31() {
+		return ""
+	}
+	return method.tags.Value(tagWebhook)
+}
+
+// upgradeObject converts OpenAPI 3.0 constructs of document to JSON Schema 2020-12 used by OpenAPI 3.1
+func (doc *swagger) upgradeObject(swaggerDoc *swObject) {
+
+	for name, schema := range swaggerDoc.Components.Schemas {
+		swaggerDoc.Components.Schemas[name] = upgradeSchema(schema)
+	}
+	for _, paths := range []map[string]swPath{swaggerDoc.Paths, swaggerDoc.Webhooks} {
+		for _, pathItem := range paths {
+			for _, operation := range []*swOperation{pathItem.Get, pathItem.Post, pathItem.Patch, pathItem.Put, pathItem.Delete} {
+				if operation != nil {
+					upgradeOperation(operation)
+				}
+			}
+		}
+	}
+}
+
+func upgradeOperation(operation *swOperation) {
+
+	for i := range operation.Parameters {
+		operation.Parameters[i].Schema = upgradeSchema(operation.Parameters[i].Schema)
+	}
+	if operation.RequestBody != nil {
+		upgradeContent(operation.RequestBody.Content)
+	}
+	for _, response := range operation.Responses {
+		upgradeContent(response.Content)
+		for name, header := range response.Headers {
+			header.Schema = upgradeSchema(header.Schema)
+			response.Headers[name] = header
+		}
+	}
+}
+
+func upgradeContent(content swContent) {
+
+	for mime, media := range content {
+		media.Schema = upgradeSchema(media.Schema)
+		content[mime] = media
+	}
+}
+
+func upgradeSchema(schema swSchema) swSchema {
+
+	if schema.Nullable {
+		schema.Nullable = false
+		switch {
+		case schema.Type != "":
+			schema.Types = []string{schema.Type, "null"}
+			schema.Type = ""
+		case schema.Ref != "":
+			schema.OneOf = []swSchema{{Ref: schema.Ref}, {Type: "null"}}
+			schema.Ref = ""
+		case len(schema.OneOf) != 0:
+			schema.OneOf = append(schema.OneOf, swSchema{Type: "null"})
+		}
+	}
+	if schema.Example != nil {
+		schema.Examples = []interface{}{schema.Example}
+		schema.Example = nil
+	}
+	if len(schema.Enum) == 1 {
+		schema.Const = schema.Enum[0]
+		schema.Enum = nil
+	}
+	if len(schema.Properties) != 0 {
+		properties := make(swProperties, len(schema.Properties))
+		for name, property := range schema.Properties {
+			properties[name] = upgradeSchema(property)
+		}
+		schema.Properties = properties
+	}
+	if schema.Items != nil {
+		items := upgradeSchema(*schema.Items)
+		schema.Items = &items
+	}
+	if len(schema.OneOf) != 0 {
+		oneOf := make([]swSchema, 0, len(schema.OneOf))
+		for _, item := range schema.OneOf {
+			oneOf = append(oneOf, upgradeSchema(item))
+		}
+		schema.OneOf = oneOf
+	}
+	if additional, ok := schema.AdditionalProperties.(swSchema); ok {
+		schema.AdditionalProperties = upgradeSchema(additional)
+	}
+	return schema
+}
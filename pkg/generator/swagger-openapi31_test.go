@@ -0,0 +1,35 @@
+package generator
+
+import (
+	"testing"
+)
+
+func TestOpenAPIVersion(t *testing.T) {
+
+	for openapi, expected := range map[string]string{
+		"":      openapi30,
+		"3.0":   "3.0.0",
+		"3.0.3": "3.0.3",
+		"3.1":   "3.1.0",
+		"3.1.1": "3.1.1",
+	} {
+		doc := newSwagger(&Transport{})
+		doc.openapi = openapi
+		version, err := doc.checkOpenAPIVersion()
+		if err != nil || version != expected {
+			t.Errorf("version %q: got %q, %v, expected %q", openapi, version, err, expected)
+		}
+	}
+	for _, openapi := range []string{"3", "3.2", "3.1foo", "2.0", "3.1.x"} {
+		doc := newSwagger(&Transport{})
+		doc.openapi = openapi
+		if _, err := doc.checkOpenAPIVersion(); err == nil {
+			t.Errorf("version %q is accepted", openapi)
+		}
+	}
+	doc := newSwagger(&Transport{})
+	doc.openapi = "3.0.3"
+	if doc.isOpenAPI31() {
+		t.Error("3.0.3 is treated as OpenAPI 3.1")
+	}
+}
@@ -0,0 +1,116 @@
+package generator
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/tundrik/tg/v2/pkg/utils"
+)
+
+// codeSamples renders curl, Go and JS client usage of method for x-code-samples
+func (doc *swagger) codeSamples(svc *service, method *method) (samples []swCodeSample) {
+
+	c := &collection{Transport: doc.Transport, doc: doc}
+	var request collectionRequest
+	if method.isJsonRPC() {
+		request = c.jsonrpcRequest(svc, method)
+	} else {
+		request = c.restRequest(svc, method)
+	}
+	samples = append(samples, swCodeSample{Lang: "curl", Source: c.curl(request)})
+	for _, clientMethod := range svc.clientMethods() {
+		if clientMethod == method {
+			samples = append(samples,
+				swCodeSample{Lang: "Go", Source: c.goSample(svc, method)},
+				swCodeSample{Lang: "JavaScript", Source: c.jsSample(svc, method)},
+			)
+			break
+		}
+	}
+	return
+}
+
+func (c *collection) curl(request collectionRequest) string {
+
+	placeholder := func(name string) string { return "{" + name + "}" }
+
+	lines := []string{fmt.Sprintf("curl -X %s %s", request.method, shellQuote(request.url(c.baseURL(), placeholder)))}
+	for _, param := range request.headers {
+		lines = append(lines, "-H "+shellQuote(param.name+": "+param.valueOr(placeholder)))
+	}
+	if len(request.cookies) != 0 {
+		lines = append(lines, "-b "+shellQuote(request.cookie(placeholder)))
+	}
+	if len(request.uploads) != 0 {
+		for _, upload := range request.uploads {
+			lines = append(lines, "-F "+shellQuote(upload+"=@"+upload))
+		}
+	} else if request.body != nil {
+		lines = append(lines, "-H "+shellQuote("Content-Type: "+contentJSON), "-d "+shellQuote(request.bodyText()))
+	}
+	return strings.Join(lines, " \\\n  ")
+}
+
+var (
+	majorVersion  = regexp.MustCompile(`^v[0-9]+$`)
+	nonIdentifier = regexp.MustCompile(`[^a-zA-Z0-9]+`)
+)
+
+// goSample renders call of method by Go client, client package is imported by client-module annotation
+func (c *collection) goSample(svc *service, method *method) string {
+
+	var args []string
+	for _, arg := range method.argsWithoutContext() {
+		args = append(args, utils.ToLowerCamel(arg.Name))
+	}
+	var results []string
+	for _, ret := range method.resultsWithoutError() {
+		results = append(results, utils.ToLowerCamel(ret.Name))
+	}
+	results = append(results, "err")
+
+	var sample bytesWriter
+	pkgName := "client"
+	if modulePath := c.tags.Value(tagClientModule); modulePath != "" {
+		pkgName = clientPkgName(modulePath)
+		sample.add("import %s %q\n\n", pkgName, modulePath)
+	}
+	sample.add("cli := %s.New(%q, zerolog.New(os.Stderr), %q)\n", pkgName, pkgName, c.baseURL())
+	sample.add("%s := cli.%s().%s(%s)\n", strings.Join(results, ", "), svc.Name, method.Name, strings.Join(append([]string{"ctx"}, args...), ", "))
+	return sample.String()
+}
+
+// clientPkgName returns identifier of client package imported by module path, major version suffix is skipped
+func clientPkgName(modulePath string) string {
+
+	elems := strings.Split(modulePath, "/")
+	name := elems[len(elems)-1]
+	if len(elems) > 1 && majorVersion.MatchString(name) {
+		name = elems[len(elems)-2]
+	}
+	name = strings.ToLower(nonIdentifier.ReplaceAllString(name, ""))
+	if name == "" || unicode.IsDigit(rune(name[0])) {
+		name = "client" + name
+	}
+	return name
+}
+
+func (c *collection) jsSample(svc *service, method *method) string {
+
+	js := &clientJS{Transport: c.Transport}
+	var args []string
+	for _, arg := range js.methodArgs(method) {
+		args = append(args, utils.ToLowerCamel(arg.Name))
+	}
+
+	var sample bytesWriter
+	sample.add("const client = new JSONRPCClient(%q);\n", c.baseURL()+"/")
+	sample.add("const result = await client.%s.%s(%s);\n", svc.lccName(), method.lccName(), strings.Join(args, ", "))
+	return sample.String()
+}
+
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
@@ -0,0 +1,40 @@
+package generator
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestGoSampleUsesClientModule(t *testing.T) {
+
+	modDir := newTestModule(t, map[string]string{
+		"service/service.go": `// @tg client-module=example.com/acme/users-client/v2
+package service
+
+import "context"
+
+// @tg jsonRPC-server log
+type Users interface {
+	Get(ctx context.Context, id int) (name string, err error)
+}
+`,
+	})
+	tr := newTestTransport(t, filepath.Join(modDir, "service"))
+	if err := tr.RenderSwagger(filepath.Join(modDir, "swagger.json"), ""); err != nil {
+		t.Fatal(err)
+	}
+	assertContains(t, readTestFile(t, filepath.Join(modDir, "swagger.json")),
+		`import usersclient \"example.com/acme/users-client/v2\"`,
+		`cli := usersclient.New(\"usersclient\", zerolog.New(os.Stderr)`,
+	)
+	for modulePath, pkgName := range map[string]string{
+		"example.com/client":   "client",
+		"example.com/api/v3":   "api",
+		"example.com/2fa-sdk":  "client2fasdk",
+		"example.com/Acme.SDK": "acmesdk",
+	} {
+		if name := clientPkgName(modulePath); name != pkgName {
+			t.Errorf("%s: package name %s, want %s", modulePath, name, pkgName)
+		}
+	}
+}
@@ -4,13 +4,15 @@
 package generator
 
 type swObject struct {
-	OpenAPI    string            `json:"openapi" yaml:"openapi"`
-	Info       swInfo            `json:"info,omitempty" yaml:"info,omitempty"`
-	Servers    []swServer        `json:"servers,omitempty" yaml:"servers,omitempty"`
-	Tags       []swTag           `json:"tags,omitempty" yaml:"tags,omitempty"`
-	Schemes    []string          `json:"schemes,omitempty" yaml:"schemes,omitempty"`
-	Paths      map[string]swPath `json:"paths" yaml:"paths"`
-	Components swComponents      `json:"components,omitempty" yaml:"components,omitempty"`
+	OpenAPI    string                  `json:"openapi" yaml:"openapi"`
+	Info       swInfo                  `json:"info,omitempty" yaml:"info,omitempty"`
+	Servers    []swServer              `json:"servers,omitempty" yaml:"servers,omitempty"`
+	Tags       []swTag                 `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Schemes    []string                `json:"schemes,omitempty" yaml:"schemes,omitempty"`
+	Paths      map[string]swPath       `json:"paths" yaml:"paths"`
+	Security   []swSecurityRequirement `json:"security,omitempty" yaml:"security,omitempty"`
+	Webhooks   map[string]swPath       `json:"webhooks,omitempty" yaml:"webhooks,omitempty"`
+	Components swComponents            `json:"components,omitempty" yaml:"components,omitempty"`
 }
 
 type swPath struct {
@@ -25,18 +27,19 @@ type swPath struct {
 }
 
 type swOperation struct {
-	Tags        []string       `json:"tags,omitempty" yaml:"tags,omitempty"`
-	Summary     string         `json:"summary,omitempty" yaml:"summary,omitempty"`
-	Description string         `json:"description,omitempty" yaml:"description,omitempty"`
-	OperationID string         `json:"operationId,omitempty" yaml:"operationId,omitempty"`
-	Consumes    []string       `json:"consumes,omitempty" yaml:"consumes,omitempty"`
-	Produces    []string       `json:"produces,omitempty" yaml:"produces,omitempty"`
-	Parameters  []swParameter  `json:"parameters,omitempty" yaml:"parameters,omitempty"`
-	RequestBody *swRequestBody `json:"requestBody,omitempty" yaml:"requestBody,omitempty"`
-	Responses   swResponses    `json:"responses,omitempty" yaml:"responses,omitempty"`
-	Deprecated  bool           `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
-	Servers     []swServer     `json:"servers,omitempty" yaml:"servers,omitempty"`
-	CodeSamples []swCodeSample `json:"x-code-samples,omitempty" yaml:"x-code-samples,omitempty"`
+	Tags        []string                 `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Summary     string                   `json:"summary,omitempty" yaml:"summary,omitempty"`
+	Description string                   `json:"description,omitempty" yaml:"description,omitempty"`
+	OperationID string                   `json:"operationId,omitempty" yaml:"operationId,omitempty"`
+	Consumes    []string                 `json:"consumes,omitempty" yaml:"consumes,omitempty"`
+	Produces    []string                 `json:"produces,omitempty" yaml:"produces,omitempty"`
+	Parameters  []swParameter            `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	RequestBody *swRequestBody           `json:"requestBody,omitempty" yaml:"requestBody,omitempty"`
+	Responses   swResponses              `json:"responses,omitempty" yaml:"responses,omitempty"`
+	Deprecated  bool                     `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
+	Servers     []swServer               `json:"servers,omitempty" yaml:"servers,omitempty"`
+	CodeSamples []swCodeSample           `json:"x-code-samples,omitempty" yaml:"x-code-samples,omitempty"`
+	Security    *[]swSecurityRequirement `json:"security,omitempty" yaml:"security,omitempty"`
 }
 
 type swCodeSample struct {
@@ -86,24 +89,58 @@ type swSchemas map[string]swSchema
 type swProperties map[string]swSchema
 
 type swComponents struct {
-	Schemas swSchemas `json:"schemas,omitempty" yaml:"schemas,omitempty"`
+	Schemas         swSchemas                   `json:"schemas,omitempty" yaml:"schemas,omitempty"`
+	SecuritySchemes map[string]swSecurityScheme `json:"securitySchemes,omitempty" yaml:"securitySchemes,omitempty"`
 }
 
+type swSecurityScheme struct {
+	Type             string        `json:"type" yaml:"type"`
+	Description      string        `json:"description,omitempty" yaml:"description,omitempty"`
+	Name             string        `json:"name,omitempty" yaml:"name,omitempty"`
+	In               string        `json:"in,omitempty" yaml:"in,omitempty"`
+	Scheme           string        `json:"scheme,omitempty" yaml:"scheme,omitempty"`
+	BearerFormat     string        `json:"bearerFormat,omitempty" yaml:"bearerFormat,omitempty"`
+	Flows            *swOAuthFlows `json:"flows,omitempty" yaml:"flows,omitempty"`
+	OpenIDConnectURL string        `json:"openIdConnectUrl,omitempty" yaml:"openIdConnectUrl,omitempty"`
+}
+
+type swOAuthFlows struct {
+	Implicit          *swOAuthFlow `json:"implicit,omitempty" yaml:"implicit,omitempty"`
+	Password          *swOAuthFlow `json:"password,omitempty" yaml:"password,omitempty"`
+	ClientCredentials *swOAuthFlow `json:"clientCredentials,omitempty" yaml:"clientCredentials,omitempty"`
+	AuthorizationCode *swOAuthFlow `json:"authorizationCode,omitempty" yaml:"authorizationCode,omitempty"`
+}
+
+type swOAuthFlow struct {
+	AuthorizationURL string            `json:"authorizationUrl,omitempty" yaml:"authorizationUrl,omitempty"`
+	TokenURL         string            `json:"tokenUrl,omitempty" yaml:"tokenUrl,omitempty"`
+	Scopes           map[string]string `json:"scopes" yaml:"scopes"`
+}
+
+type swSecurityRequirement map[string][]string
+
 type swSchema struct {
-	Ref         string       `json:"$ref,omitempty" yaml:"$ref,omitempty"`
-	Type        string       `json:"type,omitempty" yaml:"type,omitempty"`
-	Format      string       `json:"format,omitempty" yaml:"format,omitempty"`
-	Minimum     int          `json:"minimum,omitempty" yaml:"minimum,omitempty"`
-	Maximum     int          `json:"maximum,omitempty" yaml:"maximum,omitempty"`
-	Properties  swProperties `json:"properties,omitempty" yaml:"properties,omitempty"`
-	Items       *swSchema    `json:"items,omitempty" yaml:"items,omitempty"`
-	Enum        []string     `json:"enum,omitempty" yaml:"enum,omitempty"`
-	Nullable    bool         `json:"nullable,omitempty" yaml:"nullable,omitempty"`
-	Example     interface{}  `json:"example,omitempty" yaml:"example,omitempty"`
-	Description string       `json:"description,omitempty" yaml:"description,omitempty"`
+	Ref         string        `json:"$ref,omitempty" yaml:"$ref,omitempty"`
+	Type        string        `json:"type,omitempty" yaml:"type,omitempty"`
+	Types       []string      `json:"-" yaml:"-"`
+	Format      string        `json:"format,omitempty" yaml:"format,omitempty"`
+	Minimum     int           `json:"minimum,omitempty" yaml:"minimum,omitempty"`
+	Maximum     int           `json:"maximum,omitempty" yaml:"maximum,omitempty"`
+	Properties  swProperties  `json:"properties,omitempty" yaml:"properties,omitempty"`
+	Required    []string      `json:"required,omitempty" yaml:"required,omitempty"`
+	Items       *swSchema     `json:"items,omitempty" yaml:"items,omitempty"`
+	Enum        []interface{} `json:"enum,omitempty" yaml:"enum,omitempty"`
+	Nullable    bool          `json:"nullable,omitempty" yaml:"nullable,omitempty"`
+	Example     interface{}   `json:"example,omitempty" yaml:"example,omitempty"`
+	Examples    []interface{} `json:"examples,omitempty" yaml:"examples,omitempty"`
+	Const       interface{}   `json:"const,omitempty" yaml:"const,omitempty"`
+	Description string        `json:"description,omitempty" yaml:"description,omitempty"`
 
 	OneOf []swSchema `json:"oneOf,omitempty" yaml:"oneOf,omitempty"`
 
+	EnumNames        []string `json:"x-enum-varnames,omitempty" yaml:"x-enum-varnames,omitempty"`
+	EnumDescriptions []string `json:"x-enum-descriptions,omitempty" yaml:"x-enum-descriptions,omitempty"`
+
 	AdditionalProperties interface{} `json:"additionalProperties,omitempty" yaml:"additionalProperties,omitempty"`
 }
 
@@ -5,6 +5,7 @@ package generator
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -77,3 +78,15 @@ var statusText = map[int]string{
 	fiber.StatusNotExtended:                   "Not Extended",
 	fiber.StatusNetworkAuthenticationRequired: "Network Authentication Required",
 }
+
+// swaggerPath converts path parameters of router (:name) to OpenAPI templates ({name})
+func swaggerPath(urlPath string) string {
+
+	tokens := strings.Split(urlPath, "/")
+	for i, token := range tokens {
+		if strings.HasPrefix(token, ":") {
+			tokens[i] = "{" + strings.TrimPrefix(token, ":") + "}"
+		}
+	}
+	return strings.Join(tokens, "/")
+}
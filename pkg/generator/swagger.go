@@ -16,8 +16,8 @@ import (
 	"github.com/valyala/fasthttp"
 	"gopkg.in/yaml.v3"
 
-	"github.com/tundrik/tg/pkg/tags"
-	"github.com/tundrik/tg/pkg/utils"
+	"github.com/tundrik/tg/v2/pkg/tags"
+	"github.com/tundrik/tg/v2/pkg/utils"
 )
 
 const (
@@ -28,6 +28,7 @@ const (
 type swagger struct {
 	*Transport
 
+	openapi    string
 	schemas    swSchemas
 	aliasTypes map[string]int
 	knownTypes map[string]int
@@ -49,13 +50,31 @@ func (doc *swagger) render(outFilePath string) (err error) {
 	if err = os.MkdirAll(filepath.Dir(outFilePath), 0777); err != nil {
 		return
 	}
+	var docData []byte
+	swaggerDoc := doc.document()
+	if strings.ToLower(filepath.Ext(outFilePath)) == ".json" {
+		if docData, err = json.MarshalIndent(swaggerDoc, " ", "    "); err != nil {
+			return
+		}
+	} else {
+		if docData, err = yaml.Marshal(swaggerDoc); err != nil {
+			return
+		}
+	}
+	doc.log.Info("write to ", outFilePath)
+	return ioutil.WriteFile(outFilePath, docData, 0600)
+}
 
-	var swaggerDoc swObject
-	swaggerDoc.OpenAPI = "3.0.0"
+func (doc *swagger) document() (swaggerDoc swObject) {
+
+	swaggerDoc.OpenAPI = doc.openapiVersion()
 	swaggerDoc.Info.Title = doc.tags.Value("title")
 	swaggerDoc.Info.Version = doc.tags.Value("version")
 	swaggerDoc.Info.Description = doc.tags.Value("description")
 	swaggerDoc.Paths = make(map[string]swPath)
+	if doc.isOpenAPI31() {
+		swaggerDoc.Webhooks = make(map[string]swPath)
+	}
 	tagServers := strings.Split(doc.tags.Value("servers"), "|")
 	for _, tagServer := range tagServers {
 		var serverDesc string
@@ -72,8 +91,9 @@ func (doc *swagger) render(outFilePath string) (err error) {
 		serviceTags = strings.Split(service.tags.Value(tagSwaggerTags, service.Name), ",")
 		doc.log.WithField("module", "swagger").Infof("service %s append jsonRPC methods", serviceTags)
 		for _, method := range service.methods {
+			methodTags := serviceTags
 			if method.tags.Contains(tagSwaggerTags) {
-				serviceTags = strings.Split(method.tags.Value(tagSwaggerTags), ",")
+				methodTags = strings.Split(method.tags.Value(tagSwaggerTags), ",")
 			}
 			successCode := method.tags.ValueInt(tagHttpSuccess, fasthttp.StatusOK)
 
@@ -87,7 +107,7 @@ func (doc *swagger) render(outFilePath string) (err error) {
 					parameters = append(parameters, swParameter{
 						In:       "header",
 						Name:     headerKey,
-						Required: true,
+						Required: isRequired(arg.Type, nil, method.tags.Sub(arg.Name)),
 						Schema:   doc.walkVariable(arg.Name, service.pkgPath, arg.Type, nil),
 					})
 				}
@@ -118,12 +138,22 @@ func (doc *swagger) render(outFilePath string) (err error) {
 					}
 				}
 			}
+			for _, argName := range sortedKeys(method.argParamMap()) {
+				if arg := method.argByName(argName); arg != nil {
+					parameters = append(parameters, swParameter{
+						In:       "query",
+						Name:     method.argParamMap()[argName],
+						Required: isRequired(arg.Type, nil, method.tags.Sub(arg.Name)),
+						Schema:   doc.walkVariable(arg.Name, service.pkgPath, arg.Type, nil),
+					})
+				}
+			}
 			for argName, cookieName := range method.varCookieMap() {
 				if arg := method.argByName(argName); arg != nil {
 					parameters = append(parameters, swParameter{
 						In:       "cookie",
 						Name:     cookieName,
-						Required: true,
+						Required: isRequired(arg.Type, nil, method.tags.Sub(arg.Name)),
 						Schema:   doc.walkVariable(arg.Name, service.pkgPath, arg.Type, nil),
 					})
 				}
@@ -139,15 +169,23 @@ func (doc *swagger) render(outFilePath string) (err error) {
 				}
 			}
 			if service.tags.Contains(tagServerJsonRPC) && !method.tags.Contains(tagMethodHTTP) {
+				requestSchema := jsonrpcSchema("params", swSchema{Ref: "#/components/schemas/" + method.requestStructName()})
+				requestSchema.Properties["method"] = jsonrpcMethodSchema(method)
+				requestSchema.Required = []string{"id", "jsonrpc", "method"}
+				if len(doc.schemas[method.requestStructName()].Required) != 0 {
+					requestSchema.Required = append(requestSchema.Required, "params")
+				}
 				postMethod := &swOperation{
 					Summary:     method.tags.Value(tagSummary),
 					Description: method.tags.Value(tagDesc),
 					Parameters:  parameters,
-					Tags:        serviceTags,
+					Tags:        methodTags,
 					Deprecated:  method.tags.Contains(tagDeprecated),
+					CodeSamples: doc.codeSamples(service, method),
+					Security:    doc.operationSecurity(method),
 					RequestBody: &swRequestBody{
 						Content: swContent{
-							contentJSON: swMedia{Schema: jsonrpcSchema("params", swSchema{Ref: "#/components/schemas/" + method.requestStructName()})},
+							contentJSON: swMedia{Schema: requestSchema},
 						},
 					},
 					Responses: swResponses{
@@ -166,12 +204,17 @@ func (doc *swagger) render(outFilePath string) (err error) {
 						},
 					},
 				}
-				swaggerDoc.Paths[method.jsonrpcPath()] = swPath{Post: postMethod}
+				if webhook := doc.webhook(method); webhook != "" {
+					swaggerDoc.Webhooks[webhook] = swPath{Post: postMethod}
+				} else {
+					swaggerDoc.Paths[method.jsonrpcPath()] = swPath{Post: postMethod}
+				}
 			} else if service.tags.Contains(tagServerHTTP) && method.tags.Contains(tagMethodHTTP) {
-				doc.log.WithField("module", "swagger").Infof("service %s append HTTP method %s", serviceTags, method.Name)
-				httpValue, found := swaggerDoc.Paths[method.jsonrpcPath()]
+				doc.log.WithField("module", "swagger").Infof("service %s append HTTP method %s", methodTags, method.Name)
+				httpPath := swaggerPath(method.httpPath())
+				httpValue, found := swaggerDoc.Paths[httpPath]
 				if !found {
-					swaggerDoc.Paths[method.httpPath()] = swPath{}
+					swaggerDoc.Paths[httpPath] = swPath{}
 				}
 				requestContentType := contentJSON
 				responseContentType := contentJSON
@@ -182,8 +225,10 @@ func (doc *swagger) render(outFilePath string) (err error) {
 					Summary:     method.tags.Value(tagSummary),
 					Description: method.tags.Value(tagDesc),
 					Parameters:  parameters,
-					Tags:        serviceTags,
+					Tags:        methodTags,
 					Deprecated:  method.tags.Contains(tagDeprecated),
+					CodeSamples: doc.codeSamples(service, method),
+					Security:    doc.operationSecurity(method),
 					RequestBody: &swRequestBody{
 						Content: doc.clearContent(swContent{
 							requestContentType: swMedia{Schema: swSchema{Ref: "#/components/schemas/" + method.requestStructName()}},
@@ -205,24 +250,27 @@ func (doc *swagger) render(outFilePath string) (err error) {
 				if httpMethod.RequestBody.Content == nil {
 					httpMethod.RequestBody = nil
 				}
+				if webhook := doc.webhook(method); webhook != "" {
+					webhookValue := swaggerDoc.Webhooks[webhook]
+					reflect.ValueOf(&webhookValue).Elem().FieldByName(utils.ToCamel(strings.ToLower(method.httpMethod()))).Set(reflect.ValueOf(httpMethod))
+					swaggerDoc.Webhooks[webhook] = webhookValue
+					if swaggerDoc.Paths[httpPath] == (swPath{}) {
+						delete(swaggerDoc.Paths, httpPath)
+					}
+					continue
+				}
 				reflect.ValueOf(&httpValue).Elem().FieldByName(utils.ToCamel(strings.ToLower(method.httpMethod()))).Set(reflect.ValueOf(httpMethod))
-				swaggerDoc.Paths[method.httpPath()] = httpValue
+				swaggerDoc.Paths[httpPath] = httpValue
 			}
 		}
 	}
-	var docData []byte
 	swaggerDoc.Components.Schemas = doc.schemas
-	if strings.ToLower(filepath.Ext(outFilePath)) == ".json" {
-		if docData, err = json.MarshalIndent(swaggerDoc, " ", "    "); err != nil {
-			return
-		}
-	} else {
-		if docData, err = yaml.Marshal(swaggerDoc); err != nil {
-			return
-		}
+	swaggerDoc.Components.SecuritySchemes = doc.securitySchemes()
+	swaggerDoc.Security, _ = securityRequirements(doc.tags)
+	if doc.isOpenAPI31() {
+		doc.upgradeObject(&swaggerDoc)
 	}
-	doc.log.Info("write to ", outFilePath)
-	return ioutil.WriteFile(outFilePath, docData, 0600)
+	return
 }
 
 func (doc *swagger) fillErrors(responses swResponses, tags tags.DocTags) {
@@ -36,5 +36,14 @@ func (tr Transport) renderHTTP(outDir string) (err error) {
 		Return(Qual(packageIOUtil, "ReadAll").Call(Id("file"))),
 	)
 
+	srcFile.Line().Comment("pathParam returns value of path parameter, escaped by client")
+	srcFile.Func().Id("pathParam").Params(Id(_ctx_).Op("*").Qual(packageFiber, "Ctx"), Id("key").String()).Params(Id("value").String()).Block(
+		Id("value").Op("=").Id(_ctx_).Dot("Params").Call(Id("key")),
+		If(List(Id("unescaped"), Err()).Op(":=").Qual(packageURL, "PathUnescape").Call(Id("value")).Op(";").Err().Op("==").Nil()).Block(
+			Id("value").Op("=").Id("unescaped"),
+		),
+		Return(),
+	)
+
 	return srcFile.Save(path.Join(outDir, "http.go"))
 }
@@ -9,7 +9,7 @@ import (
 
 	. "github.com/dave/jennifer/jen"
 
-	"github.com/tundrik/tg/pkg/utils"
+	"github.com/tundrik/tg/v2/pkg/utils"
 )
 
 func (tr Transport) renderJsonRPC(outDir string) (err error) {
@@ -33,11 +33,34 @@ func (tr Transport) renderJsonRPC(outDir string) (err error) {
 	if hasTrace {
 		srcFile.Type().Id("methodTraceJsonRPC").Func().Params(Id("span").Qual(packageOpentracing, "Span"), Id(_ctx_).Op("*").Qual(packageFiber, "Ctx"), Id("requestBase").Id("baseJsonRPC")).Params(Id("responseBase").Op("*").Id("baseJsonRPC"))
 	}
+	if tr.hasAliases() {
+		srcFile.Line().Add(tr.jsonrpcAliasesVar())
+	}
 	srcFile.Add(tr.serveBatchFunc(hasTrace))
 	srcFile.Line().Add(tr.makeErrorResponseJsonRPCFunc())
 	return srcFile.Save(path.Join(outDir, "jsonrpc.go"))
 }
 
+func (tr Transport) jsonrpcAliasesVar() Code {
+
+	return Comment("jsonrpcAliases maps deprecated method names to actual ones").
+		Line().Var().Id("jsonrpcAliases").Op("=").Map(String()).String().Values(DictFunc(func(d Dict) {
+		for _, serviceName := range tr.serviceKeys() {
+			for _, method := range tr.services[serviceName].methods {
+				if !method.isJsonRPC() {
+					continue
+				}
+				for _, alias := range method.jsonrpcAliases() {
+					d[Lit(alias)] = Lit(method.jsonrpcName())
+				}
+				if legacyName := method.jsonrpcLegacyName(); legacyName != "" {
+					d[Lit(legacyName)] = Lit(method.jsonrpcName())
+				}
+			}
+		}
+	}))
+}
+
 func (tr Transport) serveBatchFunc(hasTrace bool) Code {
 
 	return Func().Params(Id("srv").Op("*").Id("Server")).Id("serveBatch").Params(Id(_ctx_).Op("*").Qual(packageFiber, "Ctx")).Params(Err().Error()).BlockFunc(func(bg *Group) {
@@ -78,12 +101,31 @@ func (tr Transport) serveBatchFunc(hasTrace bool) Code {
 			ig.Id("single").Op("=").True()
 			ig.Id("requests").Op("=").Append(Id("requests"), Id("request"))
 		})
+		bg.If(Err().Op("=").Id("srv").Dot("limits").Dot("checkBatch").Call(Len(Id("requests"))).Op(";").Err().Op("!=").Nil()).BlockFunc(func(ig *Group) {
+			if hasTrace {
+				ig.Qual(packageOpentracingExt, "Error").Dot("Set").Call(Id("batchSpan"), True())
+				ig.Id("batchSpan").Dot("SetTag").Call(Lit("msg"), Err().Dot("Error").Call())
+			}
+			ig.Return().Id("sendResponse").Call(Id("srv").Dot("log"), Id(_ctx_), Id("makeErrorResponseJsonRPC").Call(Op("[]").Byte().Call(Lit("null")), Id("invalidRequestError"), Err().Dot("Error").Call(), Nil()))
+		})
 		bg.Id("responses").Op(":=").Make(Id("jsonrpcResponses"), Lit(0), Len(Id("requests")))
 		bg.Var().Id("n").Int()
 		bg.Var().Id("wg").Qual(packageSync, "WaitGroup")
 		bg.For(List(Id("_"), Id("request")).Op(":=").Range().Id("requests")).Block(
 			Id("methodNameOrigin").Op(":=").Id("request").Dot("Method"),
 			Id("method").Op(":=").Qual(packageStrings, "ToLower").Call(Id("request").Dot("Method")),
+			Do(func(s *Statement) {
+				if tr.hasAliases() {
+					s.If(List(Id("name"), Id("found")).Op(":=").Id("jsonrpcAliases").Index(Id("method")).Op(";").Id("found")).Block(
+						Id("srv").Dot("log").Dot("Warn").Call().Dot("Str").Call(Lit("alias"), Id("methodNameOrigin")).Dot("Str").Call(Lit("method"), Id("name")).Dot("Msg").Call(Lit("deprecated jsonRPC method alias called")),
+						Id("method").Op("=").Id("name"),
+					)
+				}
+			}),
+			If(Id("limitErr").Op(":=").Id("srv").Dot("limits").Dot("checkRequest").Call(Id("method"), Id("request"), Op("!").Id("single")).Op(";").Id("limitErr").Op("!=").Nil()).Block(
+				Id("responses").Dot("append").Call(Id("makeErrorResponseJsonRPC").Call(Id("request").Dot("ID"), Id("invalidRequestError"), Id("limitErr").Dot("Error").Call(), Nil())),
+				Continue(),
+			),
 			Switch(Id("method")).BlockFunc(func(bg *Group) {
 				for _, serviceName := range tr.serviceKeys() {
 					svc := tr.services[serviceName]
@@ -91,7 +133,7 @@ func (tr Transport) serveBatchFunc(hasTrace bool) Code {
 						if !method.isJsonRPC() {
 							continue
 						}
-						bg.Line().Case(Lit(svc.lcName()+"."+method.lcName())).Block(
+						bg.Line().Case(Lit(method.jsonrpcName())).Block(
 							Id("wg").Dot("Add").Call(Lit(1)),
 							Go().Func().Params(Id("request").Id("baseJsonRPC")).BlockFunc(func(gg *Group) {
 								if svc.tags.IsSet(tagTrace) {
@@ -118,6 +160,15 @@ func (tr Transport) serveBatchFunc(hasTrace bool) Code {
 						)
 					}
 				}
+				if tr.hasDiscover() {
+					bg.Line().Case(Lit(rpcDiscoverMethod)).Block(
+						Id("responses").Dot("append").Call(Op("&").Id("baseJsonRPC").Values(Dict{
+							Id("ID"):      Id("request").Dot("ID"),
+							Id("Version"): Id("Version"),
+							Id("Result"):  Qual(packageJson, "RawMessage").Call(Id("openrpcDocument")),
+						})),
+					)
+				}
 				bg.Default().BlockFunc(func(dg *Group) {
 					if hasTrace {
 						dg.Id("span").Op(":=").Qual(packageOpentracing, "StartSpan").Call(Id("request").Dot("Method"), Qual(packageOpentracing, "ChildOf").Call(Id("batchSpan").Dot("Context").Call()))
@@ -185,6 +236,7 @@ func (tr Transport) baseJsonRPC(isClient bool) Code {
 
 		if isClient {
 			tg.Line().Id("retHandler").Func().Params(Id("baseJsonRPC"))
+			tg.Id("idempotent").Bool()
 		}
 	})
 }
@@ -0,0 +1,132 @@
+package generator
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+
+	. "github.com/dave/jennifer/jen"
+)
+
+func (tr Transport) renderLimits(outDir string) (err error) {
+
+	srcFile := newSrc(filepath.Base(outDir))
+	srcFile.PackageComment(doNotEdit)
+
+	hasMetrics := tr.hasMetrics()
+
+	srcFile.Line().Const().Op("(").
+		Line().Comment("defaultMaxBatchSize defines the maximum number of calls in one batch, 0 means unlimited").
+		Line().Id("defaultMaxBatchSize").Op("=").Lit(tr.tags.ValueInt(tagBatchSize, 0)).
+		Line().Comment("defaultMaxParamsSize defines the maximum size of params of one call in bytes, 0 means unlimited").
+		Line().Id("defaultMaxParamsSize").Op("=").Lit(tr.tags.ValueInt(tagParamsSize, 0)).
+		Line().Op(")")
+
+	srcFile.Line().Var().Id("jsonrpcMethods").Op("=").Map(String()).Index().String().Values(DictFunc(func(d Dict) {
+		for _, serviceName := range tr.serviceKeys() {
+			svc := tr.services[serviceName]
+			if !svc.isJsonRPC() {
+				continue
+			}
+			d[Lit(svc.jsonrpcNamespace())] = Index().String().ValuesFunc(func(vg *Group) {
+				for _, method := range svc.methods {
+					if method.isJsonRPC() {
+						vg.Lit(method.jsonrpcName())
+					}
+				}
+			})
+		}
+	}))
+
+	srcFile.Line().Type().Id("jsonrpcLimits").Struct(
+		Id("maxBatchSize").Int(),
+		Id("maxParamsSize").Int(),
+		Id("batchMethods").Map(String()).Bool(),
+	)
+
+	srcFile.Line().Func().Id("newJsonRPCLimits").Params().Params(Id("limits").Op("*").Id("jsonrpcLimits")).BlockFunc(func(bg *Group) {
+		bg.Id("limits").Op("=").Op("&").Id("jsonrpcLimits").Values(Dict{
+			Id("maxBatchSize"):  Id("defaultMaxBatchSize"),
+			Id("maxParamsSize"): Id("defaultMaxParamsSize"),
+			Id("batchMethods"):  Make(Map(String()).Bool()),
+		})
+		for _, serviceName := range tr.serviceKeys() {
+			svc := tr.services[serviceName]
+			if !svc.isJsonRPC() || !svc.tags.IsSet(tagBatchMethods) {
+				continue
+			}
+			bg.Id("limits").Dot("allowBatch").CallFunc(func(cg *Group) {
+				cg.Lit(svc.jsonrpcNamespace())
+				for _, method := range svc.batchMethods() {
+					cg.Lit(method.jsonrpcName())
+				}
+			})
+		}
+		bg.Return()
+	})
+
+	srcFile.Line().Func().Params(Id("limits").Op("*").Id("jsonrpcLimits")).Id("allowBatch").Params(Id("service").String(), Id("methods").Op("...").String()).Block(
+		For(List(Id("_"), Id("method")).Op(":=").Range().Id("jsonrpcMethods").Index(Qual(packageStrings, "ToLower").Call(Id("service")))).Block(
+			Id("limits").Dot("batchMethods").Index(Id("method")).Op("=").False(),
+		),
+		For(List(Id("_"), Id("method")).Op(":=").Range().Id("methods")).Block(
+			Id("limits").Dot("batchMethods").Index(Qual(packageStrings, "ToLower").Call(Id("method"))).Op("=").True(),
+		),
+	)
+
+	srcFile.Line().Func().Params(Id("limits").Op("*").Id("jsonrpcLimits")).Id("checkBatch").Params(Id("size").Int()).Params(Err().Error()).BlockFunc(func(bg *Group) {
+		bg.If(Id("limits").Dot("maxBatchSize").Op(">").Lit(0).Op("&&").Id("size").Op(">").Id("limits").Dot("maxBatchSize")).BlockFunc(func(ig *Group) {
+			if hasMetrics {
+				ig.Id("RequestRejected").Dot("With").Call(Lit("method"), Lit(""), Lit("reason"), Lit("batch_size")).Dot("Add").Call(Lit(1))
+			}
+			ig.Return(Qual(packageFmt, "Errorf").Call(Lit("batch size %d exceeds limit %d"), Id("size"), Id("limits").Dot("maxBatchSize")))
+		})
+		bg.Return()
+	})
+
+	srcFile.Line().Func().Params(Id("limits").Op("*").Id("jsonrpcLimits")).Id("checkRequest").
+		Params(Id("method").String(), Id("request").Id("baseJsonRPC"), Id("batch").Bool()).Params(Err().Error()).BlockFunc(func(bg *Group) {
+		bg.If(Id("limits").Dot("maxParamsSize").Op(">").Lit(0).Op("&&").Len(Id("request").Dot("Params")).Op(">").Id("limits").Dot("maxParamsSize")).BlockFunc(func(ig *Group) {
+			if hasMetrics {
+				ig.Id("RequestRejected").Dot("With").Call(Lit("method"), Id("method"), Lit("reason"), Lit("params_size")).Dot("Add").Call(Lit(1))
+			}
+			ig.Return(Qual(packageFmt, "Errorf").Call(Lit("params size %d exceeds limit %d"), Len(Id("request").Dot("Params")), Id("limits").Dot("maxParamsSize")))
+		})
+		bg.If(List(Id("allowed"), Id("found")).Op(":=").Id("limits").Dot("batchMethods").Index(Id("method")).Op(";").Id("batch").Op("&&").Id("found").Op("&&").Op("!").Id("allowed")).BlockFunc(func(ig *Group) {
+			if hasMetrics {
+				ig.Id("RequestRejected").Dot("With").Call(Lit("method"), Id("method"), Lit("reason"), Lit("batch_method")).Dot("Add").Call(Lit(1))
+			}
+			ig.Return(Qual(packageFmt, "Errorf").Call(Lit("method '%s' is not allowed in batch"), Id("request").Dot("Method")))
+		})
+		bg.Return()
+	})
+	return srcFile.Save(path.Join(outDir, "limits.go"))
+}
+
+func (svc service) batchMethods() (methods []*method) {
+
+	allowed := make(map[string]bool)
+	for _, name := range strings.Split(svc.tags.Value(tagBatchMethods), ",") {
+		allowed[strings.ToLower(strings.TrimSpace(name))] = true
+	}
+	for _, method := range svc.methods {
+		if method.isJsonRPC() && (allowed[method.lcName()] || allowed[method.jsonrpcLocalName()]) {
+			methods = append(methods, method)
+		}
+	}
+	return
+}
+
+// isBatchMethod returns false if server rejects method in batch
+func (svc service) isBatchMethod(m *method) bool {
+
+	if !svc.tags.IsSet(tagBatchMethods) {
+		return true
+	}
+	for _, method := range svc.batchMethods() {
+		if method == m {
+			return true
+		}
+	}
+	return false
+}
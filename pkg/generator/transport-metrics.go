@@ -31,6 +31,9 @@ func (tr Transport) renderMetrics(outDir string) (err error) {
 	srcFile.Add(prometheusCounterRequestCount())
 	srcFile.Add(prometheusCounterRequestCountAll())
 	srcFile.Add(prometheusSummaryRequestCount())
+	if tr.hasJsonRPC {
+		srcFile.Add(prometheusCounterRequestRejected())
+	}
 
 	srcFile.Add(tr.serveMetricsFunc())
 
@@ -84,3 +87,15 @@ func prometheusSummaryRequestCount() (code *Statement) {
 		}),
 	), Index().String().Values(Lit("method"), Lit("service"), Lit("success")))
 }
+
+func prometheusCounterRequestRejected() (code *Statement) {
+
+	return Var().Id("RequestRejected").Op("=").Qual(packageKitPrometheus, "NewCounterFrom").Call(Qual(packageStdPrometheus, "CounterOpts").Values(
+		DictFunc(func(d Dict) {
+			d[Id("Name")] = Lit("rejected_count")
+			d[Id("Namespace")] = Lit("service")
+			d[Id("Subsystem")] = Lit("requests")
+			d[Id("Help")] = Lit("Number of jsonRPC requests rejected by limits")
+		}),
+	), Index().String().Values(Lit("method"), Lit("reason")))
+}
@@ -0,0 +1,24 @@
+package generator
+
+import (
+	"encoding/json"
+	"path"
+	"path/filepath"
+)
+
+const rpcDiscoverMethod = "rpc.discover"
+
+func (tr Transport) renderDiscover(outDir string) (err error) {
+
+	srcFile := newSrc(filepath.Base(outDir))
+	srcFile.PackageComment(doNotEdit)
+
+	var docData []byte
+	if docData, err = json.Marshal(newOpenRPC(&tr).document()); err != nil {
+		return
+	}
+	srcFile.Line().Comment("openrpcDocument is returned by the '" + rpcDiscoverMethod + "' method")
+	srcFile.Const().Id("openrpcDocument").Op("=").Lit(string(docData))
+
+	return srcFile.Save(path.Join(outDir, "openrpc.go"))
+}
@@ -33,12 +33,16 @@ func (tr Transport) renderOptions(outDir string) (err error) {
 		)),
 	)
 	for _, serviceName := range tr.serviceKeys() {
+		svc := tr.services[serviceName]
 		srcFile.Line().Func().Id(serviceName).Params(Id("svc").Op("*").Id("http" + serviceName)).Id("Option").Block(
 			Return(Func().Params(Id("srv").Op("*").Id("Server")).Block(
-				If(Id("srv").Dot("srvHTTP").Op("!=").Nil()).Block(
-					Id("srv").Dot("http"+serviceName).Op("=").Id("svc"),
-					Id("svc").Dot("SetRoutes").Call(Id("srv").Dot("Fiber").Call()),
-				),
+				If(Id("srv").Dot("srvHTTP").Op("!=").Nil()).BlockFunc(func(ig *Group) {
+					ig.Id("srv").Dot("http" + serviceName).Op("=").Id("svc")
+					if svc.isJsonRPC() {
+						ig.Id("svc").Dot("limits").Op("=").Id("srv").Dot("limits")
+					}
+					ig.Id("svc").Dot("SetRoutes").Call(Id("srv").Dot("Fiber").Call())
+				}),
 			)),
 		)
 	}
@@ -47,6 +51,23 @@ func (tr Transport) renderOptions(outDir string) (err error) {
 			Id("srv").Dot("config").Dot("BodyLimit").Op("=").Id("max"),
 		)),
 	)
+	if tr.hasJsonRPC {
+		srcFile.Line().Func().Id("MaxBatchSize").Params(Id("size").Int()).Id("Option").Block(
+			Return(Func().Params(Id("srv").Op("*").Id("Server")).Block(
+				Id("srv").Dot("limits").Dot("maxBatchSize").Op("=").Id("size"),
+			)),
+		)
+		srcFile.Line().Func().Id("MaxParamsSize").Params(Id("size").Int()).Id("Option").Block(
+			Return(Func().Params(Id("srv").Op("*").Id("Server")).Block(
+				Id("srv").Dot("limits").Dot("maxParamsSize").Op("=").Id("size"),
+			)),
+		)
+		srcFile.Line().Func().Id("BatchMethods").Params(Id("service").String(), Id("methods").Op("...").String()).Id("Option").Block(
+			Return(Func().Params(Id("srv").Op("*").Id("Server")).Block(
+				Id("srv").Dot("limits").Dot("allowBatch").Call(Id("service"), Id("methods").Op("...")),
+			)),
+		)
+	}
 	srcFile.Line().Func().Id("ReadTimeout").Params(Id("timeout").Qual(packageTime, "Duration")).Id("Option").Block(
 		Return(Func().Params(Id("srv").Op("*").Id("Server")).Block(
 			Id("srv").Dot("config").Dot("ReadTimeout").Op("=").Id("timeout"),
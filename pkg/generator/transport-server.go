@@ -120,7 +120,11 @@ func (tr Transport) serverType() Code {
 		g.Line().Id("config").Qual(packageFiber, "Config")
 		g.Line().Id("srvHTTP").Op("*").Qual(packageFiber, "App")
 		g.Id("srvHealth").Op("*").Qual(packageFiber, "App")
+		g.Id("healthRoutes").Op("[]").Func().Params(Id("route").Op("*").Qual(packageFiber, "App"))
 		g.Line().Id("reporterCloser").Qual(packageIO, "Closer")
+		if tr.hasJsonRPC {
+			g.Line().Id("limits").Op("*").Id("jsonrpcLimits")
+		}
 		for _, serviceName := range tr.serviceKeys() {
 			g.Id("http" + serviceName).Op("*").Id("http" + serviceName)
 		}
@@ -131,13 +135,16 @@ func (tr Transport) serverNewFunc() Code {
 
 	return Func().Id("New").Params(Id("log").Qual(packageZeroLog, "Logger"), Id("options").Op("...").Id("Option")).Params(Id("srv").Op("*").Id("Server")).
 		BlockFunc(func(bg *Group) {
-			bg.Line().Id("srv").Op("=").Op("&").Id("Server").Values(Dict{
-				Id("log"): Id("log"),
-				Id("config"): Qual(packageFiber, "Config").Values(Dict{
+			bg.Line().Id("srv").Op("=").Op("&").Id("Server").Values(DictFunc(func(d Dict) {
+				d[Id("log")] = Id("log")
+				d[Id("config")] = Qual(packageFiber, "Config").Values(Dict{
 					Id("DisableStartupMessage"): True(),
 					Id("BodyLimit"):             Id("maxRequestBodySize"),
-				}),
-			})
+				})
+				if tr.hasJsonRPC {
+					d[Id("limits")] = Id("newJsonRPCLimits").Call()
+				}
+			}))
 			bg.For(List(Id("_"), Id("option")).Op(":=").Range().Id("options")).Block(
 				Id("option").Call(Id("srv")),
 			)
@@ -161,6 +168,9 @@ func (tr Transport) serveHealthFunc() Code {
 			Func().Params(Id(_ctx_).Op("*").Qual(packageFiber, "Ctx")).Params(Error()).Block(
 				Return().Id(_ctx_).Dot("JSON").Call(Id("response")),
 			)),
+		For(List(Id("_"), Id("setRoutes")).Op(":=").Range().Id("srv").Dot("healthRoutes")).Block(
+			Id("setRoutes").Call(Id("srv").Dot("srvHealth")),
+		),
 		Go().Func().Params().Block(
 			Err().Op(":=").Id("srv").Dot("srvHealth").Dot("Listen").Call(Id("address")),
 			Id("ExitOnError").Call(Id("srv").Dot("log"), Err(), Lit("serve health on ").Op("+").Id("address")),
@@ -0,0 +1,181 @@
+package generator
+
+// docsPage is self-contained documentation page, OpenAPI document is inlined instead of {{spec}}
+const docsPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{title}}</title>
+<style>
+body{margin:0;font:14px/1.5 -apple-system,BlinkMacSystemFont,"Segoe UI",Roboto,Helvetica,Arial,sans-serif;color:#1f2328;background:#fff}
+a{color:#0969da;text-decoration:none}a:hover{text-decoration:underline}
+nav{position:fixed;top:0;bottom:0;left:0;width:280px;overflow:auto;background:#f6f8fa;border-right:1px solid #d0d7de;padding:16px;box-sizing:border-box}
+nav h4{margin:16px 0 4px;text-transform:uppercase;font-size:11px;color:#656d76}
+nav a{display:block;padding:2px 0;white-space:nowrap;overflow:hidden;text-overflow:ellipsis}
+nav input{width:100%;box-sizing:border-box;padding:4px 8px;border:1px solid #d0d7de;border-radius:6px}
+main{margin-left:280px;padding:24px 40px;max-width:1100px}
+section{border-top:1px solid #d0d7de;padding:16px 0}
+h1{margin-top:0}h2{margin:8px 0}h3{margin:16px 0 8px;font-size:15px}
+.method{display:inline-block;min-width:56px;text-align:center;border-radius:4px;padding:0 6px;margin-right:8px;color:#fff;font-weight:600;font-size:12px;text-transform:uppercase}
+.get{background:#1f883d}.post{background:#0969da}.put{background:#9a6700}.patch{background:#8250df}.delete{background:#cf222e}
+.path{font-family:ui-monospace,SFMono-Regular,Menlo,monospace}
+.deprecated{text-decoration:line-through}
+table{border-collapse:collapse;width:100%;margin:4px 0}td,th{border:1px solid #d0d7de;padding:4px 8px;text-align:left;vertical-align:top}th{background:#f6f8fa}
+code,pre{font-family:ui-monospace,SFMono-Regular,Menlo,monospace;font-size:12px}
+pre{background:#f6f8fa;border:1px solid #d0d7de;border-radius:6px;padding:8px;overflow:auto}
+.type{color:#8250df}.required{color:#cf222e;font-size:11px}.muted{color:#656d76}
+ul.props{list-style:none;padding-left:16px;margin:2px 0;border-left:1px dashed #d0d7de}
+</style>
+</head>
+<body>
+<nav id="nav"></nav>
+<main id="main"></main>
+<script id="spec" type="application/json">{{spec}}</script>
+<script>
+(function () {
+  var spec = JSON.parse(document.getElementById("spec").textContent);
+  var methods = ["get", "post", "put", "patch", "delete"];
+  var nav = [], main = [];
+
+  function esc(value) {
+    return String(value === undefined || value === null ? "" : value).replace(/[&<>"']/g, function (c) {
+      return {"&": "&amp;", "<": "&lt;", ">": "&gt;", '"': "&quot;", "'": "&#39;"}[c];
+    });
+  }
+  function text(value) {
+    return value ? "<p>" + esc(value).replace(/\n/g, "<br>") + "</p>" : "";
+  }
+  function refName(ref) {
+    return ref.split("/").pop();
+  }
+  function typeOf(schema) {
+    if (!schema) return "";
+    if (schema.$ref) return '<a href="#schema-' + esc(refName(schema.$ref)) + '">' + esc(refName(schema.$ref)) + "</a>";
+    if (schema.oneOf) return schema.oneOf.map(typeOf).join(" | ");
+    var type = Array.isArray(schema.type) ? schema.type.join(" | ") : schema.type || "any";
+    if (schema.type === "array") type = typeOf(schema.items) + "[]";
+    if (schema.format) type += " &lt;" + esc(schema.format) + "&gt;";
+    if (schema.nullable) type += " | null";
+    return '<span class="type">' + type + "</span>";
+  }
+  function values(schema) {
+    var out = "";
+    if (schema.enum) out += ' <span class="muted">enum: ' + schema.enum.map(function (v) { return "<code>" + esc(JSON.stringify(v)) + "</code>"; }).join(", ") + "</span>";
+    if (schema.const !== undefined) out += ' <span class="muted">const: <code>' + esc(JSON.stringify(schema.const)) + "</code></span>";
+    return out;
+  }
+  function props(schema, depth) {
+    if (!schema || depth > 8) return "";
+    if (schema.type === "array" && schema.items && !schema.items.$ref) return props(schema.items, depth + 1);
+    if (typeof schema.additionalProperties === "object" && !schema.properties) {
+      return '<ul class="props"><li><code>{key}</code>: ' + typeOf(schema.additionalProperties) + props(schema.additionalProperties, depth + 1) + "</li></ul>";
+    }
+    if (!schema.properties) return "";
+    var required = schema.required || [];
+    return '<ul class="props">' + Object.keys(schema.properties).map(function (name) {
+      var prop = schema.properties[name];
+      return "<li><code>" + esc(name) + "</code>: " + typeOf(prop) +
+        (required.indexOf(name) >= 0 ? ' <span class="required">required</span>' : "") + values(prop) +
+        (prop.description ? ' <span class="muted">- ' + esc(prop.description) + "</span>" : "") + props(prop, depth + 1) + "</li>";
+    }).join("") + "</ul>";
+  }
+  function schemaBlock(schema) {
+    return typeOf(schema) + values(schema) + props(schema, 0);
+  }
+  function content(body) {
+    return Object.keys(body || {}).map(function (mime) {
+      return '<div><span class="muted">' + esc(mime) + "</span> " + schemaBlock(body[mime].schema) + "</div>";
+    }).join("");
+  }
+  function security(requirements) {
+    if (!requirements) return "";
+    if (!requirements.length) return '<p class="muted">No authorization</p>';
+    return "<p>Authorization: " + requirements.map(function (requirement) {
+      return Object.keys(requirement).map(function (name) {
+        return "<code>" + esc(name) + (requirement[name].length ? " (" + esc(requirement[name].join(", ")) + ")" : "") + "</code>";
+      }).join(" + ");
+    }).join(" or ") + "</p>";
+  }
+  function operation(id, method, path, op) {
+    var out = '<section id="' + id + '"><h2 class="' + (op.deprecated ? "deprecated" : "") + '"><span class="method ' + method + '">' + method +
+      '</span><span class="path">' + esc(path) + "</span></h2>" + text(op.summary) + text(op.description) + security(op.security);
+    if (op.parameters && op.parameters.length) {
+      out += "<h3>Parameters</h3><table><tr><th>Name</th><th>In</th><th>Type</th><th>Description</th></tr>" + op.parameters.map(function (param) {
+        return "<tr><td><code>" + esc(param.name) + "</code>" + (param.required ? ' <span class="required">required</span>' : "") +
+          "</td><td>" + esc(param.in) + "</td><td>" + schemaBlock(param.schema) + "</td><td>" + esc(param.description) + "</td></tr>";
+      }).join("") + "</table>";
+    }
+    if (op.requestBody) out += "<h3>Request body</h3>" + text(op.requestBody.description) + content(op.requestBody.content);
+    if (op.responses) {
+      out += "<h3>Responses</h3>" + Object.keys(op.responses).map(function (code) {
+        var response = op.responses[code];
+        return "<div><b>" + esc(code) + "</b> " + esc(response.description) + content(response.content) + "</div>";
+      }).join("");
+    }
+    (op["x-code-samples"] || []).forEach(function (sample) {
+      out += "<h3>" + esc(sample.lang) + "</h3><pre>" + esc(sample.source) + "</pre>";
+    });
+    return out + "</section>";
+  }
+  function operations(title, paths, prefix) {
+    var groups = {}, order = [];
+    Object.keys(paths || {}).forEach(function (path) {
+      methods.forEach(function (method) {
+        var op = paths[path][method];
+        if (!op) return;
+        var tag = (op.tags && op.tags[0]) || title;
+        if (!groups[tag]) { groups[tag] = []; order.push(tag); }
+        groups[tag].push({id: prefix + "-" + method + "-" + path.replace(/[^\w]+/g, "-"), method: method, path: path, op: op});
+      });
+    });
+    order.forEach(function (tag) {
+      nav.push("<h4>" + esc(tag) + "</h4>");
+      main.push("<h2>" + esc(tag) + "</h2>");
+      groups[tag].forEach(function (item) {
+        nav.push('<a href="#' + item.id + '"><span class="method ' + item.method + '">' + item.method + "</span>" + esc(item.op.summary || item.path) + "</a>");
+        main.push(operation(item.id, item.method, item.path, item.op));
+      });
+    });
+  }
+
+  var info = spec.info || {};
+  var base = location.pathname.replace(/\/?$/, "/");
+  document.title = info.title || document.title;
+  nav.push('<input id="filter" placeholder="Filter">');
+  main.push("<h1>" + esc(info.title) + ' <small class="muted">' + esc(info.version) + "</small></h1>" + text(info.description));
+  main.push('<p class="muted">' + (spec.servers || []).map(function (server) { return "<code>" + esc(server.url) + "</code> " + esc(server.description); }).join("<br>") +
+    "</p><p>" + ["openapi.json", "openapi.yaml"].map(function (file) { return '<a href="' + base + file + '">' + file + "</a>"; }).join(" · ") + "</p>");
+  operations("operations", spec.paths, "op");
+  operations("webhooks", spec.webhooks, "webhook");
+  var components = spec.components || {};
+  var schemes = components.securitySchemes || {};
+  if (Object.keys(schemes).length) {
+    main.push("<h2>Authorization</h2><table><tr><th>Name</th><th>Type</th><th>Description</th></tr>" + Object.keys(schemes).map(function (name) {
+      var scheme = schemes[name];
+      return "<tr><td><code>" + esc(name) + "</code></td><td>" + esc([scheme.type, scheme.scheme, scheme.bearerFormat, scheme.in, scheme.name].filter(Boolean).join(" ")) +
+        "</td><td>" + esc(scheme.description) + "</td></tr>";
+    }).join("") + "</table>");
+  }
+  var schemas = components.schemas || {};
+  if (Object.keys(schemas).length) {
+    nav.push("<h4>schemas</h4>");
+    main.push("<h2>Schemas</h2>");
+    Object.keys(schemas).sort().forEach(function (name) {
+      nav.push('<a href="#schema-' + esc(name) + '">' + esc(name) + "</a>");
+      main.push('<section id="schema-' + esc(name) + '"><h3>' + esc(name) + "</h3>" + text(schemas[name].description) + schemaBlock(schemas[name]) + "</section>");
+    });
+  }
+  document.getElementById("nav").innerHTML = nav.join("");
+  document.getElementById("main").innerHTML = main.join("");
+  document.getElementById("filter").addEventListener("input", function (event) {
+    var filter = event.target.value.toLowerCase();
+    Array.prototype.forEach.call(document.querySelectorAll("nav a"), function (link) {
+      link.style.display = link.textContent.toLowerCase().indexOf(filter) >= 0 ? "" : "none";
+    });
+  });
+})();
+</script>
+</body>
+</html>
+`
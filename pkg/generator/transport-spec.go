@@ -0,0 +1,109 @@
+package generator
+
+import (
+	"encoding/json"
+	"html"
+	"io/ioutil"
+	"path"
+	"path/filepath"
+	"strings"
+
+	. "github.com/dave/jennifer/jen"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	specJSON    = "openapi.json"
+	specYAML    = "openapi.yaml"
+	specOpenRPC = "openrpc.json"
+	specDocs    = "docs.html"
+)
+
+// renderSpec writes OpenAPI documents and documentation page to transport package and embeds them by go:embed
+func (tr Transport) renderSpec(outDir, openapi string, withOpenRPC bool) (err error) {
+
+	doc := newSwagger(&tr)
+	doc.openapi = openapi
+	if _, err = doc.checkOpenAPIVersion(); err != nil {
+		return
+	}
+	swaggerDoc := doc.document()
+
+	fileNames := []string{specJSON, specYAML, specDocs}
+	files := make(map[string][]byte)
+	if files[specJSON], err = json.MarshalIndent(swaggerDoc, "", "    "); err != nil {
+		return
+	}
+	if files[specYAML], err = yaml.Marshal(swaggerDoc); err != nil {
+		return
+	}
+	var docData []byte
+	if docData, err = json.Marshal(swaggerDoc); err != nil {
+		return
+	}
+	files[specDocs] = []byte(strings.NewReplacer("{{title}}", html.EscapeString(swaggerDoc.Info.Title), "{{spec}}", string(docData)).Replace(docsPage))
+	if withOpenRPC {
+		fileNames = append(fileNames, specOpenRPC)
+		if files[specOpenRPC], err = json.MarshalIndent(newOpenRPC(&tr).document(), "", "    "); err != nil {
+			return
+		}
+	}
+	for _, fileName := range fileNames {
+		tr.log.Info("write to ", path.Join(outDir, fileName))
+		if err = ioutil.WriteFile(path.Join(outDir, fileName), files[fileName], 0600); err != nil {
+			return
+		}
+	}
+
+	srcFile := newSrc(filepath.Base(outDir))
+	srcFile.PackageComment(doNotEdit)
+
+	srcFile.Anon("embed")
+	srcFile.ImportName(packageFiber, "fiber")
+
+	srcFile.Line().Comment("//go:embed " + specJSON)
+	srcFile.Var().Id("specJSON").Index().Byte()
+	srcFile.Line().Comment("//go:embed " + specYAML)
+	srcFile.Var().Id("specYAML").Index().Byte()
+	srcFile.Line().Comment("//go:embed " + specDocs)
+	srcFile.Var().Id("specDocs").Index().Byte()
+	if withOpenRPC {
+		srcFile.Line().Comment("//go:embed " + specOpenRPC)
+		srcFile.Var().Id("specOpenRPC").Index().Byte()
+	}
+
+	srcFile.Line().Comment("Docs serves OpenAPI documents and documentation page under prefix on main listener")
+	srcFile.Func().Id("Docs").Params(Id("prefix").String()).Id("Option").Block(
+		Return(Func().Params(Id("srv").Op("*").Id("Server")).Block(
+			If(Id("srv").Dot("srvHTTP").Op("!=").Nil()).Block(
+				Id("mountDocs").Call(Id("srv").Dot("srvHTTP"), Id("prefix")),
+			),
+		)),
+	)
+	srcFile.Line().Comment("HealthDocs serves OpenAPI documents and documentation page under prefix on health listener")
+	srcFile.Func().Id("HealthDocs").Params(Id("prefix").String()).Id("Option").Block(
+		Return(Func().Params(Id("srv").Op("*").Id("Server")).Block(
+			If(Id("srv").Dot("srvHTTP").Op("!=").Nil()).Block(
+				Id("srv").Dot("healthRoutes").Op("=").Append(Id("srv").Dot("healthRoutes"), Func().Params(Id("route").Op("*").Qual(packageFiber, "App")).Block(
+					Id("mountDocs").Call(Id("route"), Id("prefix")),
+				)),
+			),
+		)),
+	)
+	srcFile.Line().Func().Id("mountDocs").Params(Id("route").Op("*").Qual(packageFiber, "App"), Id("prefix").String()).BlockFunc(func(bg *Group) {
+		bg.Id("prefix").Op("=").Qual(packageStrings, "TrimSuffix").Call(Id("prefix"), Lit("/"))
+		bg.Id("route").Dot("Get").Call(Id("prefix").Op("+").Lit("/"+specJSON), Id("serveSpec").Call(Qual(packageFiber, "MIMEApplicationJSON"), Id("specJSON")))
+		bg.Id("route").Dot("Get").Call(Id("prefix").Op("+").Lit("/"+specYAML), Id("serveSpec").Call(Lit("application/yaml"), Id("specYAML")))
+		if withOpenRPC {
+			bg.Id("route").Dot("Get").Call(Id("prefix").Op("+").Lit("/"+specOpenRPC), Id("serveSpec").Call(Qual(packageFiber, "MIMEApplicationJSON"), Id("specOpenRPC")))
+		}
+		bg.Id("route").Dot("Get").Call(Id("prefix").Op("+").Lit("/"), Id("serveSpec").Call(Qual(packageFiber, "MIMETextHTMLCharsetUTF8"), Id("specDocs")))
+	})
+	srcFile.Line().Func().Id("serveSpec").Params(Id("contentType").String(), Id("data").Index().Byte()).Id("Handler").Block(
+		Return(Func().Params(Id(_ctx_).Op("*").Qual(packageFiber, "Ctx")).Params(Error()).Block(
+			Id(_ctx_).Dot("Set").Call(Qual(packageFiber, "HeaderContentType"), Id("contentType")),
+			Return(Id(_ctx_).Dot("Send").Call(Id("data"))),
+		)),
+	)
+	return srcFile.Save(path.Join(outDir, "spec.go"))
+}
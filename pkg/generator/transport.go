@@ -15,7 +15,7 @@ import (
 	"github.com/vetcher/go-astra"
 	"github.com/vetcher/go-astra/types"
 
-	"github.com/tundrik/tg/pkg/tags"
+	"github.com/tundrik/tg/v2/pkg/tags"
 )
 
 const doNotEdit = "GENERATED BY 'T'ransport 'G'enerator. DO NOT EDIT."
@@ -47,10 +47,27 @@ const (
 	tagHttpResponse  = "http-response"
 	tagPackageUUID   = "uuidPackage"
 	tagSwaggerTags   = "swaggerTags"
+	tagOpenAPI       = "openapi"
+	tagWebhook       = "swagger-webhook"
+	tagRequired      = "required"
+	tagOptional      = "optional"
+	tagSecurity      = "security"
+	tagJsonRPCErrors = "jsonRPC-errors"
+	tagRPCDiscover   = "jsonRPC-discover"
+	tagBatchSize     = "jsonRPC-batch-size"
+	tagParamsSize    = "jsonRPC-params-size"
+	tagBatchMethods  = "jsonRPC-batch"
+	tagRPCName       = "jsonRPC-name"
+	tagRPCAliases    = "jsonRPC-aliases"
+	tagRPCNamespace  = "jsonRPC-namespace"
+	tagIdempotent    = "idempotent"
+	tagClientModule  = "client-module"
+	tagClientVersion = "client-version"
 )
 
 type Transport struct {
 	hasJsonRPC bool
+	svcDir     string
 	tags       tags.DocTags
 	log        logrus.FieldLogger
 	services   map[string]*service
@@ -60,6 +77,7 @@ func NewTransport(log logrus.FieldLogger, svcDir string, options ...Option) (tr
 
 	tr.log = log
 	tr.services = make(map[string]*service)
+	tr.svcDir, _ = filepath.Abs(svcDir)
 
 	var files []os.FileInfo
 	if files, err = ioutil.ReadDir(svcDir); err != nil {
@@ -101,8 +119,22 @@ func (tr Transport) RenderAzure(appName, routePrefix, outDir, logLevel string, e
 	return newAzure(&tr).render(appName, routePrefix, outDir, logLevel, enableHealth)
 }
 
-func (tr Transport) RenderSwagger(outDir string) (err error) {
-	return newSwagger(&tr).render(outDir)
+func (tr Transport) RenderSwagger(outDir, openapi string) (err error) {
+
+	doc := newSwagger(&tr)
+	doc.openapi = openapi
+	if _, err = doc.checkOpenAPIVersion(); err != nil {
+		return
+	}
+	return doc.render(outDir)
+}
+
+func (tr Transport) RenderSpec(outDir, openapi string, withOpenRPC bool) (err error) {
+	return tr.renderSpec(outDir, openapi, withOpenRPC)
+}
+
+func (tr Transport) RenderOpenRPC(outFilePath string) (err error) {
+	return newOpenRPC(&tr).render(outFilePath)
 }
 
 func (tr Transport) serviceKeys() (keys []string) {
@@ -121,12 +153,19 @@ func (tr Transport) RenderClient(outDir string) (err error) {
 		return
 	}
 
-	if tr.hasTrace() {
-		showError(tr.log, tr.renderClientTracer(outDir), "renderHTTP")
-	}
 	showError(tr.log, tr.renderClientOptions(outDir), "renderHTTP")
-	if tr.hasJsonRPC {
+	if tr.hasJsonRPC || tr.hasREST() {
+		showError(tr.log, tr.renderClientTracer(outDir), "renderHTTP")
+		showError(tr.log, tr.renderClientMetrics(outDir), "renderHTTP")
 		showError(tr.log, tr.renderClientJsonRPC(outDir), "renderHTTP")
+		showError(tr.log, tr.renderClientRetry(outDir), "renderHTTP")
+		showError(tr.log, tr.renderClientTransport(outDir), "renderHTTP")
+		showError(tr.log, tr.renderClientBreaker(outDir), "renderHTTP")
+		showError(tr.log, tr.renderClientBalancer(outDir), "renderHTTP")
+		showError(tr.log, tr.renderClientAuth(outDir), "renderHTTP")
+	}
+	if tr.hasREST() {
+		showError(tr.log, tr.renderClientREST(outDir), "renderHTTP")
 	}
 	for _, serviceName := range tr.serviceKeys() {
 		svc := tr.services[serviceName]
@@ -159,6 +198,10 @@ func (tr Transport) RenderServer(outDir string) (err error) {
 	}
 	if tr.hasJsonRPC {
 		showError(tr.log, tr.renderJsonRPC(outDir), "renderJsonRPC")
+		showError(tr.log, tr.renderLimits(outDir), "renderLimits")
+	}
+	if tr.hasDiscover() {
+		showError(tr.log, tr.renderDiscover(outDir), "renderDiscover")
 	}
 
 	for _, serviceName := range tr.serviceKeys() {
@@ -188,6 +231,20 @@ func (tr Transport) hasMetrics() (hasMetric bool) {
 	return
 }
 
+func (tr Transport) hasAliases() (hasAliases bool) {
+	for _, serviceName := range tr.serviceKeys() {
+		svc := tr.services[serviceName]
+		if svc.isJsonRPC() && svc.hasAliases() {
+			return true
+		}
+	}
+	return
+}
+
+func (tr Transport) hasDiscover() bool {
+	return tr.hasJsonRPC && tr.tags.IsSet(tagRPCDiscover)
+}
+
 func showError(log logrus.FieldLogger, err error, msg string) {
 	if err != nil {
 		log.WithError(err).Error(msg)
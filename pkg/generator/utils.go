@@ -7,9 +7,16 @@ import (
 	"bufio"
 	"context"
 	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/parser"
+	"go/token"
+	goTypes "go/types"
 	"os"
 	"path"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 
 	. "github.com/dave/jennifer/jen"
@@ -17,8 +24,9 @@ import (
 	"github.com/vetcher/go-astra"
 	"github.com/vetcher/go-astra/types"
 
-	"github.com/tundrik/tg/pkg/mod"
-	"github.com/tundrik/tg/pkg/utils"
+	"github.com/tundrik/tg/v2/pkg/mod"
+	"github.com/tundrik/tg/v2/pkg/tags"
+	"github.com/tundrik/tg/v2/pkg/utils"
 )
 
 func removeSkippedFields(fields []types.Variable, skipFields []string) []types.Variable {
@@ -325,3 +333,141 @@ func parseType(relPath, name string) (retType types.Type) {
 	})
 	return
 }
+
+// isRequired reports whether value must be set: values without omitempty are required unless they are nil-able,
+// annotations required and optional override it
+func isRequired(varType types.Type, jsonTags []string, varTags tags.DocTags) bool {
+
+	if varTags.IsSet(tagOptional) {
+		return false
+	}
+	if varTags.IsSet(tagRequired) {
+		return true
+	}
+	switch varType.(type) {
+	case types.TPointer, types.TInterface, types.TEllipsis:
+		return false
+	}
+	return !tagOmitEmpty(jsonTags)
+}
+
+type enumValue struct {
+	Name  string
+	Desc  string
+	Value constant.Value
+}
+
+// literal returns JSON representation of constant value
+func (e enumValue) literal() string {
+
+	switch e.Value.Kind() {
+	case constant.String:
+		return strconv.Quote(constant.StringVal(e.Value))
+	case constant.Float:
+		value, _ := constant.Float64Val(e.Value)
+		return strconv.FormatFloat(value, 'g', -1, 64)
+	default:
+		return e.Value.ExactString()
+	}
+}
+
+// value returns constant value for JSON or YAML encoding
+func (e enumValue) value() interface{} {
+
+	switch e.Value.Kind() {
+	case constant.String:
+		return constant.StringVal(e.Value)
+	case constant.Bool:
+		return constant.BoolVal(e.Value)
+	case constant.Int:
+		if value, exact := constant.Int64Val(e.Value); exact {
+			return value
+		}
+	}
+	value, _ := constant.Float64Val(e.Value)
+	return value
+}
+
+// searchEnum returns constants of named type in declaration order, astra does not keep their values
+func searchEnum(pkg, typeName string) (values []enumValue) {
+
+	for _, pkgPath := range []string{pkg, mod.PkgModPath(pkg), path.Join("./vendor", pkg), trimLocalPkg(pkg)} {
+		if values = parseEnum(pkgPath, typeName); len(values) != 0 {
+			return
+		}
+	}
+	return
+}
+
+func parseEnum(relPath, typeName string) (values []enumValue) {
+
+	pkgPath, _ := filepath.Abs(relPath)
+
+	entries, err := os.ReadDir(pkgPath)
+	if err != nil {
+		return
+	}
+	fileSet := token.NewFileSet()
+	var files []*ast.File
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".go") || strings.HasSuffix(entry.Name(), "_test.go") {
+			continue
+		}
+		var file *ast.File
+		if file, err = parser.ParseFile(fileSet, path.Join(pkgPath, entry.Name()), nil, parser.ParseComments); err != nil {
+			return
+		}
+		files = append(files, file)
+	}
+	if len(files) == 0 {
+		return
+	}
+	info := &goTypes.Info{Defs: make(map[*ast.Ident]goTypes.Object)}
+	// imports are not resolved, errors are expected and do not affect constants of local types
+	config := goTypes.Config{Importer: skipImporter{}, Error: func(error) {}}
+	_, _ = config.Check(files[0].Name.Name, fileSet, files, info)
+
+	docs := constDocs(files)
+	positions := make(map[string]token.Pos)
+	for ident, object := range info.Defs {
+		if value, ok := object.(*goTypes.Const); ok && value.Parent() == value.Pkg().Scope() && value.Val().Kind() != constant.Unknown {
+			if named, ok := value.Type().(*goTypes.Named); ok && named.Obj().Name() == typeName && named.Obj().Pkg() == value.Pkg() {
+				positions[ident.Name] = ident.Pos()
+				values = append(values, enumValue{Name: ident.Name, Desc: strings.TrimPrefix(docs[ident.Name], ident.Name+" "), Value: value.Val()})
+			}
+		}
+	}
+	sort.Slice(values, func(i, j int) bool { return positions[values[i].Name] < positions[values[j].Name] })
+	return
+}
+
+// constDocs returns doc or line comments of constants
+func constDocs(files []*ast.File) (docs map[string]string) {
+
+	docs = make(map[string]string)
+	for _, file := range files {
+		for _, decl := range file.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.CONST {
+				continue
+			}
+			for _, spec := range genDecl.Specs {
+				valueSpec := spec.(*ast.ValueSpec)
+				doc := valueSpec.Doc.Text()
+				if doc == "" {
+					doc = valueSpec.Comment.Text()
+				}
+				for _, name := range valueSpec.Names {
+					docs[name.Name] = strings.TrimSpace(doc)
+				}
+			}
+		}
+	}
+	return
+}
+
+type skipImporter struct{}
+
+func (skipImporter) Import(pkgPath string) (*goTypes.Package, error) {
+	return nil, fmt.Errorf("import %s skipped", pkgPath)
+}
@@ -0,0 +1,360 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/token"
+	"regexp"
+	"sort"
+	"strings"
+
+	. "github.com/dave/jennifer/jen"
+
+	"github.com/tundrik/tg/v2/pkg/utils"
+)
+
+const refSchemas = "#/components/schemas/"
+
+var nonIdentifier = regexp.MustCompile(`[^a-zA-Z0-9]+`)
+
+// kind returns type of schema without 'null' of OpenAPI 3.1
+func (schema *oaSchema) kind() string {
+
+	for _, schemaType := range schema.Type {
+		if schemaType != "null" {
+			return schemaType
+		}
+	}
+	if len(schema.Properties) != 0 {
+		return "object"
+	}
+	return ""
+}
+
+func (schema *oaSchema) nullable() bool {
+
+	for _, schemaType := range schema.Type {
+		if schemaType == "null" {
+			return true
+		}
+	}
+	return schema.Nullable
+}
+
+// schemaByRef returns component schema by reference
+func (imp *openapi) schemaByRef(ref string) *oaSchema {
+	return imp.doc.Components.Schemas[strings.TrimPrefix(ref, refSchemas)]
+}
+
+// isObject returns true for schemas rendered to structures
+func (imp *openapi) isObject(schema *oaSchema) bool {
+
+	if schema == nil {
+		return false
+	}
+	if schema.Ref != "" {
+		return imp.isObject(imp.schemaByRef(schema.Ref))
+	}
+	return len(schema.Properties) != 0 || len(schema.AllOf) > 1 || (len(schema.AllOf) == 1 && imp.isObject(schema.AllOf[0]))
+}
+
+// properties returns properties of schema and its allOf parts with names of required ones,
+// owners are names of component types, which properties are taken from
+func (imp *openapi) properties(schema *oaSchema) (properties map[string]*oaSchema, required map[string]bool, owners map[string]string) {
+
+	properties = make(map[string]*oaSchema)
+	required = make(map[string]bool)
+	owners = make(map[string]string)
+	var collect func(schema *oaSchema, owner string, depth int)
+	collect = func(schema *oaSchema, owner string, depth int) {
+		if schema == nil || depth > 10 {
+			return
+		}
+		if schema.Ref != "" {
+			collect(imp.schemaByRef(schema.Ref), typeName(schema.Ref), depth+1)
+			return
+		}
+		for _, part := range schema.AllOf {
+			collect(part, owner, depth+1)
+		}
+		for name, property := range schema.Properties {
+			properties[name] = property
+			owners[name] = owner
+		}
+		for _, name := range schema.Required {
+			required[name] = true
+		}
+	}
+	collect(schema, "", 0)
+	return
+}
+
+// propertyTypeName returns name of type declared for inline schema of property,
+// types of properties taken from component are named after component
+func propertyTypeName(owners map[string]string, property, prefix string) string {
+
+	if owner := owners[property]; owner != "" {
+		prefix = owner
+	}
+	return prefix + goName(property)
+}
+
+// typeName returns name of Go type declared for component schema
+func typeName(ref string) string {
+	return goName(strings.TrimPrefix(ref, refSchemas))
+}
+
+// reserveName returns unique name of type
+func (imp *openapi) reserveName(name string) string {
+
+	unique := name
+	for i := 2; imp.typeNames[unique]; i++ {
+		unique = fmt.Sprintf("%s%d", name, i)
+	}
+	imp.typeNames[unique] = true
+	return unique
+}
+
+// schemaType returns Go type of schema, nested objects and enums are declared as named types with name prefix
+func (imp *openapi) schemaType(schema *oaSchema, name string) *Statement {
+
+	if schema == nil {
+		return Interface()
+	}
+	if schema.Ref != "" {
+		return Qual(imp.typesPkg, typeName(schema.Ref))
+	}
+	if len(schema.AllOf) == 1 && len(schema.Properties) == 0 {
+		return imp.schemaType(schema.AllOf[0], name)
+	}
+	if imp.isObject(schema) || len(schema.Enum) != 0 {
+		// inline schema of component property is declared once, allOf of other schemas reuses its type
+		if declared, found := imp.inlineTypes[schema]; found {
+			return Qual(imp.typesPkg, declared)
+		}
+		imp.inlineTypes[schema] = imp.reserveName(name)
+		return Qual(imp.typesPkg, imp.declare(imp.inlineTypes[schema], schema))
+	}
+	if len(schema.OneOf) != 0 || len(schema.AnyOf) != 0 {
+		imp.log.WithField("type", name).Warn("oneOf and anyOf are rendered as interface{}")
+		return Interface()
+	}
+	switch schema.kind() {
+	case "string":
+		switch schema.Format {
+		case "date-time":
+			return Qual("time", "Time")
+		case "byte", "binary":
+			return Index().Byte()
+		}
+		return String()
+	case "integer":
+		switch schema.Format {
+		case "int64":
+			return Int64()
+		case "int32":
+			return Int32()
+		}
+		return Int()
+	case "number":
+		if schema.Format == "float" {
+			return Float32()
+		}
+		return Float64()
+	case "boolean":
+		return Bool()
+	case "array":
+		return Index().Add(imp.schemaType(schema.Items, name+"Item"))
+	case "object":
+		if schema.AdditionalProperties.schema != nil {
+			return Map(String()).Add(imp.schemaType(schema.AdditionalProperties.schema, name+"Value"))
+		}
+		return Map(String()).Interface()
+	}
+	return Interface()
+}
+
+// fieldType returns type of property, nullable values and optional objects are pointers
+func (imp *openapi) fieldType(schema *oaSchema, name string, required bool) (fieldType *Statement, pointer bool) {
+
+	fieldType = imp.schemaType(schema, name)
+	if schema == nil {
+		return
+	}
+	if imp.isObject(schema) {
+		pointer = schema.nullable() || !required
+	} else {
+		resolved := imp.resolve(schema)
+		switch resolved.kind() {
+		case "string":
+			pointer = schema.nullable() && resolved.Format != "byte" && resolved.Format != "binary"
+		case "integer", "number", "boolean":
+			pointer = schema.nullable()
+		}
+	}
+	if pointer {
+		fieldType = Op("*").Add(fieldType)
+	}
+	return
+}
+
+func (imp *openapi) resolve(schema *oaSchema) *oaSchema {
+
+	for depth := 0; schema != nil && schema.Ref != "" && depth < 10; depth++ {
+		schema = imp.schemaByRef(schema.Ref)
+	}
+	if schema == nil {
+		return &oaSchema{}
+	}
+	return schema
+}
+
+// declare renders declaration of named type for schema
+func (imp *openapi) declare(name string, schema *oaSchema) string {
+
+	index := len(imp.types)
+	imp.types = append(imp.types, nil)
+
+	code := Line()
+	if schema.Description != "" {
+		code.Comment(name + " " + oneLine(schema.Description)).Line()
+	}
+	switch {
+	case imp.isObject(schema):
+		properties, required, owners := imp.properties(schema)
+		code.Type().Id(name).StructFunc(func(sg *Group) {
+			for _, property := range sortedKeys(properties) {
+				fieldName := goName(property)
+				fieldType, pointer := imp.fieldType(properties[property], propertyTypeName(owners, property, name), required[property])
+				jsonTag := property
+				if !required[property] {
+					jsonTag += ",omitempty"
+				}
+				annotations := schemaAnnotations(properties[property])
+				if required[property] && pointer {
+					annotations = append([]string{"required"}, annotations...)
+				}
+				if len(annotations) != 0 {
+					sg.Comment("@tg " + strings.Join(annotations, " "))
+				}
+				sg.Id(fieldName).Add(fieldType).Tag(map[string]string{"json": jsonTag})
+			}
+		})
+	case len(schema.Enum) != 0:
+		kind := enumKind(schema)
+		baseType := imp.schemaType(&oaSchema{Type: oaTypes{kind}, Format: schema.Format}, name)
+		code.Type().Id(name).Add(baseType)
+		code.Line().Const().DefsFunc(func(cg *Group) {
+			constNames := make(map[string]bool)
+			for _, value := range schema.Enum {
+				if value == nil {
+					continue
+				}
+				constName := name + goName(fmt.Sprint(value))
+				for i := 2; constNames[constName]; i++ {
+					constName = fmt.Sprintf("%s%s%d", name, goName(fmt.Sprint(value)), i)
+				}
+				constNames[constName] = true
+				if kind == "string" {
+					value = fmt.Sprint(value)
+				}
+				cg.Id(constName).Id(name).Op("=").Lit(value)
+			}
+		})
+	default:
+		schemaCopy := *schema
+		schemaCopy.Description = ""
+		code.Type().Id(name).Add(imp.schemaType(&schemaCopy, name))
+	}
+	imp.types[index] = code
+	return name
+}
+
+// enumKind returns type of enum values, type of first value is used for schemas without type
+func enumKind(schema *oaSchema) string {
+
+	if kind := schema.kind(); kind != "" {
+		return kind
+	}
+	for _, value := range schema.Enum {
+		switch value.(type) {
+		case int, int64, uint64:
+			return "integer"
+		case float64:
+			return "number"
+		case bool:
+			return "boolean"
+		}
+	}
+	return "string"
+}
+
+// schemaAnnotations returns tags of property, which are not expressed by Go type
+func schemaAnnotations(schema *oaSchema) (annotations []string) {
+
+	if schema == nil || schema.Ref != "" {
+		return
+	}
+	if schema.Description != "" {
+		annotations = append(annotations, tagValue("desc", oneLine(schema.Description)))
+	}
+	switch schema.Format {
+	case "", "date-time", "byte", "binary", "int32", "int64", "float", "double":
+	default:
+		annotations = append(annotations, tagValue("format", schema.Format))
+	}
+	if schema.Example != nil {
+		if example, ok := schema.Example.(string); ok {
+			annotations = append(annotations, tagValue("example", example))
+		} else if data, err := json.Marshal(schema.Example); err == nil {
+			annotations = append(annotations, tagValue("example", string(data)))
+		}
+	}
+	return
+}
+
+// tagValue renders annotation with value quoted for tags scanner
+func tagValue(key, value string) string {
+
+	value = strings.ReplaceAll(value, "`", "'")
+	if value == "" || strings.ContainsAny(value, " =|,") {
+		return key + "=`" + value + "`"
+	}
+	return key + "=" + value
+}
+
+func oneLine(text string) string {
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// goName returns exported Go identifier for name from document
+func goName(name string) string {
+
+	name = utils.ToCamel(strings.TrimSpace(nonIdentifier.ReplaceAllString(name, " ")))
+	if name == "" {
+		return "Value"
+	}
+	if name[0] >= '0' && name[0] <= '9' {
+		return "V" + name
+	}
+	return name
+}
+
+// varName returns name of argument or result for name from document
+func varName(name string) string {
+
+	name = utils.ToLowerCamel(goName(name))
+	if token.IsKeyword(name) || name == "ctx" || name == "err" {
+		return name + "Value"
+	}
+	return name
+}
+
+func sortedKeys[V any](values map[string]V) (keys []string) {
+
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return
+}
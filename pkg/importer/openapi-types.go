@@ -0,0 +1,169 @@
+package importer
+
+import (
+	"gopkg.in/yaml.v3"
+)
+
+type oaObject struct {
+	OpenAPI    string                  `yaml:"openapi"`
+	Info       oaInfo                  `yaml:"info"`
+	Servers    []oaServer              `yaml:"servers"`
+	Tags       []oaTag                 `yaml:"tags"`
+	Paths      map[string]oaPath       `yaml:"paths"`
+	Security   []oaSecurityRequirement `yaml:"security"`
+	Components oaComponents            `yaml:"components"`
+}
+
+type oaInfo struct {
+	Title       string `yaml:"title"`
+	Description string `yaml:"description"`
+	Version     string `yaml:"version"`
+}
+
+type oaServer struct {
+	URL         string `yaml:"url"`
+	Description string `yaml:"description"`
+}
+
+type oaTag struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+}
+
+type oaComponents struct {
+	Schemas         map[string]*oaSchema        `yaml:"schemas"`
+	Parameters      map[string]*oaParameter     `yaml:"parameters"`
+	RequestBodies   map[string]*oaRequestBody   `yaml:"requestBodies"`
+	Responses       map[string]*oaResponse      `yaml:"responses"`
+	SecuritySchemes map[string]oaSecurityScheme `yaml:"securitySchemes"`
+}
+
+type oaPath struct {
+	Parameters []*oaParameter `yaml:"parameters"`
+	Get        *oaOperation   `yaml:"get"`
+	Put        *oaOperation   `yaml:"put"`
+	Post       *oaOperation   `yaml:"post"`
+	Delete     *oaOperation   `yaml:"delete"`
+	Patch      *oaOperation   `yaml:"patch"`
+	Head       *oaOperation   `yaml:"head"`
+	Options    *oaOperation   `yaml:"options"`
+	Trace      *oaOperation   `yaml:"trace"`
+}
+
+type oaOperation struct {
+	Tags        []string                 `yaml:"tags"`
+	Summary     string                   `yaml:"summary"`
+	Description string                   `yaml:"description"`
+	OperationID string                   `yaml:"operationId"`
+	Parameters  []*oaParameter           `yaml:"parameters"`
+	RequestBody *oaRequestBody           `yaml:"requestBody"`
+	Responses   map[string]*oaResponse   `yaml:"responses"`
+	Deprecated  bool                     `yaml:"deprecated"`
+	Security    *[]oaSecurityRequirement `yaml:"security"`
+}
+
+type oaParameter struct {
+	Ref         string    `yaml:"$ref"`
+	Name        string    `yaml:"name"`
+	In          string    `yaml:"in"`
+	Description string    `yaml:"description"`
+	Required    bool      `yaml:"required"`
+	Schema      *oaSchema `yaml:"schema"`
+}
+
+type oaRequestBody struct {
+	Ref         string             `yaml:"$ref"`
+	Description string             `yaml:"description"`
+	Required    bool               `yaml:"required"`
+	Content     map[string]oaMedia `yaml:"content"`
+}
+
+type oaResponse struct {
+	Ref         string              `yaml:"$ref"`
+	Description string              `yaml:"description"`
+	Headers     map[string]oaHeader `yaml:"headers"`
+	Content     map[string]oaMedia  `yaml:"content"`
+}
+
+type oaHeader struct {
+	Description string    `yaml:"description"`
+	Schema      *oaSchema `yaml:"schema"`
+}
+
+type oaMedia struct {
+	Schema *oaSchema `yaml:"schema"`
+}
+
+type oaSecurityScheme struct {
+	Type             string       `yaml:"type"`
+	Description      string       `yaml:"description"`
+	Name             string       `yaml:"name"`
+	In               string       `yaml:"in"`
+	Scheme           string       `yaml:"scheme"`
+	BearerFormat     string       `yaml:"bearerFormat"`
+	Flows            oaOAuthFlows `yaml:"flows"`
+	OpenIDConnectURL string       `yaml:"openIdConnectUrl"`
+}
+
+type oaOAuthFlows struct {
+	Implicit          *oaOAuthFlow `yaml:"implicit"`
+	Password          *oaOAuthFlow `yaml:"password"`
+	ClientCredentials *oaOAuthFlow `yaml:"clientCredentials"`
+	AuthorizationCode *oaOAuthFlow `yaml:"authorizationCode"`
+}
+
+type oaOAuthFlow struct {
+	AuthorizationURL string            `yaml:"authorizationUrl"`
+	TokenURL         string            `yaml:"tokenUrl"`
+	Scopes           map[string]string `yaml:"scopes"`
+}
+
+type oaSecurityRequirement map[string][]string
+
+type oaSchema struct {
+	Ref                  string               `yaml:"$ref"`
+	Type                 oaTypes              `yaml:"type"`
+	Format               string               `yaml:"format"`
+	Description          string               `yaml:"description"`
+	Properties           map[string]*oaSchema `yaml:"properties"`
+	Required             []string             `yaml:"required"`
+	Items                *oaSchema            `yaml:"items"`
+	AllOf                []*oaSchema          `yaml:"allOf"`
+	OneOf                []*oaSchema          `yaml:"oneOf"`
+	AnyOf                []*oaSchema          `yaml:"anyOf"`
+	Enum                 []interface{}        `yaml:"enum"`
+	Nullable             bool                 `yaml:"nullable"`
+	Example              interface{}          `yaml:"example"`
+	AdditionalProperties oaAdditional         `yaml:"additionalProperties"`
+}
+
+// oaTypes is type of schema, OpenAPI 3.1 allows list of types
+type oaTypes []string
+
+func (t *oaTypes) UnmarshalYAML(node *yaml.Node) error {
+
+	if node.Kind == yaml.SequenceNode {
+		return node.Decode((*[]string)(t))
+	}
+	*t = oaTypes{node.Value}
+	return nil
+}
+
+// oaAdditional is value of additionalProperties, which may be boolean or schema
+type oaAdditional struct {
+	schema *oaSchema
+}
+
+func (a *oaAdditional) UnmarshalYAML(node *yaml.Node) error {
+
+	if node.Kind != yaml.MappingNode {
+		var allowed bool
+		if err := node.Decode(&allowed); err != nil || !allowed {
+			return err
+		}
+		a.schema = &oaSchema{}
+		return nil
+	}
+	a.schema = &oaSchema{}
+	return node.Decode(a.schema)
+}
@@ -0,0 +1,629 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	. "github.com/dave/jennifer/jen"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+
+	"github.com/tundrik/tg/v2/pkg/utils"
+)
+
+const (
+	tagLog         = "log"
+	tagDesc        = "desc"
+	tagRequired    = "required"
+	tagOptional    = "optional"
+	tagSecurity    = "security"
+	tagHttpArg     = "http-args"
+	tagHttpPath    = "http-path"
+	tagDeprecated  = "deprecated"
+	tagMethodHTTP  = "http-method"
+	tagServerHTTP  = "http-server"
+	tagHttpHeader  = "http-headers"
+	tagHttpCookies = "http-cookies"
+	tagHttpSuccess = "http-success"
+	tagUploadVars  = "http-upload"
+	tagSwaggerTags = "swaggerTags"
+
+	securityNone     = "none"
+	contentMultipart = "multipart/form-data"
+
+	refParameters    = "#/components/parameters/"
+	refRequestBodies = "#/components/requestBodies/"
+	refResponses     = "#/components/responses/"
+)
+
+type openapi struct {
+	log       logrus.FieldLogger
+	doc       oaObject
+	typesPkg  string
+	typeNames map[string]bool
+	// inlineTypes are names of types declared for inline schemas
+	inlineTypes map[*oaSchema]string
+	types       []Code
+}
+
+type importService struct {
+	name        string
+	tag         string
+	methods     []*importMethod
+	methodNames map[string]bool
+}
+
+type importMethod struct {
+	name        string
+	comments    []string
+	annotations [][]string
+	args        []Code
+	results     []Code
+	varNames    map[string]bool
+}
+
+// ImportOpenAPI generates types of components and annotated service interface for each tag of OpenAPI document
+func ImportOpenAPI(log logrus.FieldLogger, specPath, outDir string) (err error) {
+
+	imp := &openapi{log: log, typeNames: make(map[string]bool), inlineTypes: make(map[*oaSchema]string)}
+	if err = imp.load(specPath); err != nil {
+		return
+	}
+	typesDir := path.Join(outDir, "types")
+	serviceDir := path.Join(outDir, "service")
+	for _, dir := range []string{typesDir, serviceDir} {
+		if err = os.MkdirAll(dir, 0777); err != nil {
+			return
+		}
+	}
+	if imp.typesPkg, err = utils.GetPkgPath(typesDir, true); err != nil {
+		return
+	}
+	for _, name := range sortedKeys(imp.doc.Components.Schemas) {
+		imp.typeNames[typeName(name)] = true
+	}
+	for _, name := range sortedKeys(imp.doc.Components.Schemas) {
+		imp.declare(typeName(name), imp.doc.Components.Schemas[name])
+	}
+	services := imp.services()
+	if len(imp.types) != 0 {
+		typesFile := NewFilePathName(imp.typesPkg, "types")
+		for _, code := range imp.types {
+			typesFile.Add(code)
+		}
+		log.Info("write to ", path.Join(typesDir, "types.go"))
+		if err = typesFile.Save(path.Join(typesDir, "types.go")); err != nil {
+			return
+		}
+	}
+	log.Info("write to ", path.Join(serviceDir, "service.go"))
+	return imp.renderServices(services).Save(path.Join(serviceDir, "service.go"))
+}
+
+func (imp *openapi) load(specPath string) (err error) {
+
+	var data []byte
+	if data, err = ioutil.ReadFile(specPath); err != nil {
+		return
+	}
+	if strings.ToLower(filepath.Ext(specPath)) == ".json" {
+		var value interface{}
+		if err = json.Unmarshal(data, &value); err != nil {
+			return
+		}
+		if data, err = yaml.Marshal(value); err != nil {
+			return
+		}
+	}
+	if err = yaml.Unmarshal(data, &imp.doc); err != nil {
+		return
+	}
+	if !strings.HasPrefix(imp.doc.OpenAPI, "3.") {
+		return fmt.Errorf("unsupported version '%s' of OpenAPI document, 3.x is expected", imp.doc.OpenAPI)
+	}
+	return
+}
+
+// services groups operations to services by first tag of operation, tags with the same Go name make one service
+func (imp *openapi) services() (services []*importService) {
+
+	byName := make(map[string]*importService)
+	for _, urlPath := range sortedKeys(imp.doc.Paths) {
+		pathItem := imp.doc.Paths[urlPath]
+		operations := []struct {
+			method    string
+			operation *oaOperation
+		}{
+			{"GET", pathItem.Get}, {"POST", pathItem.Post}, {"PUT", pathItem.Put}, {"PATCH", pathItem.Patch}, {"DELETE", pathItem.Delete},
+			{"HEAD", pathItem.Head}, {"OPTIONS", pathItem.Options}, {"TRACE", pathItem.Trace},
+		}
+		for _, item := range operations {
+			if item.operation == nil {
+				continue
+			}
+			if item.method == "HEAD" || item.method == "OPTIONS" || item.method == "TRACE" {
+				imp.log.WithField("path", urlPath).Warnf("method %s is not supported, skipped", item.method)
+				continue
+			}
+			tag := imp.doc.Info.Title
+			if len(item.operation.Tags) != 0 {
+				tag = item.operation.Tags[0]
+			}
+			name := goName(tag)
+			if tag == "" {
+				name = "Service"
+			}
+			svc, found := byName[name]
+			if !found {
+				svc = &importService{name: name, tag: tag, methodNames: make(map[string]bool)}
+				byName[name] = svc
+				services = append(services, svc)
+			}
+			method := imp.method(svc, item.method, urlPath, pathItem.Parameters, item.operation)
+			if tag != svc.tag && len(item.operation.Tags) < 2 {
+				method.annotate(tagValue(tagSwaggerTags, tag))
+			}
+			svc.methods = append(svc.methods, method)
+		}
+	}
+	return
+}
+
+func (imp *openapi) method(svc *importService, httpMethod, urlPath string, pathParams []*oaParameter, op *oaOperation) (m *importMethod) {
+
+	m = &importMethod{varNames: map[string]bool{"ctx": true, "err": true}}
+	m.name = operationName(httpMethod, urlPath)
+	if op.OperationID != "" {
+		m.name = goName(op.OperationID)
+	}
+	for name, i := m.name, 2; svc.methodNames[m.name]; i++ {
+		m.name = fmt.Sprintf("%s%d", name, i)
+	}
+	svc.methodNames[m.name] = true
+	log := imp.log.WithField("method", svc.name+"."+m.name)
+
+	var description []string
+	if op.Summary != "" {
+		description = append(description, tagValue("summary", oneLine(op.Summary)))
+	}
+	if op.Description != "" {
+		description = append(description, tagValue("desc", oneLine(op.Description)))
+	}
+	m.annotate(description...)
+
+	params := make(map[string]*oaParameter)
+	var paramKeys []string
+	for _, param := range append(append([]*oaParameter{}, pathParams...), op.Parameters...) {
+		if param = imp.parameter(param); param == nil {
+			continue
+		}
+		key := param.In + ":" + param.Name
+		if _, found := params[key]; !found {
+			paramKeys = append(paramKeys, key)
+		}
+		params[key] = param
+	}
+
+	var queryArgs, headers, cookies, uploads, argAnnotations []string
+	segments := strings.Split(urlPath, "/")
+	for i, segment := range segments {
+		if !strings.HasPrefix(segment, "{") || !strings.HasSuffix(segment, "}") {
+			if strings.Contains(segment, "{") {
+				log.WithField("path", urlPath).Warn("path parameters inside of segment are not supported")
+			}
+			continue
+		}
+		paramName := strings.Trim(segment, "{}")
+		param, found := params["path:"+paramName]
+		if !found {
+			param = &oaParameter{Name: paramName, In: "path", Schema: &oaSchema{Type: oaTypes{"string"}}}
+		}
+		argName := paramName
+		if !token.IsIdentifier(argName) || token.IsKeyword(argName) || m.varNames[argName] {
+			argName = m.varName(paramName)
+			log.Warnf("path parameter '%s' is renamed to '%s'", paramName, argName)
+		}
+		m.varNames[argName] = true
+		segments[i] = ":" + argName
+		m.args = append(m.args, Id(argName).Add(imp.paramType(log, param)))
+	}
+	for _, key := range paramKeys {
+		param := params[key]
+		if param.In == "path" {
+			continue
+		}
+		argName := m.varName(param.Name)
+		switch param.In {
+		case "query":
+			queryArgs = append(queryArgs, argName+"|"+param.Name)
+		case "header":
+			headers = append(headers, argName+"|"+param.Name)
+		case "cookie":
+			cookies = append(cookies, argName+"|"+param.Name)
+		default:
+			log.Warnf("parameter '%s' in '%s' is not supported, skipped", param.Name, param.In)
+			continue
+		}
+		if !param.Required {
+			argAnnotations = append(argAnnotations, argName+"."+tagOptional)
+		}
+		m.args = append(m.args, Id(argName).Add(imp.paramType(log, param)))
+	}
+
+	if requestBody := imp.requestBody(op.RequestBody); requestBody != nil {
+		mime, media := mediaType(requestBody.Content)
+		schema := media.Schema
+		switch {
+		case schema == nil:
+		case schema.Ref != "" && imp.isObject(schema):
+			argName := m.varName(typeName(schema.Ref))
+			argAnnotations = append(argAnnotations, tagValue(argName+".tags", "json:"+argName+",inline"))
+			m.args = append(m.args, Id(argName).Add(imp.schemaType(schema, "")))
+		case imp.isObject(schema):
+			properties, required, owners := imp.properties(schema)
+			for _, property := range sortedKeys(properties) {
+				argName := m.varName(property)
+				argType, pointer := imp.fieldType(properties[property], propertyTypeName(owners, property, m.name+"Request"), required[property])
+				if mime == contentMultipart && imp.resolve(properties[property]).Format == "binary" {
+					uploads = append(uploads, argName+"|"+property)
+				}
+				argAnnotations = append(argAnnotations, fieldAnnotations(argName, property, properties[property], required[property], pointer)...)
+				m.args = append(m.args, Id(argName).Add(argType))
+			}
+		default:
+			argName := m.varName("request")
+			log.Errorf("request body is not object, it is passed as property '%s' of object, wire format differs from document", argName)
+			m.comments = append(m.comments, fmt.Sprintf("WARNING: request body of document is not object, here it is property '%s' of object", argName))
+			m.args = append(m.args, Id(argName).Add(imp.schemaType(schema, m.name+"Request")))
+		}
+	}
+
+	var successCode string
+	for _, code := range sortedKeys(op.Responses) {
+		if strings.HasPrefix(code, "2") {
+			successCode = code
+			break
+		}
+	}
+	var httpSuccess []string
+	if response := imp.response(op.Responses[successCode]); response != nil {
+		if code, err := strconv.Atoi(successCode); err == nil && code != 200 {
+			httpSuccess = append(httpSuccess, tagValue(tagHttpSuccess, successCode))
+		}
+		_, media := mediaType(response.Content)
+		schema := media.Schema
+		switch {
+		case schema == nil:
+		case schema.Ref != "" && imp.isObject(schema):
+			retName := m.varName(typeName(schema.Ref))
+			argAnnotations = append(argAnnotations, tagValue(retName+".tags", "json:"+retName+",inline"))
+			m.results = append(m.results, Id(retName).Add(imp.schemaType(schema, "")))
+		case imp.isObject(schema):
+			properties, required, owners := imp.properties(schema)
+			for _, property := range sortedKeys(properties) {
+				retName := m.varName(property)
+				retType, pointer := imp.fieldType(properties[property], propertyTypeName(owners, property, m.name+"Response"), required[property])
+				argAnnotations = append(argAnnotations, fieldAnnotations(retName, property, properties[property], required[property], pointer)...)
+				m.results = append(m.results, Id(retName).Add(retType))
+			}
+		default:
+			retName := m.varName("result")
+			if imp.resolve(schema).kind() == "array" {
+				retName = m.varName("items")
+			}
+			log.Errorf("response body is not object, it is returned as property '%s' of object, wire format differs from document", retName)
+			m.comments = append(m.comments, fmt.Sprintf("WARNING: response body of document is not object, here it is property '%s' of object", retName))
+			m.results = append(m.results, Id(retName).Add(imp.schemaType(schema, m.name+"Response")))
+		}
+		for _, header := range sortedKeys(response.Headers) {
+			retName := m.varName(header)
+			headers = append(headers, retName+"|"+header)
+			m.results = append(m.results, Id(retName).String())
+		}
+	}
+
+	var errors []string
+	for _, code := range sortedKeys(op.Responses) {
+		if code == successCode || strings.HasPrefix(code, "2") {
+			continue
+		}
+		key := code
+		if code == "default" {
+			key = "defaultError"
+		} else if _, err := strconv.Atoi(code); err != nil {
+			log.Warnf("response '%s' is not supported, skipped", code)
+			continue
+		}
+		var errorType string
+		if response := imp.response(op.Responses[code]); response != nil {
+			if _, media := mediaType(response.Content); media.Schema != nil && media.Schema.Ref != "" {
+				errorType = imp.typesPkg + ":" + typeName(media.Schema.Ref)
+			}
+		}
+		if errorType == "" {
+			if key == "defaultError" {
+				continue
+			}
+			errors = append(errors, key)
+			continue
+		}
+		errors = append(errors, tagValue(key, errorType))
+	}
+
+	m.annotate(tagValue(tagMethodHTTP, httpMethod), tagValue(tagHttpPath, strings.Join(segments, "/")))
+	m.annotate(joinTag(tagHttpArg, queryArgs), joinTag(tagHttpHeader, headers), joinTag(tagHttpCookies, cookies), joinTag(tagUploadVars, uploads))
+	m.annotate(httpSuccess...)
+	m.annotate(argAnnotations...)
+	m.annotate(errors...)
+	if op.Security != nil {
+		m.annotate(tagValue(tagSecurity, securityValue(*op.Security)))
+	}
+	if len(op.Tags) > 1 {
+		m.annotate(tagValue(tagSwaggerTags, strings.Join(op.Tags, ",")))
+	}
+	if op.Deprecated {
+		m.annotate(tagDeprecated)
+	}
+	return
+}
+
+// annotate adds line of annotations to method
+func (m *importMethod) annotate(annotations ...string) {
+
+	var line []string
+	for _, annotation := range annotations {
+		if annotation != "" {
+			line = append(line, annotation)
+		}
+	}
+	if len(line) != 0 {
+		m.annotations = append(m.annotations, line)
+	}
+}
+
+// varName returns unique name of argument or result
+func (m *importMethod) varName(name string) string {
+
+	name = varName(name)
+	unique := name
+	for i := 2; m.varNames[unique]; i++ {
+		unique = fmt.Sprintf("%s%d", name, i)
+	}
+	m.varNames[unique] = true
+	return unique
+}
+
+// fieldAnnotations returns annotations of argument or result for property of body
+func fieldAnnotations(varName, property string, schema *oaSchema, required, pointer bool) (annotations []string) {
+
+	if varName != property {
+		annotations = append(annotations, tagValue(varName+".tags", "json:"+property))
+	}
+	if !required && !pointer {
+		annotations = append(annotations, varName+"."+tagOptional)
+	}
+	if required && pointer {
+		annotations = append(annotations, varName+"."+tagRequired)
+	}
+	for _, annotation := range schemaAnnotations(schema) {
+		annotations = append(annotations, varName+"."+annotation)
+	}
+	return
+}
+
+func (imp *openapi) paramType(log logrus.FieldLogger, param *oaParameter) *Statement {
+
+	schema := imp.resolve(param.Schema)
+	switch schema.kind() {
+	case "integer":
+		if schema.Format == "int64" {
+			return Int64()
+		}
+		return Int()
+	case "number":
+		return Float64()
+	case "boolean":
+		return Bool()
+	case "string", "":
+		if schema.Format == "date-time" {
+			return Qual("time", "Time")
+		}
+		return String()
+	}
+	log.Warnf("parameter '%s' of type '%s' is passed as string", param.Name, schema.kind())
+	return String()
+}
+
+func (imp *openapi) parameter(param *oaParameter) *oaParameter {
+
+	for depth := 0; param != nil && param.Ref != "" && depth < 10; depth++ {
+		param = imp.doc.Components.Parameters[strings.TrimPrefix(param.Ref, refParameters)]
+	}
+	return param
+}
+
+func (imp *openapi) requestBody(requestBody *oaRequestBody) *oaRequestBody {
+
+	for depth := 0; requestBody != nil && requestBody.Ref != "" && depth < 10; depth++ {
+		requestBody = imp.doc.Components.RequestBodies[strings.TrimPrefix(requestBody.Ref, refRequestBodies)]
+	}
+	return requestBody
+}
+
+func (imp *openapi) response(response *oaResponse) *oaResponse {
+
+	for depth := 0; response != nil && response.Ref != "" && depth < 10; depth++ {
+		response = imp.doc.Components.Responses[strings.TrimPrefix(response.Ref, refResponses)]
+	}
+	return response
+}
+
+// mediaType returns JSON content of body or first content otherwise
+func mediaType(content map[string]oaMedia) (mime string, media oaMedia) {
+
+	for _, mime = range sortedKeys(content) {
+		if strings.Contains(mime, "json") {
+			return mime, content[mime]
+		}
+	}
+	for _, mime = range sortedKeys(content) {
+		return mime, content[mime]
+	}
+	return
+}
+
+func operationName(httpMethod, urlPath string) string {
+
+	words := []string{strings.ToLower(httpMethod)}
+	for _, segment := range strings.Split(urlPath, "/") {
+		if strings.HasPrefix(segment, "{") {
+			words = append(words, "by", strings.Trim(segment, "{}"))
+			continue
+		}
+		words = append(words, segment)
+	}
+	return goName(strings.Join(words, " "))
+}
+
+func joinTag(key string, pairs []string) string {
+
+	if len(pairs) == 0 {
+		return ""
+	}
+	return tagValue(key, strings.Join(pairs, ","))
+}
+
+// securityValue renders requirements of security: alternatives are separated by '|', schemes required together by '+'
+func securityValue(requirements []oaSecurityRequirement) string {
+
+	var alternatives []string
+	for _, requirement := range requirements {
+		var schemes []string
+		for _, name := range sortedKeys(requirement) {
+			if scopes := requirement[name]; len(scopes) != 0 {
+				schemes = append(schemes, name+":"+strings.Join(scopes, " "))
+				continue
+			}
+			schemes = append(schemes, name)
+		}
+		if len(schemes) != 0 {
+			alternatives = append(alternatives, strings.Join(schemes, "+"))
+		}
+	}
+	if len(alternatives) == 0 {
+		return securityNone
+	}
+	return strings.Join(alternatives, "|")
+}
+
+// securitySchemes renders package annotations of security schemes
+func (imp *openapi) securitySchemes() (annotations []string) {
+
+	for _, name := range sortedKeys(imp.doc.Components.SecuritySchemes) {
+		scheme := imp.doc.Components.SecuritySchemes[name]
+		var value string
+		var scopes map[string]string
+		switch scheme.Type {
+		case "http":
+			switch strings.ToLower(scheme.Scheme) {
+			case "bearer":
+				value = strings.TrimSuffix("bearer|"+scheme.BearerFormat, "|")
+			case "basic":
+				value = "basic"
+			}
+		case "apiKey":
+			value = "apiKey|" + scheme.In + "|" + scheme.Name
+		case "oauth2":
+			flows := scheme.Flows
+			switch {
+			case flows.ClientCredentials != nil:
+				value, scopes = "oauth2|clientCredentials|"+flows.ClientCredentials.TokenURL, flows.ClientCredentials.Scopes
+			case flows.AuthorizationCode != nil:
+				value, scopes = "oauth2|authorizationCode|"+flows.AuthorizationCode.AuthorizationURL+"|"+flows.AuthorizationCode.TokenURL, flows.AuthorizationCode.Scopes
+			case flows.Password != nil:
+				value, scopes = "oauth2|password|"+flows.Password.TokenURL, flows.Password.Scopes
+			case flows.Implicit != nil:
+				value, scopes = "oauth2|implicit|"+flows.Implicit.AuthorizationURL, flows.Implicit.Scopes
+			}
+		case "openIdConnect":
+			value = "openIdConnect|" + scheme.OpenIDConnectURL
+		}
+		if value == "" {
+			imp.log.WithField("scheme", name).Warnf("security scheme '%s' is not supported, skipped", scheme.Type)
+			continue
+		}
+		annotations = append(annotations, tagValue(tagSecurity+"."+name, value))
+		if scheme.Description != "" {
+			annotations = append(annotations, tagValue(tagSecurity+"."+name+"."+tagDesc, oneLine(scheme.Description)))
+		}
+		if len(scopes) != 0 {
+			var scopeValues []string
+			for _, scope := range sortedKeys(scopes) {
+				scopeValues = append(scopeValues, scope+";"+strings.NewReplacer("|", " ", ";", ",").Replace(oneLine(scopes[scope])))
+			}
+			annotations = append(annotations, tagValue(tagSecurity+"."+name+".scopes", strings.Join(scopeValues, "|")))
+		}
+	}
+	return
+}
+
+func (imp *openapi) renderServices(services []*importService) *File {
+
+	srcFile := NewFile("service")
+
+	info := []string{tagValue("title", oneLine(imp.doc.Info.Title))}
+	if imp.doc.Info.Version != "" {
+		info = append(info, tagValue("version", imp.doc.Info.Version))
+	}
+	srcFile.PackageComment("@tg " + strings.Join(info, " "))
+	if imp.doc.Info.Description != "" {
+		srcFile.PackageComment("@tg " + tagValue("description", oneLine(imp.doc.Info.Description)))
+	}
+	if len(imp.doc.Servers) != 0 {
+		var servers []string
+		for _, server := range imp.doc.Servers {
+			servers = append(servers, strings.TrimSuffix(server.URL+";"+oneLine(server.Description), ";"))
+		}
+		srcFile.PackageComment("@tg " + tagValue("servers", strings.Join(servers, "|")))
+	}
+	for _, annotation := range imp.securitySchemes() {
+		srcFile.PackageComment("@tg " + annotation)
+	}
+	if imp.doc.Security != nil {
+		srcFile.PackageComment("@tg " + tagValue(tagSecurity, securityValue(imp.doc.Security)))
+	}
+
+	tagDescriptions := make(map[string]string)
+	for _, tag := range imp.doc.Tags {
+		tagDescriptions[tag.Name] = tag.Description
+	}
+	for _, svc := range services {
+		srcFile.Line()
+		if description := tagDescriptions[svc.tag]; description != "" {
+			srcFile.Comment(svc.name + " " + oneLine(description))
+		}
+		srcFile.Comment("@tg " + tagServerHTTP + " " + tagLog)
+		if svc.tag != svc.name && svc.tag != "" {
+			srcFile.Comment("@tg " + tagValue(tagSwaggerTags, svc.tag))
+		}
+		srcFile.Type().Id(svc.name).InterfaceFunc(func(ig *Group) {
+			for _, m := range svc.methods {
+				for _, comment := range m.comments {
+					ig.Comment(comment)
+				}
+				for _, line := range m.annotations {
+					ig.Comment("@tg " + strings.Join(line, " "))
+				}
+				ig.Id(m.name).Params(append([]Code{Id("ctx").Qual("context", "Context")}, m.args...)...).Params(append(m.results, Err().Error())...)
+			}
+		})
+	}
+	return srcFile
+}
@@ -0,0 +1,236 @@
+package importer
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+
+	"github.com/tundrik/tg/v2/pkg/generator"
+)
+
+const testModule = "example.com/tgimport"
+
+const testSpec = `openapi: 3.0.3
+info:
+  title: Pets
+  version: 1.0.0
+tags:
+  - name: pets
+    description: pets of store
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      tags: [pets]
+      parameters:
+        - name: active
+          in: query
+          schema:
+            type: boolean
+        - name: minWeight
+          in: query
+          schema:
+            type: number
+      responses:
+        "200":
+          description: list of pets
+          content:
+            application/json:
+              schema:
+                type: array
+                items:
+                  $ref: "#/components/schemas/Pet"
+    post:
+      operationId: createPet
+      tags: [pets]
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: "#/components/schemas/Pet"
+      responses:
+        "201":
+          description: created pet
+          content:
+            application/json:
+              schema:
+                $ref: "#/components/schemas/Pet"
+  /pets/{petId}:
+    get:
+      operationId: getPet
+      parameters:
+        - name: petId
+          in: path
+          required: true
+          schema:
+            type: integer
+            format: int64
+      responses:
+        "200":
+          description: pet
+          content:
+            application/json:
+              schema:
+                $ref: "#/components/schemas/Pet"
+components:
+  schemas:
+    NewPet:
+      type: object
+      required: [name]
+      properties:
+        name:
+          type: string
+        tag:
+          type: string
+        kind:
+          type: string
+          enum: [cat, dog]
+    Pet:
+      allOf:
+        - $ref: "#/components/schemas/NewPet"
+        - type: object
+          required: [id]
+          properties:
+            id:
+              type: integer
+              format: int64
+`
+
+func TestImportOpenAPIRoundTrip(t *testing.T) {
+
+	modDir := t.TempDir()
+	files := map[string]string{"go.mod": "module " + testModule + "\n\ngo 1.22\n", "spec.yaml": testSpec}
+	for fileName, content := range files {
+		if err := os.WriteFile(filepath.Join(modDir, fileName), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	log, hook := test.NewNullLogger()
+	outDir := filepath.Join(modDir, "api")
+	if err := ImportOpenAPI(log, filepath.Join(modDir, "spec.yaml"), outDir); err != nil {
+		t.Fatal(err)
+	}
+	service := readFile(t, filepath.Join(outDir, "service", "service.go"))
+	if count := strings.Count(service, "type Pets interface"); count != 1 {
+		t.Errorf("service Pets is declared %d times:\n%s", count, service)
+	}
+	for _, fragment := range []string{
+		"// @tg swaggerTags=pets\ntype Pets interface",
+		"// @tg swaggerTags=Pets\n\tGetPet(",
+		"// WARNING: response body of document is not object, here it is property 'items' of object\n",
+	} {
+		if !strings.Contains(service, fragment) {
+			t.Errorf("%q not found in:\n%s", fragment, service)
+		}
+	}
+	if !strings.Contains(service, "ListPets(ctx context.Context, active bool, minWeight float64)") {
+		t.Errorf("boolean and number parameters are not imported as bool and float64:\n%s", service)
+	}
+	typesFile := readFile(t, filepath.Join(outDir, "types", "types.go"))
+	if strings.Contains(typesFile, "type PetKind ") || !strings.Contains(typesFile, "Kind NewPetKind") {
+		t.Errorf("enum of allOf part is not reused:\n%s", typesFile)
+	}
+	var arrayErrors int
+	for _, entry := range hook.AllEntries() {
+		if entry.Level == logrus.ErrorLevel && strings.Contains(entry.Message, "response body is not object") {
+			arrayErrors++
+		}
+	}
+	if arrayErrors != 1 {
+		t.Errorf("expected one error about array response, got %d", arrayErrors)
+	}
+	typeCheck(t, outDir, "types", "service")
+
+	genLog := logrus.New()
+	genLog.SetOutput(io.Discard)
+	tr, err := generator.NewTransport(genLog, filepath.Join(outDir, "service"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	wd, _ := os.Getwd()
+	if err = os.Chdir(modDir); err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = os.Chdir(wd) }()
+	swaggerPath := filepath.Join(modDir, "swagger.json")
+	if err = tr.RenderSwagger(swaggerPath, ""); err != nil {
+		t.Fatal(err)
+	}
+	var doc struct {
+		Paths map[string]map[string]struct {
+			Tags []string `json:"tags"`
+		} `json:"paths"`
+	}
+	if err = json.Unmarshal([]byte(readFile(t, swaggerPath)), &doc); err != nil {
+		t.Fatal(err)
+	}
+	for urlPath, methods := range map[string]map[string]string{
+		"/pets":         {"get": "pets", "post": "pets"},
+		"/pets/{petId}": {"get": "Pets"},
+	} {
+		for httpMethod, tag := range methods {
+			if operation, found := doc.Paths[urlPath][httpMethod]; !found || strings.Join(operation.Tags, ",") != tag {
+				t.Errorf("%s %s: tags %v, want %s", httpMethod, urlPath, operation.Tags, tag)
+			}
+		}
+	}
+}
+
+func readFile(t *testing.T, filePath string) string {
+
+	t.Helper()
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+// typeCheck checks imported packages, they depend only on standard library and each other
+func typeCheck(t *testing.T, outDir string, pkgNames ...string) {
+
+	t.Helper()
+	fset := token.NewFileSet()
+	packages := make(map[string]*types.Package)
+	std := importer.Default()
+	imp := importerFunc(func(pkgPath string) (*types.Package, error) {
+		if pkg, found := packages[pkgPath]; found {
+			return pkg, nil
+		}
+		return std.Import(pkgPath)
+	})
+	for _, pkgName := range pkgNames {
+		fileNames, _ := filepath.Glob(filepath.Join(outDir, pkgName, "*.go"))
+		var files []*ast.File
+		for _, fileName := range fileNames {
+			file, err := parser.ParseFile(fset, fileName, nil, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			files = append(files, file)
+		}
+		conf := types.Config{Importer: imp}
+		pkg, err := conf.Check(testModule+"/api/"+pkgName, fset, files, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		packages[pkg.Path()] = pkg
+	}
+}
+
+type importerFunc func(pkgPath string) (*types.Package, error)
+
+func (f importerFunc) Import(pkgPath string) (*types.Package, error) {
+	return f(pkgPath)
+}
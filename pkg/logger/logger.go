@@ -8,7 +8,7 @@ import (
 
 	"github.com/sirupsen/logrus"
 
-	"github.com/tundrik/tg/pkg/logger/format"
+	"github.com/tundrik/tg/v2/pkg/logger/format"
 )
 
 var Log Logger
@@ -18,9 +18,9 @@ import (
 
 	. "github.com/dave/jennifer/jen"
 
-	"github.com/tundrik/tg/pkg/logger"
-	"github.com/tundrik/tg/pkg/tags"
-	"github.com/tundrik/tg/pkg/utils"
+	"github.com/tundrik/tg/v2/pkg/logger"
+	"github.com/tundrik/tg/v2/pkg/tags"
+	"github.com/tundrik/tg/v2/pkg/utils"
 )
 
 var log = logger.Log.WithField("module", "skeleton")
@@ -16,7 +16,7 @@ import (
 
 	"github.com/pkg/errors"
 
-	"github.com/tundrik/tg/pkg/logger"
+	"github.com/tundrik/tg/v2/pkg/logger"
 )
 
 var (
@@ -0,0 +1,30 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Converts a string to snake_case, abbreviations are kept as one word (fileID -> file_id, HTTPServer -> http_server)
+func ToSnake(s string) string {
+
+	runes := []rune(strings.TrimSpace(s))
+	var n strings.Builder
+	for i, v := range runes {
+		if v == '-' || v == ' ' || v == '_' {
+			if n.Len() != 0 && !strings.HasSuffix(n.String(), "_") {
+				n.WriteRune('_')
+			}
+			continue
+		}
+		if unicode.IsUpper(v) && i > 0 && n.Len() != 0 && !strings.HasSuffix(n.String(), "_") {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				n.WriteRune('_')
+			}
+		}
+		n.WriteRune(unicode.ToLower(v))
+	}
+	return n.String()
+}